@@ -25,11 +25,19 @@ func (m Message) TagType() byte {
 }
 
 func (m Message) MarshalNBT(w io.Writer) error {
+	var data []byte
+	var err error
 	if m.Translate != "" {
-		return nbt.NewEncoder(w).Encode(translateMsg(m), "")
+		data, err = nbt.Marshal(translateMsg(m))
 	} else {
-		return nbt.NewEncoder(w).Encode(rawMsgStruct(m), "")
+		data, err = nbt.Marshal(rawMsgStruct(m))
 	}
+	if err != nil {
+		return err
+	}
+	// MarshalNBT only writes the payload. Skip the tag type and the empty tag name.
+	_, err = w.Write(data[3:])
+	return err
 }
 
 func (m *Message) UnmarshalNBT(tagType byte, r nbt.DecoderReader) error {
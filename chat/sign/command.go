@@ -0,0 +1,112 @@
+package sign
+
+import (
+	"errors"
+	"io"
+
+	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
+)
+
+// Argument is a signable argument of a command, such as the message of "/say".
+// Each of them is signed as a message with the Value as its content.
+type Argument struct {
+	Name  string
+	Value string
+}
+
+// ArgumentSignature is the signature of an argument by its name.
+type ArgumentSignature struct {
+	Name      string
+	Signature Signature
+}
+
+// ArgumentSignatures is the signatures of a command's arguments in the ServerboundChatCommandSigned packet.
+type ArgumentSignatures []ArgumentSignature
+
+// MaxArgumentSignatures is the max number of the signed arguments of a command.
+const MaxArgumentSignatures = 8
+
+func (a ArgumentSignatures) WriteTo(w io.Writer) (n int64, err error) {
+	n, err = pk.VarInt(len(a)).WriteTo(w)
+	for _, s := range a {
+		if err != nil {
+			return
+		}
+		var nn int64
+		nn, err = pk.Tuple{pk.String(s.Name), s.Signature}.WriteTo(w)
+		n += nn
+	}
+	return
+}
+
+func (a *ArgumentSignatures) ReadFrom(r io.Reader) (n int64, err error) {
+	var length pk.VarInt
+	if n, err = length.ReadFrom(r); err != nil {
+		return
+	}
+	if length < 0 || length > MaxArgumentSignatures {
+		return n, errors.New("sign: too many argument signatures")
+	}
+	*a = make(ArgumentSignatures, length)
+	for i := range *a {
+		s := &(*a)[i]
+		nn, err := pk.Tuple{(*pk.String)(&s.Name), &s.Signature}.ReadFrom(r)
+		n += nn
+		if err != nil {
+			return n, err
+		}
+	}
+	return
+}
+
+// SignArguments signs the arguments of a command, like Sign signs each of them with the Value as the content.
+// The arguments share the Timestamp, Salt and LastSeen of the body, which are sent with the command.
+// The Timestamp and Salt are set if they are zero, and the PlainMsg of the body is ignored.
+func (s *Signer) SignArguments(body *MessageBody, args []Argument) (ArgumentSignatures, error) {
+	if len(args) > MaxArgumentSignatures {
+		return nil, errors.New("sign: too many arguments to sign")
+	}
+	signatures := make(ArgumentSignatures, len(args))
+	for i, arg := range args {
+		b := *body
+		b.PlainMsg = arg.Value
+		msg, err := s.Sign(&b)
+		if err != nil {
+			return nil, err
+		}
+		// The first signing sets the timestamp and salt for the rest.
+		body.Timestamp, body.Salt = b.Timestamp, b.Salt
+		signatures[i] = ArgumentSignature{Name: arg.Name, Signature: *msg.Signature}
+	}
+	return signatures, nil
+}
+
+// VerifyArguments verifies the signatures of the command's arguments by VerifyAndUpdate,
+// and returns the signed messages of the arguments, which can be broadcast like the chat messages.
+// The prev is the link of the first argument, and each following argument has the next index.
+//
+// Every argument must have a signature, otherwise the verification fails.
+func (s *Session) VerifyArguments(prev Prev, body *MessageBody, args []Argument, signatures ArgumentSignatures) ([]*Message, bool) {
+	if len(args) != len(signatures) {
+		s.valid = false
+		return nil, false
+	}
+	msgs := make([]*Message, len(args))
+	for i, arg := range args {
+		var signature *Signature
+		for j := range signatures {
+			if signatures[j].Name == arg.Name {
+				signature = &signatures[j].Signature
+				break
+			}
+		}
+		b := *body
+		b.PlainMsg = arg.Value
+		msgs[i] = &Message{Prev: prev, Signature: signature, MessageBody: &b}
+		if !s.VerifyAndUpdate(msgs[i]) {
+			return nil, false
+		}
+		prev.Index++
+	}
+	return msgs, true
+}
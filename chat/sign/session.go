@@ -57,8 +57,10 @@ func (s *Session) InitValidate() {
 	s.lastMsg = nil
 }
 
+// VerifyAndUpdate verifies the signature of the message and whether it follows the last message.
+// Once a message fails, the session is invalid and all following messages fail until InitValidate is called.
 func (s *Session) VerifyAndUpdate(msg *Message) bool {
-	s.valid = s.valid && s.verifyHash(msg) && s.verifyChain(msg)
+	s.valid = s.valid && msg.Signature != nil && s.verifyChain(msg) && s.verifyHash(msg)
 	if s.valid {
 		s.lastMsg = msg
 		return true
@@ -67,13 +69,18 @@ func (s *Session) VerifyAndUpdate(msg *Message) bool {
 }
 
 func (s *Session) verifyHash(msg *Message) bool {
+	return s.PublicKey.VerifyMessage(messageHash(msg), msg.Signature[:]) == nil
+}
+
+// messageHash is the SHA-256 hash of the message link and body, which is signed by the sender.
+func messageHash(msg *Message) []byte {
 	h := sha256.New()
 	// 1
 	_ = binary.Write(h, binary.BigEndian, int32(1))
 	// Prev
 	_, _ = h.Write(msg.Prev.Sender[:])
 	_, _ = h.Write(msg.Prev.Session[:])
-	_ = binary.Write(h, binary.BigEndian, msg.Prev.Index)
+	_ = binary.Write(h, binary.BigEndian, int32(msg.Prev.Index))
 	// Body
 	_ = binary.Write(h, binary.BigEndian, msg.Salt)
 	_ = binary.Write(h, binary.BigEndian, msg.Timestamp.Unix())
@@ -85,9 +92,18 @@ func (s *Session) verifyHash(msg *Message) bool {
 	for _, v := range msg.LastSeen {
 		_, _ = h.Write((*v)[:])
 	}
-	return s.PublicKey.VerifyMessage(h.Sum(nil), msg.Signature[:]) == nil
+	return h.Sum(nil)
 }
 
+// verifyChain reports whether the message is a descendant of the last message,
+// and isn't sent before it.
 func (s *Session) verifyChain(msg *Message) bool {
-	return s.lastMsg != nil && (msg.Prev.Index < s.lastMsg.Prev.Index || msg.Prev.Sender != s.lastMsg.Prev.Sender || msg.Prev.Session != s.lastMsg.Prev.Session)
+	if s.lastMsg == nil {
+		return true
+	}
+	last := s.lastMsg
+	return msg.Prev.Index > last.Prev.Index &&
+		msg.Prev.Sender == last.Prev.Sender &&
+		msg.Prev.Session == last.Prev.Session &&
+		!msg.Timestamp.Before(last.Timestamp)
 }
@@ -0,0 +1,93 @@
+package sign
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"git.konjactw.dev/falloutBot/go-mc/yggdrasil/user"
+)
+
+func TestSession_verifyChain(t *testing.T) {
+	sender, session := uuid.New(), uuid.New()
+	now := time.Now()
+	last := &Message{Prev: Prev{Index: 3, Sender: sender, Session: session}, MessageBody: &MessageBody{Timestamp: now}}
+	for _, tc := range []struct {
+		name string
+		prev Prev
+		time time.Time
+		want bool
+	}{
+		{"next", Prev{Index: 4, Sender: sender, Session: session}, now.Add(time.Second), true},
+		{"skip indices", Prev{Index: 10, Sender: sender, Session: session}, now.Add(time.Second), true},
+		{"same time", Prev{Index: 4, Sender: sender, Session: session}, now, true},
+		{"same index", Prev{Index: 3, Sender: sender, Session: session}, now.Add(time.Second), false},
+		{"previous index", Prev{Index: 2, Sender: sender, Session: session}, now.Add(time.Second), false},
+		{"other sender", Prev{Index: 4, Sender: uuid.New(), Session: session}, now.Add(time.Second), false},
+		{"other session", Prev{Index: 4, Sender: sender, Session: uuid.New()}, now.Add(time.Second), false},
+		{"earlier", Prev{Index: 4, Sender: sender, Session: session}, now.Add(-time.Second), false},
+	} {
+		s := Session{lastMsg: last}
+		msg := &Message{Prev: tc.prev, MessageBody: &MessageBody{Timestamp: tc.time}}
+		if got := s.verifyChain(msg); got != tc.want {
+			t.Errorf("%s: got %t, want %t", tc.name, got, tc.want)
+		}
+	}
+
+	// the first message can have any link
+	var s Session
+	if !s.verifyChain(&Message{Prev: Prev{Index: 7}, MessageBody: &MessageBody{}}) {
+		t.Error("the first message should be valid")
+	}
+}
+
+func TestSession_VerifyAndUpdate(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := NewSigner(uuid.New(), &user.KeyPair{
+		PrivateKey: key,
+		PublicKey:  user.PublicKey{ExpiresAt: time.Now().Add(time.Hour), PubKey: &key.PublicKey},
+	})
+	session := signer.Session()
+	if msg, _ := signer.Sign(&MessageBody{PlainMsg: "before init"}); session.VerifyAndUpdate(msg) {
+		t.Error("messages shouldn't be valid before InitValidate")
+	}
+	session.InitValidate()
+
+	first, err := signer.Sign(&MessageBody{PlainMsg: "first"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := signer.Sign(&MessageBody{PlainMsg: "second"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !session.VerifyAndUpdate(second) {
+		t.Fatal("second message should be valid")
+	}
+	// the first message is out of order
+	if session.VerifyAndUpdate(first) {
+		t.Error("message out of order shouldn't be valid")
+	}
+	third, err := signer.Sign(&MessageBody{PlainMsg: "third"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if session.VerifyAndUpdate(third) {
+		t.Error("session should stay invalid after a failure")
+	}
+
+	session.InitValidate()
+	if !session.VerifyAndUpdate(third) {
+		t.Error("session should be valid again after InitValidate")
+	}
+	unsigned := &Message{Prev: Prev{Index: 100, Sender: third.Prev.Sender, Session: third.Prev.Session}, MessageBody: &MessageBody{Timestamp: time.Now()}}
+	if session.VerifyAndUpdate(unsigned) {
+		t.Error("unsigned message shouldn't be valid")
+	}
+}
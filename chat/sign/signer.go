@@ -0,0 +1,82 @@
+package sign
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"git.konjactw.dev/falloutBot/go-mc/yggdrasil/user"
+)
+
+// Signer signs the chat messages of a player, which is what a client does.
+//
+// Each Signer is a chat session with a random session ID.
+// When the key pair is refreshed, create a new Signer and send its Session to the server again.
+type Signer struct {
+	sender  uuid.UUID
+	session Session
+	key     *rsa.PrivateKey
+
+	index int
+	lock  sync.Mutex
+}
+
+// NewSigner creates a Signer with the player's UUID and key pair.
+func NewSigner(sender uuid.UUID, keyPair *user.KeyPair) *Signer {
+	return &Signer{
+		sender: sender,
+		session: Session{
+			SessionID: uuid.New(),
+			PublicKey: keyPair.PublicKey,
+		},
+		key: keyPair.PrivateKey,
+	}
+}
+
+// Session returns the chat session, which is sent in the ServerboundChatSessionUpdate packet.
+func (s *Signer) Session() Session {
+	return Session{SessionID: s.session.SessionID, PublicKey: s.session.PublicKey}
+}
+
+// Expired reports whether the key of the session is expired,
+// after which the server rejects the signed messages.
+func (s *Signer) Expired() bool {
+	return s.session.PublicKey.Expired(time.Now())
+}
+
+// Sign signs the message with a random salt, and links it to the previous messages of the session.
+// The Timestamp of the body is set to now if it's zero.
+func (s *Signer) Sign(body *MessageBody) (*Message, error) {
+	if body.Timestamp.IsZero() {
+		body.Timestamp = time.Now()
+	}
+	if body.Salt == 0 {
+		var salt [8]byte
+		_, _ = rand.Read(salt[:])
+		body.Salt = int64(binary.BigEndian.Uint64(salt[:]))
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	msg := &Message{
+		Prev: Prev{
+			Index:   s.index,
+			Sender:  s.sender,
+			Session: s.session.SessionID,
+		},
+		MessageBody: body,
+	}
+	signature, err := rsa.SignPKCS1v15(nil, s.key, crypto.SHA256, messageHash(msg))
+	if err != nil {
+		return nil, err
+	}
+	msg.Signature = new(Signature)
+	copy(msg.Signature[:], signature)
+	s.index++
+	return msg, nil
+}
@@ -0,0 +1,98 @@
+package sign
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"git.konjactw.dev/falloutBot/go-mc/yggdrasil/user"
+)
+
+func TestSigner(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := NewSigner(uuid.New(), &user.KeyPair{
+		PrivateKey: key,
+		PublicKey:  user.PublicKey{ExpiresAt: time.Now().Add(time.Hour), PubKey: &key.PublicKey},
+	})
+	session := signer.Session()
+	session.InitValidate()
+
+	for _, text := range []string{"hello", "world"} {
+		msg, err := signer.Sign(&MessageBody{PlainMsg: text})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !session.VerifyAndUpdate(msg) {
+			t.Fatalf("message %q should be valid", text)
+		}
+	}
+
+	msg, err := signer.Sign(&MessageBody{PlainMsg: "tampered"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg.PlainMsg = "modified"
+	if session.VerifyAndUpdate(msg) {
+		t.Error("modified message shouldn't be valid")
+	}
+}
+
+func TestSigner_SignArguments(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sender := uuid.New()
+	signer := NewSigner(sender, &user.KeyPair{
+		PrivateKey: key,
+		PublicKey:  user.PublicKey{ExpiresAt: time.Now().Add(time.Hour), PubKey: &key.PublicKey},
+	})
+	session := signer.Session()
+	session.InitValidate()
+
+	args := []Argument{{Name: "targets", Value: "@a"}, {Name: "message", Value: "hello"}}
+	body := new(MessageBody)
+	signatures, err := signer.SignArguments(body, args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body.Timestamp.IsZero() || body.Salt == 0 {
+		t.Error("timestamp and salt should be set")
+	}
+
+	var buf bytes.Buffer
+	if _, err := signatures.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var decoded ArgumentSignatures
+	if _, err := decoded.ReadFrom(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, signatures) {
+		t.Fatal("decoded signatures differ")
+	}
+
+	prev := Prev{Index: 0, Sender: sender, Session: session.SessionID}
+	msgs, ok := session.VerifyArguments(prev, body, args, decoded)
+	if !ok || len(msgs) != 2 || msgs[1].PlainMsg != "hello" || msgs[1].Prev.Index != 1 {
+		t.Fatalf("arguments should be valid, got %v", msgs)
+	}
+
+	// the next command continues the chain
+	signatures, err = signer.SignArguments(&MessageBody{}, args[1:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := &MessageBody{Timestamp: time.Now(), Salt: 1}
+	if _, ok := session.VerifyArguments(Prev{Index: 2, Sender: sender, Session: session.SessionID}, tampered, args[1:], signatures); ok {
+		t.Error("arguments with a different body shouldn't be valid")
+	}
+}
@@ -0,0 +1,2463 @@
+// Code generated by generator/main.go; DO NOT EDIT.
+
+package metadata
+
+// Indexes of the synced fields, named after the class that declares them.
+const (
+	EntityFlags                                Index = 0
+	EntityAirSupply                            Index = 1
+	EntityCustomName                           Index = 2
+	EntityCustomNameVisible                    Index = 3
+	EntitySilent                               Index = 4
+	EntityNoGravity                            Index = 5
+	EntityPose                                 Index = 6
+	EntityTicksFrozen                          Index = 7
+	InteractionWidth                           Index = 8
+	InteractionHeight                          Index = 9
+	InteractionResponse                        Index = 10
+	DisplayInterpolationDelay                  Index = 8
+	DisplayTransformationInterpolationDuration Index = 9
+	DisplayPosRotInterpolationDuration         Index = 10
+	DisplayTranslation                         Index = 11
+	DisplayScale                               Index = 12
+	DisplayLeftRotation                        Index = 13
+	DisplayRightRotation                       Index = 14
+	DisplayBillboardConstraints                Index = 15
+	DisplayBrightnessOverride                  Index = 16
+	DisplayViewRange                           Index = 17
+	DisplayShadowRadius                        Index = 18
+	DisplayShadowStrength                      Index = 19
+	DisplayWidth                               Index = 20
+	DisplayHeight                              Index = 21
+	DisplayGlowColorOverride                   Index = 22
+	BlockDisplayBlockState                     Index = 23
+	ItemDisplayItem                            Index = 23
+	ItemDisplayItemDisplay                     Index = 24
+	TextDisplayText                            Index = 23
+	TextDisplayLineWidth                       Index = 24
+	TextDisplayBackgroundColor                 Index = 25
+	TextDisplayTextOpacity                     Index = 26
+	TextDisplayStyleFlags                      Index = 27
+	ThrowableItemProjectileItem                Index = 8
+	EyeOfEnderItem                             Index = 8
+	FallingBlockStartPos                       Index = 8
+	AreaEffectCloudRadius                      Index = 8
+	AreaEffectCloudWaiting                     Index = 9
+	AreaEffectCloudParticle                    Index = 10
+	FishingHookHookedEntity                    Index = 8
+	FishingHookBiting                          Index = 9
+	AbstractArrowFlags                         Index = 8
+	AbstractArrowPierceLevel                   Index = 9
+	AbstractArrowInGround                      Index = 10
+	ArrowEffectColor                           Index = 11
+	ThrownTridentLoyalty                       Index = 11
+	ThrownTridentFoil                          Index = 12
+	VehicleEntityHurtTime                      Index = 8
+	VehicleEntityHurtDir                       Index = 9
+	VehicleEntityDamage                        Index = 10
+	AbstractBoatPaddleLeft                     Index = 11
+	AbstractBoatPaddleRight                    Index = 12
+	AbstractBoatBubbleTime                     Index = 13
+	AbstractMinecartCustomDisplayBlock         Index = 11
+	AbstractMinecartDisplayOffset              Index = 12
+	MinecartFurnaceFuel                        Index = 13
+	MinecartCommandBlockCommandName            Index = 13
+	MinecartCommandBlockLastOutput             Index = 14
+	EndCrystalBeamTarget                       Index = 8
+	EndCrystalShowBottom                       Index = 9
+	FireballItem                               Index = 8
+	WitherSkullDangerousSkull                  Index = 8
+	FireworkRocketItem                         Index = 8
+	FireworkRocketAttachedToEntity             Index = 9
+	FireworkRocketShotAtAngle                  Index = 10
+	ItemFrameItem                              Index = 8
+	ItemFrameRotation                          Index = 9
+	PaintingVariantID                          Index = 8
+	ItemEntityItem                             Index = 8
+	OminousItemSpawnerItem                     Index = 8
+	PrimedTntFuse                              Index = 8
+	PrimedTntBlockState                        Index = 9
+	ExperienceOrbValue                         Index = 8
+	LivingEntityFlags                          Index = 8
+	LivingEntityHealth                         Index = 9
+	LivingEntityEffectParticles                Index = 10
+	LivingEntityEffectAmbience                 Index = 11
+	LivingEntityArrowCount                     Index = 12
+	LivingEntityStingerCount                   Index = 13
+	LivingEntitySleepingPos                    Index = 14
+	PlayerAbsorptionAmount                     Index = 15
+	PlayerScore                                Index = 16
+	PlayerModelCustomisation                   Index = 17
+	PlayerMainHand                             Index = 18
+	PlayerShoulderLeft                         Index = 19
+	PlayerShoulderRight                        Index = 20
+	ArmorStandClientFlags                      Index = 15
+	ArmorStandHeadPose                         Index = 16
+	ArmorStandBodyPose                         Index = 17
+	ArmorStandLeftArmPose                      Index = 18
+	ArmorStandRightArmPose                     Index = 19
+	ArmorStandLeftLegPose                      Index = 20
+	ArmorStandRightLegPose                     Index = 21
+	MobFlags                                   Index = 15
+	BatFlags                                   Index = 16
+	EnderDragonPhase                           Index = 16
+	GhastCharging                              Index = 16
+	PhantomSize                                Index = 16
+	SlimeSize                                  Index = 16
+	AllayDancing                               Index = 16
+	AllayCanDuplicate                          Index = 17
+	AbstractFishFromBucket                     Index = 16
+	SalmonType                                 Index = 17
+	PufferfishPuffState                        Index = 17
+	TropicalFishTypeVariant                    Index = 17
+	AgeableMobBaby                             Index = 16
+	GlowSquidDarkTicksRemaining                Index = 17
+	DolphinTreasurePos                         Index = 17
+	DolphinGotFish                             Index = 18
+	DolphinMoistnessLevel                      Index = 19
+	AbstractVillagerUnhappyCounter             Index = 17
+	VillagerVillagerData                       Index = 18
+	SnifferCurrentState                        Index = 17
+	SnifferDropSeedAtTick                      Index = 18
+	AbstractHorseFlags                         Index = 17
+	HorseTypeVariant                           Index = 18
+	CamelDash                                  Index = 18
+	CamelLastPoseChangeTick                    Index = 19
+	AbstractChestedHorseChest                  Index = 18
+	LlamaStrength                              Index = 19
+	LlamaVariant                               Index = 20
+	AxolotlVariant                             Index = 17
+	AxolotlPlayingDead                         Index = 18
+	AxolotlFromBucket                          Index = 19
+	BeeFlags                                   Index = 17
+	BeeRemainingAngerTime                      Index = 18
+	FoxType                                    Index = 17
+	FoxFlags                                   Index = 18
+	FoxTrustedID0                              Index = 19
+	FoxTrustedID1                              Index = 20
+	FrogVariantID                              Index = 17
+	FrogTongueTarget                           Index = 18
+	OcelotTrusting                             Index = 17
+	PandaUnhappyCounter                        Index = 17
+	PandaSneezeCounter                         Index = 18
+	PandaEatCounter                            Index = 19
+	PandaMainGene                              Index = 20
+	PandaHiddenGene                            Index = 21
+	PandaFlags                                 Index = 22
+	PigBoostTime                               Index = 17
+	PigVariantID                               Index = 18
+	RabbitType                                 Index = 17
+	TurtleHasEgg                               Index = 17
+	TurtleLayingEgg                            Index = 18
+	PolarBearStanding                          Index = 17
+	ChickenVariantID                           Index = 17
+	CowVariantID                               Index = 17
+	MushroomCowType                            Index = 17
+	HoglinImmuneToZombification                Index = 17
+	SheepWoolFlags                             Index = 17
+	StriderBoostTime                           Index = 17
+	StriderSuffocating                         Index = 18
+	GoatScreaming                              Index = 17
+	GoatHasLeftHorn                            Index = 18
+	GoatHasRightHorn                           Index = 19
+	ArmadilloCurrentState                      Index = 17
+	HappyGhastLeashHolder                      Index = 17
+	HappyGhastStaysStill                       Index = 18
+	TamableAnimalFlags                         Index = 17
+	TamableAnimalOwner                         Index = 18
+	CatVariantID                               Index = 19
+	CatLying                                   Index = 20
+	CatRelaxStateOne                           Index = 21
+	CatCollarColor                             Index = 22
+	WolfInterested                             Index = 19
+	WolfCollarColor                            Index = 20
+	WolfRemainingAngerTime                     Index = 21
+	WolfVariantID                              Index = 22
+	WolfSoundVariantID                         Index = 23
+	ParrotVariant                              Index = 19
+	IronGolemFlags                             Index = 16
+	SnowGolemPumpkin                           Index = 16
+	ShulkerAttachFace                          Index = 16
+	ShulkerPeek                                Index = 17
+	ShulkerColor                               Index = 18
+	SkeletonStrayConversion                    Index = 16
+	BoggedSheared                              Index = 16
+	BlazeFlags                                 Index = 16
+	CreeperSwellDir                            Index = 16
+	CreeperPowered                             Index = 17
+	CreeperIgnited                             Index = 18
+	GuardianMoving                             Index = 16
+	GuardianAttackTarget                       Index = 17
+	RaiderCelebrating                          Index = 16
+	PillagerChargingCrossbow                   Index = 17
+	SpellcasterIllagerSpell                    Index = 17
+	WitchUsingItem                             Index = 17
+	VexFlags                                   Index = 16
+	ZombieBaby                                 Index = 16
+	ZombieSpecialType                          Index = 17
+	ZombieDrownedConversion                    Index = 18
+	ZombieVillagerConverting                   Index = 19
+	ZombieVillagerVillagerData                 Index = 20
+	EnderManCarryState                         Index = 16
+	EnderManCreepy                             Index = 17
+	EnderManStaredAt                           Index = 18
+	WitherBossTargetA                          Index = 16
+	WitherBossTargetB                          Index = 17
+	WitherBossTargetC                          Index = 18
+	WitherBossInvulnerableTicks                Index = 19
+	SpiderFlags                                Index = 16
+	WardenAngerLevel                           Index = 16
+	AbstractPiglinImmuneToZombification        Index = 16
+	PiglinBaby                                 Index = 17
+	PiglinChargingCrossbow                     Index = 18
+	PiglinDancing                              Index = 19
+	ZoglinBaby                                 Index = 16
+	CreakingCanMove                            Index = 16
+	CreakingActive                             Index = 17
+	CreakingTearingDown                        Index = 18
+	CreakingHomePos                            Index = 19
+)
+
+var (
+	entityFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+	}
+	interactionFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{InteractionWidth, TypeFloat},
+		{InteractionHeight, TypeFloat},
+		{InteractionResponse, TypeBoolean},
+	}
+	displayFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{DisplayInterpolationDelay, TypeVarInt},
+		{DisplayTransformationInterpolationDuration, TypeVarInt},
+		{DisplayPosRotInterpolationDuration, TypeVarInt},
+		{DisplayTranslation, TypeVector3},
+		{DisplayScale, TypeVector3},
+		{DisplayLeftRotation, TypeQuaternion},
+		{DisplayRightRotation, TypeQuaternion},
+		{DisplayBillboardConstraints, TypeByte},
+		{DisplayBrightnessOverride, TypeVarInt},
+		{DisplayViewRange, TypeFloat},
+		{DisplayShadowRadius, TypeFloat},
+		{DisplayShadowStrength, TypeFloat},
+		{DisplayWidth, TypeFloat},
+		{DisplayHeight, TypeFloat},
+		{DisplayGlowColorOverride, TypeVarInt},
+	}
+	blockDisplayFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{DisplayInterpolationDelay, TypeVarInt},
+		{DisplayTransformationInterpolationDuration, TypeVarInt},
+		{DisplayPosRotInterpolationDuration, TypeVarInt},
+		{DisplayTranslation, TypeVector3},
+		{DisplayScale, TypeVector3},
+		{DisplayLeftRotation, TypeQuaternion},
+		{DisplayRightRotation, TypeQuaternion},
+		{DisplayBillboardConstraints, TypeByte},
+		{DisplayBrightnessOverride, TypeVarInt},
+		{DisplayViewRange, TypeFloat},
+		{DisplayShadowRadius, TypeFloat},
+		{DisplayShadowStrength, TypeFloat},
+		{DisplayWidth, TypeFloat},
+		{DisplayHeight, TypeFloat},
+		{DisplayGlowColorOverride, TypeVarInt},
+		{BlockDisplayBlockState, TypeBlockState},
+	}
+	itemDisplayFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{DisplayInterpolationDelay, TypeVarInt},
+		{DisplayTransformationInterpolationDuration, TypeVarInt},
+		{DisplayPosRotInterpolationDuration, TypeVarInt},
+		{DisplayTranslation, TypeVector3},
+		{DisplayScale, TypeVector3},
+		{DisplayLeftRotation, TypeQuaternion},
+		{DisplayRightRotation, TypeQuaternion},
+		{DisplayBillboardConstraints, TypeByte},
+		{DisplayBrightnessOverride, TypeVarInt},
+		{DisplayViewRange, TypeFloat},
+		{DisplayShadowRadius, TypeFloat},
+		{DisplayShadowStrength, TypeFloat},
+		{DisplayWidth, TypeFloat},
+		{DisplayHeight, TypeFloat},
+		{DisplayGlowColorOverride, TypeVarInt},
+		{ItemDisplayItem, TypeSlot},
+		{ItemDisplayItemDisplay, TypeByte},
+	}
+	textDisplayFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{DisplayInterpolationDelay, TypeVarInt},
+		{DisplayTransformationInterpolationDuration, TypeVarInt},
+		{DisplayPosRotInterpolationDuration, TypeVarInt},
+		{DisplayTranslation, TypeVector3},
+		{DisplayScale, TypeVector3},
+		{DisplayLeftRotation, TypeQuaternion},
+		{DisplayRightRotation, TypeQuaternion},
+		{DisplayBillboardConstraints, TypeByte},
+		{DisplayBrightnessOverride, TypeVarInt},
+		{DisplayViewRange, TypeFloat},
+		{DisplayShadowRadius, TypeFloat},
+		{DisplayShadowStrength, TypeFloat},
+		{DisplayWidth, TypeFloat},
+		{DisplayHeight, TypeFloat},
+		{DisplayGlowColorOverride, TypeVarInt},
+		{TextDisplayText, TypeTextComponent},
+		{TextDisplayLineWidth, TypeVarInt},
+		{TextDisplayBackgroundColor, TypeVarInt},
+		{TextDisplayTextOpacity, TypeByte},
+		{TextDisplayStyleFlags, TypeByte},
+	}
+	throwableItemProjectileFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{ThrowableItemProjectileItem, TypeSlot},
+	}
+	eyeOfEnderFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{EyeOfEnderItem, TypeSlot},
+	}
+	fallingBlockFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{FallingBlockStartPos, TypePosition},
+	}
+	areaEffectCloudFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{AreaEffectCloudRadius, TypeFloat},
+		{AreaEffectCloudWaiting, TypeBoolean},
+		{AreaEffectCloudParticle, TypeParticle},
+	}
+	fishingHookFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{FishingHookHookedEntity, TypeVarInt},
+		{FishingHookBiting, TypeBoolean},
+	}
+	abstractArrowFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{AbstractArrowFlags, TypeByte},
+		{AbstractArrowPierceLevel, TypeByte},
+		{AbstractArrowInGround, TypeBoolean},
+	}
+	arrowFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{AbstractArrowFlags, TypeByte},
+		{AbstractArrowPierceLevel, TypeByte},
+		{AbstractArrowInGround, TypeBoolean},
+		{ArrowEffectColor, TypeVarInt},
+	}
+	spectralArrowFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{AbstractArrowFlags, TypeByte},
+		{AbstractArrowPierceLevel, TypeByte},
+		{AbstractArrowInGround, TypeBoolean},
+	}
+	thrownTridentFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{AbstractArrowFlags, TypeByte},
+		{AbstractArrowPierceLevel, TypeByte},
+		{AbstractArrowInGround, TypeBoolean},
+		{ThrownTridentLoyalty, TypeByte},
+		{ThrownTridentFoil, TypeBoolean},
+	}
+	vehicleEntityFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{VehicleEntityHurtTime, TypeVarInt},
+		{VehicleEntityHurtDir, TypeVarInt},
+		{VehicleEntityDamage, TypeFloat},
+	}
+	abstractBoatFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{VehicleEntityHurtTime, TypeVarInt},
+		{VehicleEntityHurtDir, TypeVarInt},
+		{VehicleEntityDamage, TypeFloat},
+		{AbstractBoatPaddleLeft, TypeBoolean},
+		{AbstractBoatPaddleRight, TypeBoolean},
+		{AbstractBoatBubbleTime, TypeVarInt},
+	}
+	abstractMinecartFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{VehicleEntityHurtTime, TypeVarInt},
+		{VehicleEntityHurtDir, TypeVarInt},
+		{VehicleEntityDamage, TypeFloat},
+		{AbstractMinecartCustomDisplayBlock, TypeOptBlockState},
+		{AbstractMinecartDisplayOffset, TypeVarInt},
+	}
+	minecartFurnaceFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{VehicleEntityHurtTime, TypeVarInt},
+		{VehicleEntityHurtDir, TypeVarInt},
+		{VehicleEntityDamage, TypeFloat},
+		{AbstractMinecartCustomDisplayBlock, TypeOptBlockState},
+		{AbstractMinecartDisplayOffset, TypeVarInt},
+		{MinecartFurnaceFuel, TypeBoolean},
+	}
+	minecartCommandBlockFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{VehicleEntityHurtTime, TypeVarInt},
+		{VehicleEntityHurtDir, TypeVarInt},
+		{VehicleEntityDamage, TypeFloat},
+		{AbstractMinecartCustomDisplayBlock, TypeOptBlockState},
+		{AbstractMinecartDisplayOffset, TypeVarInt},
+		{MinecartCommandBlockCommandName, TypeString},
+		{MinecartCommandBlockLastOutput, TypeTextComponent},
+	}
+	endCrystalFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{EndCrystalBeamTarget, TypeOptPosition},
+		{EndCrystalShowBottom, TypeBoolean},
+	}
+	fireballFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{FireballItem, TypeSlot},
+	}
+	witherSkullFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{WitherSkullDangerousSkull, TypeBoolean},
+	}
+	fireworkRocketFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{FireworkRocketItem, TypeSlot},
+		{FireworkRocketAttachedToEntity, TypeOptVarInt},
+		{FireworkRocketShotAtAngle, TypeBoolean},
+	}
+	itemFrameFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{ItemFrameItem, TypeSlot},
+		{ItemFrameRotation, TypeVarInt},
+	}
+	paintingFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{PaintingVariantID, TypePaintingVariant},
+	}
+	itemEntityFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{ItemEntityItem, TypeSlot},
+	}
+	ominousItemSpawnerFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{OminousItemSpawnerItem, TypeSlot},
+	}
+	primedTntFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{PrimedTntFuse, TypeVarInt},
+		{PrimedTntBlockState, TypeBlockState},
+	}
+	experienceOrbFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{ExperienceOrbValue, TypeVarInt},
+	}
+	livingEntityFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+	}
+	playerFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{PlayerAbsorptionAmount, TypeFloat},
+		{PlayerScore, TypeVarInt},
+		{PlayerModelCustomisation, TypeByte},
+		{PlayerMainHand, TypeByte},
+		{PlayerShoulderLeft, TypeNBT},
+		{PlayerShoulderRight, TypeNBT},
+	}
+	armorStandFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{ArmorStandClientFlags, TypeByte},
+		{ArmorStandHeadPose, TypeRotations},
+		{ArmorStandBodyPose, TypeRotations},
+		{ArmorStandLeftArmPose, TypeRotations},
+		{ArmorStandRightArmPose, TypeRotations},
+		{ArmorStandLeftLegPose, TypeRotations},
+		{ArmorStandRightLegPose, TypeRotations},
+	}
+	mobFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+	}
+	batFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{BatFlags, TypeByte},
+	}
+	enderDragonFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{EnderDragonPhase, TypeVarInt},
+	}
+	ghastFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{GhastCharging, TypeBoolean},
+	}
+	phantomFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{PhantomSize, TypeVarInt},
+	}
+	slimeFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{SlimeSize, TypeVarInt},
+	}
+	allayFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AllayDancing, TypeBoolean},
+		{AllayCanDuplicate, TypeBoolean},
+	}
+	abstractFishFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AbstractFishFromBucket, TypeBoolean},
+	}
+	codFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AbstractFishFromBucket, TypeBoolean},
+	}
+	tadpoleFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AbstractFishFromBucket, TypeBoolean},
+	}
+	salmonFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AbstractFishFromBucket, TypeBoolean},
+		{SalmonType, TypeVarInt},
+	}
+	pufferfishFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AbstractFishFromBucket, TypeBoolean},
+		{PufferfishPuffState, TypeVarInt},
+	}
+	tropicalFishFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AbstractFishFromBucket, TypeBoolean},
+		{TropicalFishTypeVariant, TypeVarInt},
+	}
+	ageableMobFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+	}
+	squidFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+	}
+	glowSquidFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+		{GlowSquidDarkTicksRemaining, TypeVarInt},
+	}
+	dolphinFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+		{DolphinTreasurePos, TypePosition},
+		{DolphinGotFish, TypeBoolean},
+		{DolphinMoistnessLevel, TypeVarInt},
+	}
+	abstractVillagerFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+		{AbstractVillagerUnhappyCounter, TypeVarInt},
+	}
+	villagerFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+		{AbstractVillagerUnhappyCounter, TypeVarInt},
+		{VillagerVillagerData, TypeVillagerData},
+	}
+	wanderingTraderFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+		{AbstractVillagerUnhappyCounter, TypeVarInt},
+	}
+	animalFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+	}
+	snifferFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+		{SnifferCurrentState, TypeSnifferState},
+		{SnifferDropSeedAtTick, TypeVarInt},
+	}
+	abstractHorseFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+		{AbstractHorseFlags, TypeByte},
+	}
+	horseFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+		{AbstractHorseFlags, TypeByte},
+		{HorseTypeVariant, TypeVarInt},
+	}
+	skeletonHorseFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+		{AbstractHorseFlags, TypeByte},
+	}
+	zombieHorseFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+		{AbstractHorseFlags, TypeByte},
+	}
+	camelFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+		{AbstractHorseFlags, TypeByte},
+		{CamelDash, TypeBoolean},
+		{CamelLastPoseChangeTick, TypeVarLong},
+	}
+	abstractChestedHorseFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+		{AbstractHorseFlags, TypeByte},
+		{AbstractChestedHorseChest, TypeBoolean},
+	}
+	llamaFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+		{AbstractHorseFlags, TypeByte},
+		{AbstractChestedHorseChest, TypeBoolean},
+		{LlamaStrength, TypeVarInt},
+		{LlamaVariant, TypeVarInt},
+	}
+	axolotlFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+		{AxolotlVariant, TypeVarInt},
+		{AxolotlPlayingDead, TypeBoolean},
+		{AxolotlFromBucket, TypeBoolean},
+	}
+	beeFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+		{BeeFlags, TypeByte},
+		{BeeRemainingAngerTime, TypeVarInt},
+	}
+	foxFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+		{FoxType, TypeVarInt},
+		{FoxFlags, TypeByte},
+		{FoxTrustedID0, TypeOptUUID},
+		{FoxTrustedID1, TypeOptUUID},
+	}
+	frogFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+		{FrogVariantID, TypeFrogVariant},
+		{FrogTongueTarget, TypeOptVarInt},
+	}
+	ocelotFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+		{OcelotTrusting, TypeBoolean},
+	}
+	pandaFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+		{PandaUnhappyCounter, TypeVarInt},
+		{PandaSneezeCounter, TypeVarInt},
+		{PandaEatCounter, TypeVarInt},
+		{PandaMainGene, TypeByte},
+		{PandaHiddenGene, TypeByte},
+		{PandaFlags, TypeByte},
+	}
+	pigFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+		{PigBoostTime, TypeVarInt},
+		{PigVariantID, TypePigVariant},
+	}
+	rabbitFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+		{RabbitType, TypeVarInt},
+	}
+	turtleFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+		{TurtleHasEgg, TypeBoolean},
+		{TurtleLayingEgg, TypeBoolean},
+	}
+	polarBearFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+		{PolarBearStanding, TypeBoolean},
+	}
+	chickenFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+		{ChickenVariantID, TypeChickenVariant},
+	}
+	cowFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+		{CowVariantID, TypeCowVariant},
+	}
+	mushroomCowFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+		{MushroomCowType, TypeVarInt},
+	}
+	hoglinFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+		{HoglinImmuneToZombification, TypeBoolean},
+	}
+	sheepFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+		{SheepWoolFlags, TypeByte},
+	}
+	striderFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+		{StriderBoostTime, TypeVarInt},
+		{StriderSuffocating, TypeBoolean},
+	}
+	goatFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+		{GoatScreaming, TypeBoolean},
+		{GoatHasLeftHorn, TypeBoolean},
+		{GoatHasRightHorn, TypeBoolean},
+	}
+	armadilloFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+		{ArmadilloCurrentState, TypeArmadilloState},
+	}
+	happyGhastFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+		{HappyGhastLeashHolder, TypeBoolean},
+		{HappyGhastStaysStill, TypeBoolean},
+	}
+	tamableAnimalFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+		{TamableAnimalFlags, TypeByte},
+		{TamableAnimalOwner, TypeOptUUID},
+	}
+	catFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+		{TamableAnimalFlags, TypeByte},
+		{TamableAnimalOwner, TypeOptUUID},
+		{CatVariantID, TypeCatVariant},
+		{CatLying, TypeBoolean},
+		{CatRelaxStateOne, TypeBoolean},
+		{CatCollarColor, TypeVarInt},
+	}
+	wolfFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+		{TamableAnimalFlags, TypeByte},
+		{TamableAnimalOwner, TypeOptUUID},
+		{WolfInterested, TypeBoolean},
+		{WolfCollarColor, TypeVarInt},
+		{WolfRemainingAngerTime, TypeVarInt},
+		{WolfVariantID, TypeWolfVariant},
+		{WolfSoundVariantID, TypeWolfSoundVariant},
+	}
+	parrotFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AgeableMobBaby, TypeBoolean},
+		{TamableAnimalFlags, TypeByte},
+		{TamableAnimalOwner, TypeOptUUID},
+		{ParrotVariant, TypeVarInt},
+	}
+	abstractGolemFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+	}
+	ironGolemFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{IronGolemFlags, TypeByte},
+	}
+	snowGolemFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{SnowGolemPumpkin, TypeByte},
+	}
+	shulkerFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{ShulkerAttachFace, TypeDirection},
+		{ShulkerPeek, TypeByte},
+		{ShulkerColor, TypeByte},
+	}
+	monsterFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+	}
+	abstractSkeletonFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+	}
+	skeletonFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{SkeletonStrayConversion, TypeBoolean},
+	}
+	boggedFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{BoggedSheared, TypeBoolean},
+	}
+	blazeFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{BlazeFlags, TypeByte},
+	}
+	creeperFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{CreeperSwellDir, TypeVarInt},
+		{CreeperPowered, TypeBoolean},
+		{CreeperIgnited, TypeBoolean},
+	}
+	guardianFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{GuardianMoving, TypeBoolean},
+		{GuardianAttackTarget, TypeVarInt},
+	}
+	raiderFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{RaiderCelebrating, TypeBoolean},
+	}
+	pillagerFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{RaiderCelebrating, TypeBoolean},
+		{PillagerChargingCrossbow, TypeBoolean},
+	}
+	spellcasterIllagerFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{RaiderCelebrating, TypeBoolean},
+		{SpellcasterIllagerSpell, TypeByte},
+	}
+	witchFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{RaiderCelebrating, TypeBoolean},
+		{WitchUsingItem, TypeBoolean},
+	}
+	vexFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{VexFlags, TypeByte},
+	}
+	zombieFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{ZombieBaby, TypeBoolean},
+		{ZombieSpecialType, TypeVarInt},
+		{ZombieDrownedConversion, TypeBoolean},
+	}
+	zombieVillagerFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{ZombieBaby, TypeBoolean},
+		{ZombieSpecialType, TypeVarInt},
+		{ZombieDrownedConversion, TypeBoolean},
+		{ZombieVillagerConverting, TypeBoolean},
+		{ZombieVillagerVillagerData, TypeVillagerData},
+	}
+	enderManFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{EnderManCarryState, TypeOptBlockState},
+		{EnderManCreepy, TypeBoolean},
+		{EnderManStaredAt, TypeBoolean},
+	}
+	witherBossFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{WitherBossTargetA, TypeVarInt},
+		{WitherBossTargetB, TypeVarInt},
+		{WitherBossTargetC, TypeVarInt},
+		{WitherBossInvulnerableTicks, TypeVarInt},
+	}
+	spiderFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{SpiderFlags, TypeByte},
+	}
+	wardenFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{WardenAngerLevel, TypeVarInt},
+	}
+	abstractPiglinFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AbstractPiglinImmuneToZombification, TypeBoolean},
+	}
+	piglinFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{AbstractPiglinImmuneToZombification, TypeBoolean},
+		{PiglinBaby, TypeBoolean},
+		{PiglinChargingCrossbow, TypeBoolean},
+		{PiglinDancing, TypeBoolean},
+	}
+	zoglinFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{ZoglinBaby, TypeBoolean},
+	}
+	creakingFields = []Field{
+		{EntityFlags, TypeByte},
+		{EntityAirSupply, TypeVarInt},
+		{EntityCustomName, TypeOptTextComponent},
+		{EntityCustomNameVisible, TypeBoolean},
+		{EntitySilent, TypeBoolean},
+		{EntityNoGravity, TypeBoolean},
+		{EntityPose, TypePose},
+		{EntityTicksFrozen, TypeVarInt},
+		{LivingEntityFlags, TypeByte},
+		{LivingEntityHealth, TypeFloat},
+		{LivingEntityEffectParticles, TypeParticles},
+		{LivingEntityEffectAmbience, TypeBoolean},
+		{LivingEntityArrowCount, TypeVarInt},
+		{LivingEntityStingerCount, TypeVarInt},
+		{LivingEntitySleepingPos, TypeOptPosition},
+		{MobFlags, TypeByte},
+		{CreakingCanMove, TypeBoolean},
+		{CreakingActive, TypeBoolean},
+		{CreakingTearingDown, TypeBoolean},
+		{CreakingHomePos, TypeOptPosition},
+	}
+)
+
+// Fields lists the metadata layout of each entity type, keyed by the entity ID.
+var Fields = map[string][]Field{
+	"minecraft:acacia_boat":            abstractBoatFields,
+	"minecraft:acacia_chest_boat":      abstractBoatFields,
+	"minecraft:allay":                  allayFields,
+	"minecraft:area_effect_cloud":      areaEffectCloudFields,
+	"minecraft:armadillo":              armadilloFields,
+	"minecraft:armor_stand":            armorStandFields,
+	"minecraft:arrow":                  arrowFields,
+	"minecraft:axolotl":                axolotlFields,
+	"minecraft:bamboo_chest_raft":      abstractBoatFields,
+	"minecraft:bamboo_raft":            abstractBoatFields,
+	"minecraft:bat":                    batFields,
+	"minecraft:bee":                    beeFields,
+	"minecraft:birch_boat":             abstractBoatFields,
+	"minecraft:birch_chest_boat":       abstractBoatFields,
+	"minecraft:blaze":                  blazeFields,
+	"minecraft:block_display":          blockDisplayFields,
+	"minecraft:bogged":                 boggedFields,
+	"minecraft:breeze":                 monsterFields,
+	"minecraft:breeze_wind_charge":     entityFields,
+	"minecraft:camel":                  camelFields,
+	"minecraft:cat":                    catFields,
+	"minecraft:cave_spider":            spiderFields,
+	"minecraft:cherry_boat":            abstractBoatFields,
+	"minecraft:cherry_chest_boat":      abstractBoatFields,
+	"minecraft:chest_minecart":         abstractMinecartFields,
+	"minecraft:chicken":                chickenFields,
+	"minecraft:cod":                    codFields,
+	"minecraft:command_block_minecart": minecartCommandBlockFields,
+	"minecraft:cow":                    cowFields,
+	"minecraft:creaking":               creakingFields,
+	"minecraft:creeper":                creeperFields,
+	"minecraft:dark_oak_boat":          abstractBoatFields,
+	"minecraft:dark_oak_chest_boat":    abstractBoatFields,
+	"minecraft:dolphin":                dolphinFields,
+	"minecraft:donkey":                 abstractChestedHorseFields,
+	"minecraft:dragon_fireball":        entityFields,
+	"minecraft:drowned":                zombieFields,
+	"minecraft:egg":                    throwableItemProjectileFields,
+	"minecraft:elder_guardian":         guardianFields,
+	"minecraft:enderman":               enderManFields,
+	"minecraft:endermite":              monsterFields,
+	"minecraft:ender_dragon":           enderDragonFields,
+	"minecraft:ender_pearl":            throwableItemProjectileFields,
+	"minecraft:end_crystal":            endCrystalFields,
+	"minecraft:evoker":                 spellcasterIllagerFields,
+	"minecraft:evoker_fangs":           entityFields,
+	"minecraft:experience_bottle":      throwableItemProjectileFields,
+	"minecraft:experience_orb":         experienceOrbFields,
+	"minecraft:eye_of_ender":           eyeOfEnderFields,
+	"minecraft:falling_block":          fallingBlockFields,
+	"minecraft:fireball":               fireballFields,
+	"minecraft:firework_rocket":        fireworkRocketFields,
+	"minecraft:fox":                    foxFields,
+	"minecraft:frog":                   frogFields,
+	"minecraft:furnace_minecart":       minecartFurnaceFields,
+	"minecraft:ghast":                  ghastFields,
+	"minecraft:happy_ghast":            happyGhastFields,
+	"minecraft:giant":                  monsterFields,
+	"minecraft:glow_item_frame":        itemFrameFields,
+	"minecraft:glow_squid":             glowSquidFields,
+	"minecraft:goat":                   goatFields,
+	"minecraft:guardian":               guardianFields,
+	"minecraft:hoglin":                 hoglinFields,
+	"minecraft:hopper_minecart":        abstractMinecartFields,
+	"minecraft:horse":                  horseFields,
+	"minecraft:husk":                   zombieFields,
+	"minecraft:illusioner":             spellcasterIllagerFields,
+	"minecraft:interaction":            interactionFields,
+	"minecraft:iron_golem":             ironGolemFields,
+	"minecraft:item":                   itemEntityFields,
+	"minecraft:item_display":           itemDisplayFields,
+	"minecraft:item_frame":             itemFrameFields,
+	"minecraft:jungle_boat":            abstractBoatFields,
+	"minecraft:jungle_chest_boat":      abstractBoatFields,
+	"minecraft:leash_knot":             entityFields,
+	"minecraft:lightning_bolt":         entityFields,
+	"minecraft:llama":                  llamaFields,
+	"minecraft:llama_spit":             entityFields,
+	"minecraft:magma_cube":             slimeFields,
+	"minecraft:mangrove_boat":          abstractBoatFields,
+	"minecraft:mangrove_chest_boat":    abstractBoatFields,
+	"minecraft:marker":                 entityFields,
+	"minecraft:minecart":               abstractMinecartFields,
+	"minecraft:mooshroom":              mushroomCowFields,
+	"minecraft:mule":                   abstractChestedHorseFields,
+	"minecraft:oak_boat":               abstractBoatFields,
+	"minecraft:oak_chest_boat":         abstractBoatFields,
+	"minecraft:ocelot":                 ocelotFields,
+	"minecraft:ominous_item_spawner":   ominousItemSpawnerFields,
+	"minecraft:painting":               paintingFields,
+	"minecraft:pale_oak_boat":          abstractBoatFields,
+	"minecraft:pale_oak_chest_boat":    abstractBoatFields,
+	"minecraft:panda":                  pandaFields,
+	"minecraft:parrot":                 parrotFields,
+	"minecraft:phantom":                phantomFields,
+	"minecraft:pig":                    pigFields,
+	"minecraft:piglin":                 piglinFields,
+	"minecraft:piglin_brute":           abstractPiglinFields,
+	"minecraft:pillager":               pillagerFields,
+	"minecraft:polar_bear":             polarBearFields,
+	"minecraft:splash_potion":          throwableItemProjectileFields,
+	"minecraft:lingering_potion":       throwableItemProjectileFields,
+	"minecraft:pufferfish":             pufferfishFields,
+	"minecraft:rabbit":                 rabbitFields,
+	"minecraft:ravager":                raiderFields,
+	"minecraft:salmon":                 salmonFields,
+	"minecraft:sheep":                  sheepFields,
+	"minecraft:shulker":                shulkerFields,
+	"minecraft:shulker_bullet":         entityFields,
+	"minecraft:silverfish":             monsterFields,
+	"minecraft:skeleton":               skeletonFields,
+	"minecraft:skeleton_horse":         skeletonHorseFields,
+	"minecraft:slime":                  slimeFields,
+	"minecraft:small_fireball":         fireballFields,
+	"minecraft:sniffer":                snifferFields,
+	"minecraft:snowball":               throwableItemProjectileFields,
+	"minecraft:snow_golem":             snowGolemFields,
+	"minecraft:spawner_minecart":       abstractMinecartFields,
+	"minecraft:spectral_arrow":         spectralArrowFields,
+	"minecraft:spider":                 spiderFields,
+	"minecraft:spruce_boat":            abstractBoatFields,
+	"minecraft:spruce_chest_boat":      abstractBoatFields,
+	"minecraft:squid":                  squidFields,
+	"minecraft:stray":                  abstractSkeletonFields,
+	"minecraft:strider":                striderFields,
+	"minecraft:tadpole":                tadpoleFields,
+	"minecraft:text_display":           textDisplayFields,
+	"minecraft:tnt":                    primedTntFields,
+	"minecraft:tnt_minecart":           abstractMinecartFields,
+	"minecraft:trader_llama":           llamaFields,
+	"minecraft:trident":                thrownTridentFields,
+	"minecraft:tropical_fish":          tropicalFishFields,
+	"minecraft:turtle":                 turtleFields,
+	"minecraft:vex":                    vexFields,
+	"minecraft:villager":               villagerFields,
+	"minecraft:vindicator":             raiderFields,
+	"minecraft:wandering_trader":       wanderingTraderFields,
+	"minecraft:warden":                 wardenFields,
+	"minecraft:wind_charge":            entityFields,
+	"minecraft:witch":                  witchFields,
+	"minecraft:wither":                 witherBossFields,
+	"minecraft:wither_skeleton":        abstractSkeletonFields,
+	"minecraft:wither_skull":           witherSkullFields,
+	"minecraft:wolf":                   wolfFields,
+	"minecraft:zoglin":                 zoglinFields,
+	"minecraft:zombie":                 zombieFields,
+	"minecraft:zombie_horse":           zombieHorseFields,
+	"minecraft:zombie_villager":        zombieVillagerFields,
+	"minecraft:zombified_piglin":       zombieFields,
+	"minecraft:player":                 playerFields,
+	"minecraft:fishing_bobber":         fishingHookFields,
+}
@@ -0,0 +1,595 @@
+package main
+
+type field struct {
+	Name string
+	Type string
+}
+
+type class struct {
+	Name   string
+	Parent string
+	Fields []field
+}
+
+// classes is the entity class hierarchy and the synced fields declared by each class.
+// A class must be declared after its parent.
+var classes = []class{
+	{"Entity", "", []field{
+		{"Flags", "Byte"},
+		{"AirSupply", "VarInt"},
+		{"CustomName", "OptTextComponent"},
+		{"CustomNameVisible", "Boolean"},
+		{"Silent", "Boolean"},
+		{"NoGravity", "Boolean"},
+		{"Pose", "Pose"},
+		{"TicksFrozen", "VarInt"},
+	}},
+	{"Interaction", "Entity", []field{
+		{"Width", "Float"},
+		{"Height", "Float"},
+		{"Response", "Boolean"},
+	}},
+	{"Display", "Entity", []field{
+		{"InterpolationDelay", "VarInt"},
+		{"TransformationInterpolationDuration", "VarInt"},
+		{"PosRotInterpolationDuration", "VarInt"},
+		{"Translation", "Vector3"},
+		{"Scale", "Vector3"},
+		{"LeftRotation", "Quaternion"},
+		{"RightRotation", "Quaternion"},
+		{"BillboardConstraints", "Byte"},
+		{"BrightnessOverride", "VarInt"},
+		{"ViewRange", "Float"},
+		{"ShadowRadius", "Float"},
+		{"ShadowStrength", "Float"},
+		{"Width", "Float"},
+		{"Height", "Float"},
+		{"GlowColorOverride", "VarInt"},
+	}},
+	{"BlockDisplay", "Display", []field{
+		{"BlockState", "BlockState"},
+	}},
+	{"ItemDisplay", "Display", []field{
+		{"Item", "Slot"},
+		{"ItemDisplay", "Byte"},
+	}},
+	{"TextDisplay", "Display", []field{
+		{"Text", "TextComponent"},
+		{"LineWidth", "VarInt"},
+		{"BackgroundColor", "VarInt"},
+		{"TextOpacity", "Byte"},
+		{"StyleFlags", "Byte"},
+	}},
+	{"ThrowableItemProjectile", "Entity", []field{
+		{"Item", "Slot"},
+	}},
+	{"EyeOfEnder", "Entity", []field{
+		{"Item", "Slot"},
+	}},
+	{"FallingBlock", "Entity", []field{
+		{"StartPos", "Position"},
+	}},
+	{"AreaEffectCloud", "Entity", []field{
+		{"Radius", "Float"},
+		{"Waiting", "Boolean"},
+		{"Particle", "Particle"},
+	}},
+	{"FishingHook", "Entity", []field{
+		{"HookedEntity", "VarInt"},
+		{"Biting", "Boolean"},
+	}},
+	{"AbstractArrow", "Entity", []field{
+		{"Flags", "Byte"},
+		{"PierceLevel", "Byte"},
+		{"InGround", "Boolean"},
+	}},
+	{"Arrow", "AbstractArrow", []field{
+		{"EffectColor", "VarInt"},
+	}},
+	{"SpectralArrow", "AbstractArrow", nil},
+	{"ThrownTrident", "AbstractArrow", []field{
+		{"Loyalty", "Byte"},
+		{"Foil", "Boolean"},
+	}},
+	{"VehicleEntity", "Entity", []field{
+		{"HurtTime", "VarInt"},
+		{"HurtDir", "VarInt"},
+		{"Damage", "Float"},
+	}},
+	{"AbstractBoat", "VehicleEntity", []field{
+		{"PaddleLeft", "Boolean"},
+		{"PaddleRight", "Boolean"},
+		{"BubbleTime", "VarInt"},
+	}},
+	{"AbstractMinecart", "VehicleEntity", []field{
+		{"CustomDisplayBlock", "OptBlockState"},
+		{"DisplayOffset", "VarInt"},
+	}},
+	{"MinecartFurnace", "AbstractMinecart", []field{
+		{"Fuel", "Boolean"},
+	}},
+	{"MinecartCommandBlock", "AbstractMinecart", []field{
+		{"CommandName", "String"},
+		{"LastOutput", "TextComponent"},
+	}},
+	{"EndCrystal", "Entity", []field{
+		{"BeamTarget", "OptPosition"},
+		{"ShowBottom", "Boolean"},
+	}},
+	{"Fireball", "Entity", []field{
+		{"Item", "Slot"},
+	}},
+	{"WitherSkull", "Entity", []field{
+		{"DangerousSkull", "Boolean"},
+	}},
+	{"FireworkRocket", "Entity", []field{
+		{"Item", "Slot"},
+		{"AttachedToEntity", "OptVarInt"},
+		{"ShotAtAngle", "Boolean"},
+	}},
+	{"ItemFrame", "Entity", []field{
+		{"Item", "Slot"},
+		{"Rotation", "VarInt"},
+	}},
+	{"Painting", "Entity", []field{
+		{"VariantID", "PaintingVariant"},
+	}},
+	{"ItemEntity", "Entity", []field{
+		{"Item", "Slot"},
+	}},
+	{"OminousItemSpawner", "Entity", []field{
+		{"Item", "Slot"},
+	}},
+	{"PrimedTnt", "Entity", []field{
+		{"Fuse", "VarInt"},
+		{"BlockState", "BlockState"},
+	}},
+	{"ExperienceOrb", "Entity", []field{
+		{"Value", "VarInt"},
+	}},
+	{"LivingEntity", "Entity", []field{
+		{"Flags", "Byte"},
+		{"Health", "Float"},
+		{"EffectParticles", "Particles"},
+		{"EffectAmbience", "Boolean"},
+		{"ArrowCount", "VarInt"},
+		{"StingerCount", "VarInt"},
+		{"SleepingPos", "OptPosition"},
+	}},
+	{"Player", "LivingEntity", []field{
+		{"AbsorptionAmount", "Float"},
+		{"Score", "VarInt"},
+		{"ModelCustomisation", "Byte"},
+		{"MainHand", "Byte"},
+		{"ShoulderLeft", "NBT"},
+		{"ShoulderRight", "NBT"},
+	}},
+	{"ArmorStand", "LivingEntity", []field{
+		{"ClientFlags", "Byte"},
+		{"HeadPose", "Rotations"},
+		{"BodyPose", "Rotations"},
+		{"LeftArmPose", "Rotations"},
+		{"RightArmPose", "Rotations"},
+		{"LeftLegPose", "Rotations"},
+		{"RightLegPose", "Rotations"},
+	}},
+	{"Mob", "LivingEntity", []field{
+		{"Flags", "Byte"},
+	}},
+	{"Bat", "Mob", []field{
+		{"Flags", "Byte"},
+	}},
+	{"EnderDragon", "Mob", []field{
+		{"Phase", "VarInt"},
+	}},
+	{"Ghast", "Mob", []field{
+		{"Charging", "Boolean"},
+	}},
+	{"Phantom", "Mob", []field{
+		{"Size", "VarInt"},
+	}},
+	{"Slime", "Mob", []field{
+		{"Size", "VarInt"},
+	}},
+	{"Allay", "Mob", []field{
+		{"Dancing", "Boolean"},
+		{"CanDuplicate", "Boolean"},
+	}},
+	{"AbstractFish", "Mob", []field{
+		{"FromBucket", "Boolean"},
+	}},
+	{"Cod", "AbstractFish", nil},
+	{"Tadpole", "AbstractFish", nil},
+	{"Salmon", "AbstractFish", []field{
+		{"Type", "VarInt"},
+	}},
+	{"Pufferfish", "AbstractFish", []field{
+		{"PuffState", "VarInt"},
+	}},
+	{"TropicalFish", "AbstractFish", []field{
+		{"TypeVariant", "VarInt"},
+	}},
+	{"AgeableMob", "Mob", []field{
+		{"Baby", "Boolean"},
+	}},
+	{"Squid", "AgeableMob", nil},
+	{"GlowSquid", "AgeableMob", []field{
+		{"DarkTicksRemaining", "VarInt"},
+	}},
+	{"Dolphin", "AgeableMob", []field{
+		{"TreasurePos", "Position"},
+		{"GotFish", "Boolean"},
+		{"MoistnessLevel", "VarInt"},
+	}},
+	{"AbstractVillager", "AgeableMob", []field{
+		{"UnhappyCounter", "VarInt"},
+	}},
+	{"Villager", "AbstractVillager", []field{
+		{"VillagerData", "VillagerData"},
+	}},
+	{"WanderingTrader", "AbstractVillager", nil},
+	{"Animal", "AgeableMob", nil},
+	{"Sniffer", "Animal", []field{
+		{"CurrentState", "SnifferState"},
+		{"DropSeedAtTick", "VarInt"},
+	}},
+	{"AbstractHorse", "Animal", []field{
+		{"Flags", "Byte"},
+	}},
+	{"Horse", "AbstractHorse", []field{
+		{"TypeVariant", "VarInt"},
+	}},
+	{"SkeletonHorse", "AbstractHorse", nil},
+	{"ZombieHorse", "AbstractHorse", nil},
+	{"Camel", "AbstractHorse", []field{
+		{"Dash", "Boolean"},
+		{"LastPoseChangeTick", "VarLong"},
+	}},
+	{"AbstractChestedHorse", "AbstractHorse", []field{
+		{"Chest", "Boolean"},
+	}},
+	{"Llama", "AbstractChestedHorse", []field{
+		{"Strength", "VarInt"},
+		{"Variant", "VarInt"},
+	}},
+	{"Axolotl", "Animal", []field{
+		{"Variant", "VarInt"},
+		{"PlayingDead", "Boolean"},
+		{"FromBucket", "Boolean"},
+	}},
+	{"Bee", "Animal", []field{
+		{"Flags", "Byte"},
+		{"RemainingAngerTime", "VarInt"},
+	}},
+	{"Fox", "Animal", []field{
+		{"Type", "VarInt"},
+		{"Flags", "Byte"},
+		{"TrustedID0", "OptUUID"},
+		{"TrustedID1", "OptUUID"},
+	}},
+	{"Frog", "Animal", []field{
+		{"VariantID", "FrogVariant"},
+		{"TongueTarget", "OptVarInt"},
+	}},
+	{"Ocelot", "Animal", []field{
+		{"Trusting", "Boolean"},
+	}},
+	{"Panda", "Animal", []field{
+		{"UnhappyCounter", "VarInt"},
+		{"SneezeCounter", "VarInt"},
+		{"EatCounter", "VarInt"},
+		{"MainGene", "Byte"},
+		{"HiddenGene", "Byte"},
+		{"Flags", "Byte"},
+	}},
+	{"Pig", "Animal", []field{
+		{"BoostTime", "VarInt"},
+		{"VariantID", "PigVariant"},
+	}},
+	{"Rabbit", "Animal", []field{
+		{"Type", "VarInt"},
+	}},
+	{"Turtle", "Animal", []field{
+		{"HasEgg", "Boolean"},
+		{"LayingEgg", "Boolean"},
+	}},
+	{"PolarBear", "Animal", []field{
+		{"Standing", "Boolean"},
+	}},
+	{"Chicken", "Animal", []field{
+		{"VariantID", "ChickenVariant"},
+	}},
+	{"Cow", "Animal", []field{
+		{"VariantID", "CowVariant"},
+	}},
+	{"MushroomCow", "Animal", []field{
+		{"Type", "VarInt"},
+	}},
+	{"Hoglin", "Animal", []field{
+		{"ImmuneToZombification", "Boolean"},
+	}},
+	{"Sheep", "Animal", []field{
+		{"WoolFlags", "Byte"},
+	}},
+	{"Strider", "Animal", []field{
+		{"BoostTime", "VarInt"},
+		{"Suffocating", "Boolean"},
+	}},
+	{"Goat", "Animal", []field{
+		{"Screaming", "Boolean"},
+		{"HasLeftHorn", "Boolean"},
+		{"HasRightHorn", "Boolean"},
+	}},
+	{"Armadillo", "Animal", []field{
+		{"CurrentState", "ArmadilloState"},
+	}},
+	{"HappyGhast", "Animal", []field{
+		{"LeashHolder", "Boolean"},
+		{"StaysStill", "Boolean"},
+	}},
+	{"TamableAnimal", "Animal", []field{
+		{"Flags", "Byte"},
+		{"Owner", "OptUUID"},
+	}},
+	{"Cat", "TamableAnimal", []field{
+		{"VariantID", "CatVariant"},
+		{"Lying", "Boolean"},
+		{"RelaxStateOne", "Boolean"},
+		{"CollarColor", "VarInt"},
+	}},
+	{"Wolf", "TamableAnimal", []field{
+		{"Interested", "Boolean"},
+		{"CollarColor", "VarInt"},
+		{"RemainingAngerTime", "VarInt"},
+		{"VariantID", "WolfVariant"},
+		{"SoundVariantID", "WolfSoundVariant"},
+	}},
+	{"Parrot", "TamableAnimal", []field{
+		{"Variant", "VarInt"},
+	}},
+	{"AbstractGolem", "Mob", nil},
+	{"IronGolem", "AbstractGolem", []field{
+		{"Flags", "Byte"},
+	}},
+	{"SnowGolem", "AbstractGolem", []field{
+		{"Pumpkin", "Byte"},
+	}},
+	{"Shulker", "AbstractGolem", []field{
+		{"AttachFace", "Direction"},
+		{"Peek", "Byte"},
+		{"Color", "Byte"},
+	}},
+	{"Monster", "Mob", nil},
+	{"AbstractSkeleton", "Monster", nil},
+	{"Skeleton", "AbstractSkeleton", []field{
+		{"StrayConversion", "Boolean"},
+	}},
+	{"Bogged", "AbstractSkeleton", []field{
+		{"Sheared", "Boolean"},
+	}},
+	{"Blaze", "Monster", []field{
+		{"Flags", "Byte"},
+	}},
+	{"Creeper", "Monster", []field{
+		{"SwellDir", "VarInt"},
+		{"Powered", "Boolean"},
+		{"Ignited", "Boolean"},
+	}},
+	{"Guardian", "Monster", []field{
+		{"Moving", "Boolean"},
+		{"AttackTarget", "VarInt"},
+	}},
+	{"Raider", "Monster", []field{
+		{"Celebrating", "Boolean"},
+	}},
+	{"Pillager", "Raider", []field{
+		{"ChargingCrossbow", "Boolean"},
+	}},
+	{"SpellcasterIllager", "Raider", []field{
+		{"Spell", "Byte"},
+	}},
+	{"Witch", "Raider", []field{
+		{"UsingItem", "Boolean"},
+	}},
+	{"Vex", "Monster", []field{
+		{"Flags", "Byte"},
+	}},
+	{"Zombie", "Monster", []field{
+		{"Baby", "Boolean"},
+		{"SpecialType", "VarInt"},
+		{"DrownedConversion", "Boolean"},
+	}},
+	{"ZombieVillager", "Zombie", []field{
+		{"Converting", "Boolean"},
+		{"VillagerData", "VillagerData"},
+	}},
+	{"EnderMan", "Monster", []field{
+		{"CarryState", "OptBlockState"},
+		{"Creepy", "Boolean"},
+		{"StaredAt", "Boolean"},
+	}},
+	{"WitherBoss", "Monster", []field{
+		{"TargetA", "VarInt"},
+		{"TargetB", "VarInt"},
+		{"TargetC", "VarInt"},
+		{"InvulnerableTicks", "VarInt"},
+	}},
+	{"Spider", "Monster", []field{
+		{"Flags", "Byte"},
+	}},
+	{"Warden", "Monster", []field{
+		{"AngerLevel", "VarInt"},
+	}},
+	{"AbstractPiglin", "Monster", []field{
+		{"ImmuneToZombification", "Boolean"},
+	}},
+	{"Piglin", "AbstractPiglin", []field{
+		{"Baby", "Boolean"},
+		{"ChargingCrossbow", "Boolean"},
+		{"Dancing", "Boolean"},
+	}},
+	{"Zoglin", "Monster", []field{
+		{"Baby", "Boolean"},
+	}},
+	{"Creaking", "Monster", []field{
+		{"CanMove", "Boolean"},
+		{"Active", "Boolean"},
+		{"TearingDown", "Boolean"},
+		{"HomePos", "OptPosition"},
+	}},
+}
+
+// classesOf maps each entity type to the class it belongs to.
+var classesOf = map[string]string{
+	"minecraft:acacia_boat":            "AbstractBoat",
+	"minecraft:acacia_chest_boat":      "AbstractBoat",
+	"minecraft:allay":                  "Allay",
+	"minecraft:area_effect_cloud":      "AreaEffectCloud",
+	"minecraft:armadillo":              "Armadillo",
+	"minecraft:armor_stand":            "ArmorStand",
+	"minecraft:arrow":                  "Arrow",
+	"minecraft:axolotl":                "Axolotl",
+	"minecraft:bamboo_chest_raft":      "AbstractBoat",
+	"minecraft:bamboo_raft":            "AbstractBoat",
+	"minecraft:bat":                    "Bat",
+	"minecraft:bee":                    "Bee",
+	"minecraft:birch_boat":             "AbstractBoat",
+	"minecraft:birch_chest_boat":       "AbstractBoat",
+	"minecraft:blaze":                  "Blaze",
+	"minecraft:block_display":          "BlockDisplay",
+	"minecraft:bogged":                 "Bogged",
+	"minecraft:breeze":                 "Monster",
+	"minecraft:breeze_wind_charge":     "Entity",
+	"minecraft:camel":                  "Camel",
+	"minecraft:cat":                    "Cat",
+	"minecraft:cave_spider":            "Spider",
+	"minecraft:cherry_boat":            "AbstractBoat",
+	"minecraft:cherry_chest_boat":      "AbstractBoat",
+	"minecraft:chest_minecart":         "AbstractMinecart",
+	"minecraft:chicken":                "Chicken",
+	"minecraft:cod":                    "Cod",
+	"minecraft:command_block_minecart": "MinecartCommandBlock",
+	"minecraft:cow":                    "Cow",
+	"minecraft:creaking":               "Creaking",
+	"minecraft:creeper":                "Creeper",
+	"minecraft:dark_oak_boat":          "AbstractBoat",
+	"minecraft:dark_oak_chest_boat":    "AbstractBoat",
+	"minecraft:dolphin":                "Dolphin",
+	"minecraft:donkey":                 "AbstractChestedHorse",
+	"minecraft:dragon_fireball":        "Entity",
+	"minecraft:drowned":                "Zombie",
+	"minecraft:egg":                    "ThrowableItemProjectile",
+	"minecraft:elder_guardian":         "Guardian",
+	"minecraft:enderman":               "EnderMan",
+	"minecraft:endermite":              "Monster",
+	"minecraft:ender_dragon":           "EnderDragon",
+	"minecraft:ender_pearl":            "ThrowableItemProjectile",
+	"minecraft:end_crystal":            "EndCrystal",
+	"minecraft:evoker":                 "SpellcasterIllager",
+	"minecraft:evoker_fangs":           "Entity",
+	"minecraft:experience_bottle":      "ThrowableItemProjectile",
+	"minecraft:experience_orb":         "ExperienceOrb",
+	"minecraft:eye_of_ender":           "EyeOfEnder",
+	"minecraft:falling_block":          "FallingBlock",
+	"minecraft:fireball":               "Fireball",
+	"minecraft:firework_rocket":        "FireworkRocket",
+	"minecraft:fox":                    "Fox",
+	"minecraft:frog":                   "Frog",
+	"minecraft:furnace_minecart":       "MinecartFurnace",
+	"minecraft:ghast":                  "Ghast",
+	"minecraft:happy_ghast":            "HappyGhast",
+	"minecraft:giant":                  "Monster",
+	"minecraft:glow_item_frame":        "ItemFrame",
+	"minecraft:glow_squid":             "GlowSquid",
+	"minecraft:goat":                   "Goat",
+	"minecraft:guardian":               "Guardian",
+	"minecraft:hoglin":                 "Hoglin",
+	"minecraft:hopper_minecart":        "AbstractMinecart",
+	"minecraft:horse":                  "Horse",
+	"minecraft:husk":                   "Zombie",
+	"minecraft:illusioner":             "SpellcasterIllager",
+	"minecraft:interaction":            "Interaction",
+	"minecraft:iron_golem":             "IronGolem",
+	"minecraft:item":                   "ItemEntity",
+	"minecraft:item_display":           "ItemDisplay",
+	"minecraft:item_frame":             "ItemFrame",
+	"minecraft:jungle_boat":            "AbstractBoat",
+	"minecraft:jungle_chest_boat":      "AbstractBoat",
+	"minecraft:leash_knot":             "Entity",
+	"minecraft:lightning_bolt":         "Entity",
+	"minecraft:llama":                  "Llama",
+	"minecraft:llama_spit":             "Entity",
+	"minecraft:magma_cube":             "Slime",
+	"minecraft:mangrove_boat":          "AbstractBoat",
+	"minecraft:mangrove_chest_boat":    "AbstractBoat",
+	"minecraft:marker":                 "Entity",
+	"minecraft:minecart":               "AbstractMinecart",
+	"minecraft:mooshroom":              "MushroomCow",
+	"minecraft:mule":                   "AbstractChestedHorse",
+	"minecraft:oak_boat":               "AbstractBoat",
+	"minecraft:oak_chest_boat":         "AbstractBoat",
+	"minecraft:ocelot":                 "Ocelot",
+	"minecraft:ominous_item_spawner":   "OminousItemSpawner",
+	"minecraft:painting":               "Painting",
+	"minecraft:pale_oak_boat":          "AbstractBoat",
+	"minecraft:pale_oak_chest_boat":    "AbstractBoat",
+	"minecraft:panda":                  "Panda",
+	"minecraft:parrot":                 "Parrot",
+	"minecraft:phantom":                "Phantom",
+	"minecraft:pig":                    "Pig",
+	"minecraft:piglin":                 "Piglin",
+	"minecraft:piglin_brute":           "AbstractPiglin",
+	"minecraft:pillager":               "Pillager",
+	"minecraft:polar_bear":             "PolarBear",
+	"minecraft:splash_potion":          "ThrowableItemProjectile",
+	"minecraft:lingering_potion":       "ThrowableItemProjectile",
+	"minecraft:pufferfish":             "Pufferfish",
+	"minecraft:rabbit":                 "Rabbit",
+	"minecraft:ravager":                "Raider",
+	"minecraft:salmon":                 "Salmon",
+	"minecraft:sheep":                  "Sheep",
+	"minecraft:shulker":                "Shulker",
+	"minecraft:shulker_bullet":         "Entity",
+	"minecraft:silverfish":             "Monster",
+	"minecraft:skeleton":               "Skeleton",
+	"minecraft:skeleton_horse":         "SkeletonHorse",
+	"minecraft:slime":                  "Slime",
+	"minecraft:small_fireball":         "Fireball",
+	"minecraft:sniffer":                "Sniffer",
+	"minecraft:snowball":               "ThrowableItemProjectile",
+	"minecraft:snow_golem":             "SnowGolem",
+	"minecraft:spawner_minecart":       "AbstractMinecart",
+	"minecraft:spectral_arrow":         "SpectralArrow",
+	"minecraft:spider":                 "Spider",
+	"minecraft:spruce_boat":            "AbstractBoat",
+	"minecraft:spruce_chest_boat":      "AbstractBoat",
+	"minecraft:squid":                  "Squid",
+	"minecraft:stray":                  "AbstractSkeleton",
+	"minecraft:strider":                "Strider",
+	"minecraft:tadpole":                "Tadpole",
+	"minecraft:text_display":           "TextDisplay",
+	"minecraft:tnt":                    "PrimedTnt",
+	"minecraft:tnt_minecart":           "AbstractMinecart",
+	"minecraft:trader_llama":           "Llama",
+	"minecraft:trident":                "ThrownTrident",
+	"minecraft:tropical_fish":          "TropicalFish",
+	"minecraft:turtle":                 "Turtle",
+	"minecraft:vex":                    "Vex",
+	"minecraft:villager":               "Villager",
+	"minecraft:vindicator":             "Raider",
+	"minecraft:wandering_trader":       "WanderingTrader",
+	"minecraft:warden":                 "Warden",
+	"minecraft:wind_charge":            "Entity",
+	"minecraft:witch":                  "Witch",
+	"minecraft:wither":                 "WitherBoss",
+	"minecraft:wither_skeleton":        "AbstractSkeleton",
+	"minecraft:wither_skull":           "WitherSkull",
+	"minecraft:wolf":                   "Wolf",
+	"minecraft:zoglin":                 "Zoglin",
+	"minecraft:zombie":                 "Zombie",
+	"minecraft:zombie_horse":           "ZombieHorse",
+	"minecraft:zombie_villager":        "ZombieVillager",
+	"minecraft:zombified_piglin":       "Zombie",
+	"minecraft:player":                 "Player",
+	"minecraft:fishing_bobber":         "FishingHook",
+}
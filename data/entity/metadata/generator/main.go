@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	_ "embed"
+	"go/format"
+	"log"
+	"os"
+	"text/template"
+	"unicode"
+
+	"git.konjactw.dev/falloutBot/go-mc/nbt"
+)
+
+//go:embed fields.go.tmpl
+var tempSource string
+
+var temp = template.Must(template.
+	New("metadata_template").
+	Funcs(template.FuncMap{
+		"LowerTheFirst": lowerTheFirst,
+		"Generator":     func() string { return "generator/main.go" },
+	}).
+	Parse(tempSource),
+)
+
+type Entity struct {
+	Name string
+	Id   int
+}
+
+type genField struct {
+	Class, Name, Type string
+	Index             int
+}
+
+type genClass struct {
+	Name   string
+	Fields []genField // declared by this class
+	All    []genField // including the inherited fields
+}
+
+type genEntity struct {
+	Name, Class string
+}
+
+func main() {
+	var entities []Entity
+	readEntities(&entities)
+
+	// flatten the class hierarchy
+	var genClasses []genClass
+	index := make(map[string]int)
+	for _, c := range classes {
+		var g genClass
+		g.Name = c.Name
+		if c.Parent != "" {
+			i, ok := index[c.Parent]
+			if !ok {
+				log.Panicf("class %s is declared before its parent %s", c.Name, c.Parent)
+			}
+			g.All = append(g.All, genClasses[i].All...)
+		}
+		for _, f := range c.Fields {
+			field := genField{Class: c.Name, Name: f.Name, Type: f.Type, Index: len(g.All)}
+			g.Fields = append(g.Fields, field)
+			g.All = append(g.All, field)
+		}
+		index[c.Name] = len(genClasses)
+		genClasses = append(genClasses, g)
+	}
+
+	genEntities := make([]genEntity, len(entities))
+	for i, e := range entities {
+		class, ok := classesOf[e.Name]
+		if !ok {
+			log.Panicf("entity %s doesn't belong to any class", e.Name)
+		}
+		if _, ok := index[class]; !ok {
+			log.Panicf("unknown class %s of entity %s", class, e.Name)
+		}
+		genEntities[i] = genEntity{Name: e.Name, Class: class}
+	}
+
+	genSourceFile(genClasses, genEntities)
+}
+
+func readEntities(entities *[]Entity) {
+	f, err := os.Open("../entities.nbt")
+	if err != nil {
+		log.Panic(err)
+	}
+	defer f.Close()
+
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	// parse the nbt format
+	if _, err := nbt.NewDecoder(r).Decode(entities); err != nil {
+		log.Panic(err)
+	}
+}
+
+func genSourceFile(classes []genClass, entities []genEntity) {
+	var source bytes.Buffer
+	err := temp.Execute(&source, struct {
+		Classes  []genClass
+		Entities []genEntity
+	}{classes, entities})
+	if err != nil {
+		log.Panic(err)
+	}
+
+	formattedSource, err := format.Source(source.Bytes())
+	if err != nil {
+		panic(err)
+	}
+
+	err = os.WriteFile("fields.go", formattedSource, 0o666)
+	if err != nil {
+		panic(err)
+	}
+	log.Print("Generated fields.go")
+}
+
+func lowerTheFirst(word string) string {
+	runes := []rune(word)
+	if len(runes) > 0 {
+		runes[0] = unicode.ToLower(runes[0])
+	}
+	return string(runes)
+}
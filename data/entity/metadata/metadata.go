@@ -0,0 +1,209 @@
+// Package metadata implements the entity metadata (also called data watcher)
+// format used by the SetEntityData packet.
+//
+// A metadata is a list of entries, each entry is identified by an index and
+// carries a typed value. The layout of the indexes for every entity type is
+// listed in [Fields], which is generated from the entity class hierarchy.
+package metadata
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
+)
+
+//go:generate go run ./generator
+
+// Type is the serializer ID of a metadata value.
+type Type int32
+
+const (
+	TypeByte Type = iota
+	TypeVarInt
+	TypeVarLong
+	TypeFloat
+	TypeString
+	TypeTextComponent
+	TypeOptTextComponent
+	TypeSlot
+	TypeBoolean
+	TypeRotations
+	TypePosition
+	TypeOptPosition
+	TypeDirection
+	TypeOptUUID
+	TypeBlockState
+	TypeOptBlockState
+	TypeNBT
+	TypeParticle
+	TypeParticles
+	TypeVillagerData
+	TypeOptVarInt
+	TypePose
+	TypeCatVariant
+	TypeCowVariant
+	TypeWolfVariant
+	TypeWolfSoundVariant
+	TypeFrogVariant
+	TypePigVariant
+	TypeChickenVariant
+	TypeOptGlobalPos
+	TypePaintingVariant
+	TypeSnifferState
+	TypeArmadilloState
+	TypeVector3
+	TypeQuaternion
+)
+
+// Index is the position of a field in the metadata of an entity.
+type Index uint8
+
+// endIndex terminates the list of entries.
+const endIndex = 0xFF
+
+// Field describes a synced field of an entity type.
+type Field struct {
+	Index Index
+	Type  Type
+}
+
+// Value is a metadata value which can be sent to the client.
+// All types in this package that implement Value have a pointer
+// type that implements [pk.FieldDecoder].
+type Value interface {
+	pk.FieldEncoder
+	Type() Type
+}
+
+// Entry is a single indexed value of a metadata.
+type Entry struct {
+	Index Index
+	Value Value
+}
+
+// Metadata is a list of entries as sent by the SetEntityData packet.
+// It implements [pk.Field].
+type Metadata []Entry
+
+func (m Metadata) WriteTo(w io.Writer) (n int64, err error) {
+	var n1, n2, n3 int64
+	for _, e := range m {
+		n1, err = pk.UnsignedByte(e.Index).WriteTo(w)
+		n += n1
+		if err != nil {
+			return
+		}
+		n2, err = pk.VarInt(e.Value.Type()).WriteTo(w)
+		n += n2
+		if err != nil {
+			return
+		}
+		n3, err = e.Value.WriteTo(w)
+		n += n3
+		if err != nil {
+			return
+		}
+	}
+	n1, err = pk.UnsignedByte(endIndex).WriteTo(w)
+	return n + n1, err
+}
+
+func (m *Metadata) ReadFrom(r io.Reader) (n int64, err error) {
+	*m = (*m)[:0]
+	var (
+		index pk.UnsignedByte
+		typ   pk.VarInt
+		n1    int64
+	)
+	for {
+		n1, err = index.ReadFrom(r)
+		n += n1
+		if err != nil {
+			return
+		}
+		if index == endIndex {
+			return
+		}
+		n1, err = typ.ReadFrom(r)
+		n += n1
+		if err != nil {
+			return
+		}
+		var v Value
+		v, n1, err = DecodeValue(Type(typ), r)
+		n += n1
+		if err != nil {
+			return n, fmt.Errorf("metadata: decode index %d: %w", index, err)
+		}
+		*m = append(*m, Entry{Index: Index(index), Value: v})
+	}
+}
+
+// Get returns the value of the entry with the index, or nil if it's absent.
+func (m Metadata) Get(index Index) Value {
+	for _, e := range m {
+		if e.Index == index {
+			return e.Value
+		}
+	}
+	return nil
+}
+
+var ErrUnknownType = errors.New("unknown metadata type")
+
+// DecodeValue reads a value of type t from r.
+func DecodeValue(t Type, r io.Reader) (Value, int64, error) {
+	if t < 0 || int(t) >= len(decoders) {
+		return nil, 0, ErrUnknownType
+	}
+	return decoders[t](r)
+}
+
+var decoders = [...]func(io.Reader) (Value, int64, error){
+	TypeByte:             decode[Byte],
+	TypeVarInt:           decode[VarInt],
+	TypeVarLong:          decode[VarLong],
+	TypeFloat:            decode[Float],
+	TypeString:           decode[String],
+	TypeTextComponent:    decode[TextComponent],
+	TypeOptTextComponent: decode[OptTextComponent],
+	TypeSlot:             decode[Slot],
+	TypeBoolean:          decode[Boolean],
+	TypeRotations:        decode[Rotations],
+	TypePosition:         decode[Position],
+	TypeOptPosition:      decode[OptPosition],
+	TypeDirection:        decode[Direction],
+	TypeOptUUID:          decode[OptUUID],
+	TypeBlockState:       decode[BlockState],
+	TypeOptBlockState:    decode[OptBlockState],
+	TypeNBT:              decode[NBT],
+	TypeParticle:         decode[Particle],
+	TypeParticles:        decode[Particles],
+	TypeVillagerData:     decode[VillagerData],
+	TypeOptVarInt:        decode[OptVarInt],
+	TypePose:             decode[Pose],
+	TypeCatVariant:       decode[CatVariant],
+	TypeCowVariant:       decode[CowVariant],
+	TypeWolfVariant:      decode[WolfVariant],
+	TypeWolfSoundVariant: decode[WolfSoundVariant],
+	TypeFrogVariant:      decode[FrogVariant],
+	TypePigVariant:       decode[PigVariant],
+	TypeChickenVariant:   decode[ChickenVariant],
+	TypeOptGlobalPos:     decode[OptGlobalPos],
+	TypePaintingVariant:  decode[PaintingVariant],
+	TypeSnifferState:     decode[SnifferState],
+	TypeArmadilloState:   decode[ArmadilloState],
+	TypeVector3:          decode[Vector3],
+	TypeQuaternion:       decode[Quaternion],
+}
+
+func decode[T Value, P interface {
+	*T
+	pk.FieldDecoder
+}](r io.Reader) (Value, int64, error) {
+	var v T
+	n, err := P(&v).ReadFrom(r)
+	return v, n, err
+}
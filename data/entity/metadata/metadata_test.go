@@ -0,0 +1,93 @@
+package metadata
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
+)
+
+func TestMetadata_ReadFrom(t *testing.T) {
+	// metadata of a frog
+	var want Metadata
+	want = append(want,
+		Entry{EntityFlags, Byte(0x20)},
+		Entry{EntityCustomName, OptTextComponent{}},
+		Entry{EntityPose, Sneaking},
+		Entry{LivingEntityHealth, Float(20)},
+		Entry{LivingEntityEffectParticles, Particles{
+			{ID: 20, Data: &ColorParticle{Color: -1}},
+			{ID: 0},
+		}},
+		Entry{LivingEntitySleepingPos, OptPosition{pk.Option[pk.Position, *pk.Position]{Has: true, Val: pk.Position{X: 1, Y: -2, Z: 3}}}},
+		Entry{FrogVariantID, FrogVariant(2)},
+		Entry{FrogTongueTarget, OptVarInt{Has: true, Val: 0}},
+	)
+
+	var buf bytes.Buffer
+	if _, err := want.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var got Metadata
+	if _, err := got.ReadFrom(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("%d bytes left unread", buf.Len())
+	}
+	if len(got) != len(want) {
+		t.Fatalf("length not match: %d != %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].Index != want[i].Index || got[i].Value.Type() != want[i].Value.Type() {
+			t.Errorf("entry %d: want %v, get %v", i, want[i], got[i])
+		}
+	}
+	if p := got.Get(LivingEntityEffectParticles).(Particles); !reflect.DeepEqual(p[0].Data, &ColorParticle{Color: -1}) {
+		t.Errorf("particle options not match: %v", p[0].Data)
+	}
+	if v := got.Get(FrogTongueTarget).(OptVarInt); !v.Has || v.Val != 0 {
+		t.Errorf("optional varint not match: %v", v)
+	}
+}
+
+func TestFields(t *testing.T) {
+	for name, fields := range Fields {
+		for i, f := range fields {
+			if int(f.Index) != i {
+				t.Errorf("%s: field %d has index %d", name, i, f.Index)
+			}
+		}
+	}
+	if f := Fields["minecraft:zombie"]; f[ZombieBaby].Type != TypeBoolean {
+		t.Error("zombie baby should be a boolean")
+	}
+}
+
+func TestTracker(t *testing.T) {
+	tracker, err := NewTracker("minecraft:player")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracker.Set(LivingEntityHealth, Float(20))
+	tracker.Set(EntityFlags, Byte(0))
+	if m := tracker.PackDirty(); len(m) != 2 || m[0].Index != EntityFlags {
+		t.Errorf("unexpected dirty entries: %v", m)
+	}
+	if tracker.Dirty() {
+		t.Error("should be clean after packing")
+	}
+
+	tracker.Set(LivingEntityHealth, Float(20))
+	if tracker.Dirty() {
+		t.Error("setting a same value shouldn't mark dirty")
+	}
+	tracker.Set(LivingEntityHealth, Float(19))
+	if m := tracker.PackDirty(); len(m) != 1 || m[0].Value != Float(19) {
+		t.Errorf("unexpected dirty entries: %v", m)
+	}
+	if m := tracker.PackAll(); len(m) != 2 {
+		t.Errorf("unexpected entries: %v", m)
+	}
+}
@@ -0,0 +1,240 @@
+package metadata
+
+import (
+	"errors"
+	"io"
+
+	"git.konjactw.dev/falloutBot/go-mc/data/registryid"
+	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
+)
+
+// Particle is a particle type with its options.
+// Data is nil for particle types which don't have any option.
+type Particle struct {
+	// ID is the registry ID of registryid.ParticleType.
+	ID   int32
+	Data ParticleOptions
+}
+
+// ParticleOptions is the extra data of some particle types.
+type ParticleOptions interface {
+	pk.FieldEncoder
+}
+
+func (Particle) Type() Type { return TypeParticle }
+
+func (p Particle) WriteTo(w io.Writer) (int64, error) {
+	n, err := pk.VarInt(p.ID).WriteTo(w)
+	if err != nil || p.Data == nil {
+		return n, err
+	}
+	n1, err := p.Data.WriteTo(w)
+	return n + n1, err
+}
+
+func (p *Particle) ReadFrom(r io.Reader) (int64, error) {
+	n, err := (*pk.VarInt)(&p.ID).ReadFrom(r)
+	if err != nil {
+		return n, err
+	}
+	if p.ID < 0 || int(p.ID) >= len(registryid.ParticleType) {
+		return n, errors.New("metadata: unknown particle type")
+	}
+	newOpts, ok := particleOptions[registryid.ParticleType[p.ID]]
+	if !ok {
+		p.Data = nil
+		return n, nil
+	}
+	opts := newOpts()
+	n1, err := opts.ReadFrom(r)
+	p.Data = opts.(ParticleOptions)
+	return n + n1, err
+}
+
+// Particles is a list of particles.
+type Particles []Particle
+
+func (Particles) Type() Type { return TypeParticles }
+
+func (p Particles) WriteTo(w io.Writer) (int64, error) {
+	return pk.Array(p).WriteTo(w)
+}
+
+func (p *Particles) ReadFrom(r io.Reader) (int64, error) {
+	return pk.Array(p).ReadFrom(r)
+}
+
+var particleOptions = map[string]func() pk.FieldDecoder{
+	"minecraft:block":                 func() pk.FieldDecoder { return new(BlockParticle) },
+	"minecraft:block_marker":          func() pk.FieldDecoder { return new(BlockParticle) },
+	"minecraft:falling_dust":          func() pk.FieldDecoder { return new(BlockParticle) },
+	"minecraft:dust_pillar":           func() pk.FieldDecoder { return new(BlockParticle) },
+	"minecraft:block_crumble":         func() pk.FieldDecoder { return new(BlockParticle) },
+	"minecraft:dust":                  func() pk.FieldDecoder { return new(DustParticle) },
+	"minecraft:dust_color_transition": func() pk.FieldDecoder { return new(DustColorTransitionParticle) },
+	"minecraft:entity_effect":         func() pk.FieldDecoder { return new(ColorParticle) },
+	"minecraft:tinted_leaves":         func() pk.FieldDecoder { return new(ColorParticle) },
+	"minecraft:effect":                func() pk.FieldDecoder { return new(SpellParticle) },
+	"minecraft:instant_effect":        func() pk.FieldDecoder { return new(SpellParticle) },
+	"minecraft:item":                  func() pk.FieldDecoder { return new(ItemParticle) },
+	"minecraft:vibration":             func() pk.FieldDecoder { return new(VibrationParticle) },
+	"minecraft:trail":                 func() pk.FieldDecoder { return new(TrailParticle) },
+	"minecraft:sculk_charge":          func() pk.FieldDecoder { return new(SculkChargeParticle) },
+	"minecraft:shriek":                func() pk.FieldDecoder { return new(ShriekParticle) },
+}
+
+// BlockParticle is used by block, block_marker, falling_dust, dust_pillar and block_crumble.
+type BlockParticle struct {
+	State BlockState
+}
+
+func (p BlockParticle) WriteTo(w io.Writer) (int64, error)   { return p.State.WriteTo(w) }
+func (p *BlockParticle) ReadFrom(r io.Reader) (int64, error) { return p.State.ReadFrom(r) }
+
+// DustParticle is used by dust. Color is in RGB format.
+type DustParticle struct {
+	Color int32
+	Scale float32
+}
+
+func (p DustParticle) WriteTo(w io.Writer) (int64, error) {
+	return pk.Tuple{pk.Int(p.Color), pk.Float(p.Scale)}.WriteTo(w)
+}
+
+func (p *DustParticle) ReadFrom(r io.Reader) (int64, error) {
+	return pk.Tuple{(*pk.Int)(&p.Color), (*pk.Float)(&p.Scale)}.ReadFrom(r)
+}
+
+// DustColorTransitionParticle is used by dust_color_transition.
+type DustColorTransitionParticle struct {
+	From, To int32
+	Scale    float32
+}
+
+func (p DustColorTransitionParticle) WriteTo(w io.Writer) (int64, error) {
+	return pk.Tuple{pk.Int(p.From), pk.Int(p.To), pk.Float(p.Scale)}.WriteTo(w)
+}
+
+func (p *DustColorTransitionParticle) ReadFrom(r io.Reader) (int64, error) {
+	return pk.Tuple{(*pk.Int)(&p.From), (*pk.Int)(&p.To), (*pk.Float)(&p.Scale)}.ReadFrom(r)
+}
+
+// ColorParticle is used by entity_effect and tinted_leaves. Color is in ARGB format.
+type ColorParticle struct {
+	Color int32
+}
+
+func (p ColorParticle) WriteTo(w io.Writer) (int64, error)   { return pk.Int(p.Color).WriteTo(w) }
+func (p *ColorParticle) ReadFrom(r io.Reader) (int64, error) { return (*pk.Int)(&p.Color).ReadFrom(r) }
+
+// SpellParticle is used by effect and instant_effect.
+type SpellParticle struct {
+	Color int32
+	Power float32
+}
+
+func (p SpellParticle) WriteTo(w io.Writer) (int64, error) {
+	return pk.Tuple{pk.Int(p.Color), pk.Float(p.Power)}.WriteTo(w)
+}
+
+func (p *SpellParticle) ReadFrom(r io.Reader) (int64, error) {
+	return pk.Tuple{(*pk.Int)(&p.Color), (*pk.Float)(&p.Power)}.ReadFrom(r)
+}
+
+// ItemParticle is used by item.
+type ItemParticle struct {
+	Item Slot
+}
+
+func (p ItemParticle) WriteTo(w io.Writer) (int64, error)   { return p.Item.WriteTo(w) }
+func (p *ItemParticle) ReadFrom(r io.Reader) (int64, error) { return p.Item.ReadFrom(r) }
+
+// VibrationParticle is used by vibration.
+type VibrationParticle struct {
+	Source PositionSource
+	Ticks  int32
+}
+
+func (p VibrationParticle) WriteTo(w io.Writer) (int64, error) {
+	return pk.Tuple{p.Source, pk.VarInt(p.Ticks)}.WriteTo(w)
+}
+
+func (p *VibrationParticle) ReadFrom(r io.Reader) (int64, error) {
+	return pk.Tuple{&p.Source, (*pk.VarInt)(&p.Ticks)}.ReadFrom(r)
+}
+
+// PositionSource is the destination of a vibration.
+// Type 0 is a block, and Type 1 is an entity.
+type PositionSource struct {
+	Type int32
+	// Block is used when Type is 0.
+	Block pk.Position
+	// Entity and EyeHeight are used when Type is 1.
+	Entity    int32
+	EyeHeight float32
+}
+
+func (p PositionSource) WriteTo(w io.Writer) (int64, error) {
+	if p.Type == 0 {
+		return pk.Tuple{pk.VarInt(p.Type), p.Block}.WriteTo(w)
+	}
+	return pk.Tuple{pk.VarInt(p.Type), pk.VarInt(p.Entity), pk.Float(p.EyeHeight)}.WriteTo(w)
+}
+
+func (p *PositionSource) ReadFrom(r io.Reader) (int64, error) {
+	n, err := (*pk.VarInt)(&p.Type).ReadFrom(r)
+	if err != nil {
+		return n, err
+	}
+	var n1 int64
+	switch p.Type {
+	case 0:
+		n1, err = p.Block.ReadFrom(r)
+	case 1:
+		n1, err = pk.Tuple{(*pk.VarInt)(&p.Entity), (*pk.Float)(&p.EyeHeight)}.ReadFrom(r)
+	default:
+		err = errors.New("metadata: unknown position source type")
+	}
+	return n + n1, err
+}
+
+// TrailParticle is used by trail.
+type TrailParticle struct {
+	Target   [3]float64
+	Color    int32
+	Duration int32
+}
+
+func (p TrailParticle) WriteTo(w io.Writer) (int64, error) {
+	return pk.Tuple{
+		pk.Double(p.Target[0]), pk.Double(p.Target[1]), pk.Double(p.Target[2]),
+		pk.Int(p.Color), pk.VarInt(p.Duration),
+	}.WriteTo(w)
+}
+
+func (p *TrailParticle) ReadFrom(r io.Reader) (int64, error) {
+	return pk.Tuple{
+		(*pk.Double)(&p.Target[0]), (*pk.Double)(&p.Target[1]), (*pk.Double)(&p.Target[2]),
+		(*pk.Int)(&p.Color), (*pk.VarInt)(&p.Duration),
+	}.ReadFrom(r)
+}
+
+// SculkChargeParticle is used by sculk_charge.
+type SculkChargeParticle struct {
+	Roll float32
+}
+
+func (p SculkChargeParticle) WriteTo(w io.Writer) (int64, error) { return pk.Float(p.Roll).WriteTo(w) }
+func (p *SculkChargeParticle) ReadFrom(r io.Reader) (int64, error) {
+	return (*pk.Float)(&p.Roll).ReadFrom(r)
+}
+
+// ShriekParticle is used by shriek.
+type ShriekParticle struct {
+	Delay int32
+}
+
+func (p ShriekParticle) WriteTo(w io.Writer) (int64, error) { return pk.VarInt(p.Delay).WriteTo(w) }
+func (p *ShriekParticle) ReadFrom(r io.Reader) (int64, error) {
+	return (*pk.VarInt)(&p.Delay).ReadFrom(r)
+}
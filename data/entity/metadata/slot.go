@@ -0,0 +1,12 @@
+package metadata
+
+import (
+	"git.konjactw.dev/falloutBot/go-mc/level/item"
+)
+
+// Slot is an item stack.
+type Slot struct {
+	item.Stack
+}
+
+func (Slot) Type() Type { return TypeSlot }
@@ -0,0 +1,78 @@
+package metadata
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Tracker holds the metadata values of an entity on the server side,
+// and records which of them are changed since they were last sent.
+//
+// A Tracker is not safe for concurrent use.
+type Tracker struct {
+	fields []Field
+	values map[Index]Value
+	dirty  map[Index]struct{}
+}
+
+// NewTracker creates a Tracker for the entity type, e.g. "minecraft:zombie".
+func NewTracker(entityType string) (*Tracker, error) {
+	fields, ok := Fields[entityType]
+	if !ok {
+		return nil, fmt.Errorf("metadata: unknown entity type %q", entityType)
+	}
+	return &Tracker{
+		fields: fields,
+		values: make(map[Index]Value),
+		dirty:  make(map[Index]struct{}),
+	}, nil
+}
+
+// Set updates the value at the index. The entry is only marked dirty if the value is changed.
+// Panic if the entity doesn't have the index or the type of value mismatches.
+func (t *Tracker) Set(index Index, v Value) {
+	if int(index) >= len(t.fields) || t.fields[index].Type != v.Type() {
+		panic(fmt.Errorf("metadata: invalid value of type %d at index %d", v.Type(), index))
+	}
+	if old, ok := t.values[index]; ok && reflect.DeepEqual(old, v) {
+		return
+	}
+	t.values[index] = v
+	t.dirty[index] = struct{}{}
+}
+
+// Get returns the value at the index, or nil if it's never set.
+func (t *Tracker) Get(index Index) Value {
+	return t.values[index]
+}
+
+// Dirty reports whether any value is changed since the last call of PackDirty.
+func (t *Tracker) Dirty() bool {
+	return len(t.dirty) > 0
+}
+
+// PackDirty returns the changed entries and clears the dirty marks.
+// Returns nil if nothing is changed.
+func (t *Tracker) PackDirty() (m Metadata) {
+	if len(t.dirty) == 0 {
+		return nil
+	}
+	for _, f := range t.fields {
+		if _, ok := t.dirty[f.Index]; ok {
+			m = append(m, Entry{Index: f.Index, Value: t.values[f.Index]})
+		}
+	}
+	clear(t.dirty)
+	return
+}
+
+// PackAll returns all entries that have been set,
+// which is usually sent after the entity is spawned for a client.
+func (t *Tracker) PackAll() (m Metadata) {
+	for _, f := range t.fields {
+		if v, ok := t.values[f.Index]; ok {
+			m = append(m, Entry{Index: f.Index, Value: v})
+		}
+	}
+	return
+}
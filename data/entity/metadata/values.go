@@ -0,0 +1,329 @@
+package metadata
+
+import (
+	"io"
+
+	"git.konjactw.dev/falloutBot/go-mc/chat"
+	"git.konjactw.dev/falloutBot/go-mc/nbt"
+	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
+)
+
+type (
+	Byte    int8
+	VarInt  int32
+	VarLong int64
+	Float   float32
+	String  string
+	Boolean bool
+
+	// TextComponent is a chat message encoded in NBT.
+	TextComponent chat.Message
+	// OptTextComponent is a TextComponent that may be absent.
+	OptTextComponent struct {
+		pk.Option[chat.Message, *chat.Message]
+	}
+
+	// Rotations is the rotation around X, Y and Z axes in degrees, used by armor stands.
+	Rotations struct{ X, Y, Z float32 }
+
+	Position    pk.Position
+	OptPosition struct {
+		pk.Option[pk.Position, *pk.Position]
+	}
+
+	// OptUUID is an optional entity reference.
+	OptUUID struct {
+		pk.Option[pk.UUID, *pk.UUID]
+	}
+
+	// BlockState is a block state ID, same as block.StateID.
+	BlockState int32
+	// OptBlockState is a block state ID, while 0 (air) means absent.
+	OptBlockState int32
+
+	// NBT is an arbitrary NBT tag in network format.
+	NBT struct {
+		Tag nbt.RawMessage
+	}
+
+	// VillagerData is the type, profession and level of a villager.
+	// VillagerType and Profession are the registry IDs of registryid.VillagerType and registryid.VillagerProfession.
+	VillagerData struct {
+		VillagerType, Profession, Level int32
+	}
+
+	// OptVarInt is an optional non-negative integer, usually an entity ID.
+	OptVarInt struct {
+		Has bool
+		Val int32
+	}
+
+	// The following types are registry IDs.
+
+	CatVariant       int32
+	CowVariant       int32
+	WolfVariant      int32
+	WolfSoundVariant int32
+	FrogVariant      int32
+	PigVariant       int32
+	ChickenVariant   int32
+
+	// PaintingVariant is either a registry ID or an inlined painting definition.
+	PaintingVariant struct {
+		pk.OptID[PaintingDefinition, *PaintingDefinition]
+	}
+
+	// OptGlobalPos is an optional position with its dimension.
+	OptGlobalPos struct {
+		pk.Option[GlobalPos, *GlobalPos]
+	}
+
+	Vector3    struct{ X, Y, Z float32 }
+	Quaternion struct{ X, Y, Z, W float32 }
+)
+
+func (Byte) Type() Type             { return TypeByte }
+func (VarInt) Type() Type           { return TypeVarInt }
+func (VarLong) Type() Type          { return TypeVarLong }
+func (Float) Type() Type            { return TypeFloat }
+func (String) Type() Type           { return TypeString }
+func (TextComponent) Type() Type    { return TypeTextComponent }
+func (OptTextComponent) Type() Type { return TypeOptTextComponent }
+func (Boolean) Type() Type          { return TypeBoolean }
+func (Rotations) Type() Type        { return TypeRotations }
+func (Position) Type() Type         { return TypePosition }
+func (OptPosition) Type() Type      { return TypeOptPosition }
+func (Direction) Type() Type        { return TypeDirection }
+func (OptUUID) Type() Type          { return TypeOptUUID }
+func (BlockState) Type() Type       { return TypeBlockState }
+func (OptBlockState) Type() Type    { return TypeOptBlockState }
+func (NBT) Type() Type              { return TypeNBT }
+func (VillagerData) Type() Type     { return TypeVillagerData }
+func (OptVarInt) Type() Type        { return TypeOptVarInt }
+func (Pose) Type() Type             { return TypePose }
+func (CatVariant) Type() Type       { return TypeCatVariant }
+func (CowVariant) Type() Type       { return TypeCowVariant }
+func (WolfVariant) Type() Type      { return TypeWolfVariant }
+func (WolfSoundVariant) Type() Type { return TypeWolfSoundVariant }
+func (FrogVariant) Type() Type      { return TypeFrogVariant }
+func (PigVariant) Type() Type       { return TypePigVariant }
+func (ChickenVariant) Type() Type   { return TypeChickenVariant }
+func (OptGlobalPos) Type() Type     { return TypeOptGlobalPos }
+func (PaintingVariant) Type() Type  { return TypePaintingVariant }
+func (SnifferState) Type() Type     { return TypeSnifferState }
+func (ArmadilloState) Type() Type   { return TypeArmadilloState }
+func (Vector3) Type() Type          { return TypeVector3 }
+func (Quaternion) Type() Type       { return TypeQuaternion }
+
+func (v Byte) WriteTo(w io.Writer) (int64, error)     { return pk.Byte(v).WriteTo(w) }
+func (v *Byte) ReadFrom(r io.Reader) (int64, error)   { return (*pk.Byte)(v).ReadFrom(r) }
+func (v VarInt) WriteTo(w io.Writer) (int64, error)   { return pk.VarInt(v).WriteTo(w) }
+func (v *VarInt) ReadFrom(r io.Reader) (int64, error) { return (*pk.VarInt)(v).ReadFrom(r) }
+
+func (v VarLong) WriteTo(w io.Writer) (int64, error)   { return pk.VarLong(v).WriteTo(w) }
+func (v *VarLong) ReadFrom(r io.Reader) (int64, error) { return (*pk.VarLong)(v).ReadFrom(r) }
+func (v Float) WriteTo(w io.Writer) (int64, error)     { return pk.Float(v).WriteTo(w) }
+func (v *Float) ReadFrom(r io.Reader) (int64, error)   { return (*pk.Float)(v).ReadFrom(r) }
+func (v String) WriteTo(w io.Writer) (int64, error)    { return pk.String(v).WriteTo(w) }
+func (v *String) ReadFrom(r io.Reader) (int64, error)  { return (*pk.String)(v).ReadFrom(r) }
+func (v Boolean) WriteTo(w io.Writer) (int64, error)   { return pk.Boolean(v).WriteTo(w) }
+func (v *Boolean) ReadFrom(r io.Reader) (int64, error) { return (*pk.Boolean)(v).ReadFrom(r) }
+
+func (v TextComponent) WriteTo(w io.Writer) (int64, error) {
+	return chat.Message(v).WriteTo(w)
+}
+
+func (v *TextComponent) ReadFrom(r io.Reader) (int64, error) {
+	return (*chat.Message)(v).ReadFrom(r)
+}
+
+func (v Rotations) WriteTo(w io.Writer) (int64, error) {
+	return pk.Tuple{pk.Float(v.X), pk.Float(v.Y), pk.Float(v.Z)}.WriteTo(w)
+}
+
+func (v *Rotations) ReadFrom(r io.Reader) (int64, error) {
+	return pk.Tuple{(*pk.Float)(&v.X), (*pk.Float)(&v.Y), (*pk.Float)(&v.Z)}.ReadFrom(r)
+}
+
+func (v Position) WriteTo(w io.Writer) (int64, error)   { return pk.Position(v).WriteTo(w) }
+func (v *Position) ReadFrom(r io.Reader) (int64, error) { return (*pk.Position)(v).ReadFrom(r) }
+
+func (v BlockState) WriteTo(w io.Writer) (int64, error)      { return pk.VarInt(v).WriteTo(w) }
+func (v *BlockState) ReadFrom(r io.Reader) (int64, error)    { return (*pk.VarInt)(v).ReadFrom(r) }
+func (v OptBlockState) WriteTo(w io.Writer) (int64, error)   { return pk.VarInt(v).WriteTo(w) }
+func (v *OptBlockState) ReadFrom(r io.Reader) (int64, error) { return (*pk.VarInt)(v).ReadFrom(r) }
+
+func (v NBT) WriteTo(w io.Writer) (int64, error) {
+	return pk.NBT(v.Tag).WriteTo(w)
+}
+
+func (v *NBT) ReadFrom(r io.Reader) (int64, error) {
+	return pk.NBT(&v.Tag).ReadFrom(r)
+}
+
+func (v VillagerData) WriteTo(w io.Writer) (int64, error) {
+	return pk.Tuple{pk.VarInt(v.VillagerType), pk.VarInt(v.Profession), pk.VarInt(v.Level)}.WriteTo(w)
+}
+
+func (v *VillagerData) ReadFrom(r io.Reader) (int64, error) {
+	return pk.Tuple{(*pk.VarInt)(&v.VillagerType), (*pk.VarInt)(&v.Profession), (*pk.VarInt)(&v.Level)}.ReadFrom(r)
+}
+
+func (v OptVarInt) WriteTo(w io.Writer) (int64, error) {
+	if !v.Has {
+		return pk.VarInt(0).WriteTo(w)
+	}
+	return pk.VarInt(v.Val + 1).WriteTo(w)
+}
+
+func (v *OptVarInt) ReadFrom(r io.Reader) (int64, error) {
+	var val pk.VarInt
+	n, err := val.ReadFrom(r)
+	v.Has, v.Val = val != 0, int32(val)-1
+	if !v.Has {
+		v.Val = 0
+	}
+	return n, err
+}
+
+func (v CatVariant) WriteTo(w io.Writer) (int64, error)         { return pk.VarInt(v).WriteTo(w) }
+func (v *CatVariant) ReadFrom(r io.Reader) (int64, error)       { return (*pk.VarInt)(v).ReadFrom(r) }
+func (v CowVariant) WriteTo(w io.Writer) (int64, error)         { return pk.VarInt(v).WriteTo(w) }
+func (v *CowVariant) ReadFrom(r io.Reader) (int64, error)       { return (*pk.VarInt)(v).ReadFrom(r) }
+func (v WolfVariant) WriteTo(w io.Writer) (int64, error)        { return pk.VarInt(v).WriteTo(w) }
+func (v *WolfVariant) ReadFrom(r io.Reader) (int64, error)      { return (*pk.VarInt)(v).ReadFrom(r) }
+func (v WolfSoundVariant) WriteTo(w io.Writer) (int64, error)   { return pk.VarInt(v).WriteTo(w) }
+func (v *WolfSoundVariant) ReadFrom(r io.Reader) (int64, error) { return (*pk.VarInt)(v).ReadFrom(r) }
+func (v FrogVariant) WriteTo(w io.Writer) (int64, error)        { return pk.VarInt(v).WriteTo(w) }
+func (v *FrogVariant) ReadFrom(r io.Reader) (int64, error)      { return (*pk.VarInt)(v).ReadFrom(r) }
+func (v PigVariant) WriteTo(w io.Writer) (int64, error)         { return pk.VarInt(v).WriteTo(w) }
+func (v *PigVariant) ReadFrom(r io.Reader) (int64, error)       { return (*pk.VarInt)(v).ReadFrom(r) }
+func (v ChickenVariant) WriteTo(w io.Writer) (int64, error)     { return pk.VarInt(v).WriteTo(w) }
+func (v *ChickenVariant) ReadFrom(r io.Reader) (int64, error)   { return (*pk.VarInt)(v).ReadFrom(r) }
+
+// PaintingDefinition is an inlined painting variant.
+type PaintingDefinition struct {
+	Width, Height int32
+	AssetID       string
+	Title         pk.Option[chat.Message, *chat.Message]
+	Author        pk.Option[chat.Message, *chat.Message]
+}
+
+func (p PaintingDefinition) WriteTo(w io.Writer) (int64, error) {
+	return pk.Tuple{
+		pk.VarInt(p.Width),
+		pk.VarInt(p.Height),
+		pk.Identifier(p.AssetID),
+		p.Title,
+		p.Author,
+	}.WriteTo(w)
+}
+
+func (p *PaintingDefinition) ReadFrom(r io.Reader) (int64, error) {
+	return pk.Tuple{
+		(*pk.VarInt)(&p.Width),
+		(*pk.VarInt)(&p.Height),
+		(*pk.Identifier)(&p.AssetID),
+		&p.Title,
+		&p.Author,
+	}.ReadFrom(r)
+}
+
+// GlobalPos is a block position in a dimension.
+type GlobalPos struct {
+	Dimension string
+	Pos       pk.Position
+}
+
+func (g GlobalPos) WriteTo(w io.Writer) (int64, error) {
+	return pk.Tuple{pk.Identifier(g.Dimension), g.Pos}.WriteTo(w)
+}
+
+func (g *GlobalPos) ReadFrom(r io.Reader) (int64, error) {
+	return pk.Tuple{(*pk.Identifier)(&g.Dimension), &g.Pos}.ReadFrom(r)
+}
+
+func (v Vector3) WriteTo(w io.Writer) (int64, error) {
+	return pk.Tuple{pk.Float(v.X), pk.Float(v.Y), pk.Float(v.Z)}.WriteTo(w)
+}
+
+func (v *Vector3) ReadFrom(r io.Reader) (int64, error) {
+	return pk.Tuple{(*pk.Float)(&v.X), (*pk.Float)(&v.Y), (*pk.Float)(&v.Z)}.ReadFrom(r)
+}
+
+func (q Quaternion) WriteTo(w io.Writer) (int64, error) {
+	return pk.Tuple{pk.Float(q.X), pk.Float(q.Y), pk.Float(q.Z), pk.Float(q.W)}.WriteTo(w)
+}
+
+func (q *Quaternion) ReadFrom(r io.Reader) (int64, error) {
+	return pk.Tuple{(*pk.Float)(&q.X), (*pk.Float)(&q.Y), (*pk.Float)(&q.Z), (*pk.Float)(&q.W)}.ReadFrom(r)
+}
+
+// Direction is the facing of a block face.
+type Direction int32
+
+const (
+	Down Direction = iota
+	Up
+	North
+	South
+	West
+	East
+)
+
+func (v Direction) WriteTo(w io.Writer) (int64, error)   { return pk.VarInt(v).WriteTo(w) }
+func (v *Direction) ReadFrom(r io.Reader) (int64, error) { return (*pk.VarInt)(v).ReadFrom(r) }
+
+// Pose is the posture of an entity.
+type Pose int32
+
+const (
+	Standing Pose = iota
+	FallFlying
+	Sleeping
+	Swimming
+	SpinAttack
+	Sneaking
+	LongJumping
+	Dying
+	Croaking
+	UsingTongue
+	Sitting
+	Roaring
+	Sniffing
+	Emerging
+	Digging
+	Sliding
+	Shooting
+	Inhaling
+)
+
+func (v Pose) WriteTo(w io.Writer) (int64, error)   { return pk.VarInt(v).WriteTo(w) }
+func (v *Pose) ReadFrom(r io.Reader) (int64, error) { return (*pk.VarInt)(v).ReadFrom(r) }
+
+type SnifferState int32
+
+const (
+	SnifferIdling SnifferState = iota
+	SnifferFeelingHappy
+	SnifferScenting
+	SnifferSniffing
+	SnifferSearching
+	SnifferDigging
+	SnifferRising
+)
+
+func (v SnifferState) WriteTo(w io.Writer) (int64, error)   { return pk.VarInt(v).WriteTo(w) }
+func (v *SnifferState) ReadFrom(r io.Reader) (int64, error) { return (*pk.VarInt)(v).ReadFrom(r) }
+
+type ArmadilloState int32
+
+const (
+	ArmadilloIdle ArmadilloState = iota
+	ArmadilloRolling
+	ArmadilloScared
+	ArmadilloUnrolling
+)
+
+func (v ArmadilloState) WriteTo(w io.Writer) (int64, error)   { return pk.VarInt(v).WriteTo(w) }
+func (v *ArmadilloState) ReadFrom(r io.Reader) (int64, error) { return (*pk.VarInt)(v).ReadFrom(r) }
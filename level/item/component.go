@@ -0,0 +1,177 @@
+package item
+
+import (
+	"fmt"
+	"io"
+
+	"git.konjactw.dev/falloutBot/go-mc/data/registryid"
+	"git.konjactw.dev/falloutBot/go-mc/nbt"
+	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
+)
+
+// ComponentType is the index of registryid.DataComponentType.
+type ComponentType int32
+
+const (
+	ComponentCustomData               ComponentType = 0
+	ComponentMaxStackSize             ComponentType = 1
+	ComponentMaxDamage                ComponentType = 2
+	ComponentDamage                   ComponentType = 3
+	ComponentUnbreakable              ComponentType = 4
+	ComponentCustomName               ComponentType = 5
+	ComponentItemName                 ComponentType = 6
+	ComponentItemModel                ComponentType = 7
+	ComponentLore                     ComponentType = 8
+	ComponentRarity                   ComponentType = 9
+	ComponentEnchantments             ComponentType = 10
+	ComponentRepairCost               ComponentType = 16
+	ComponentCreativeSlotLock         ComponentType = 17
+	ComponentEnchantmentGlintOverride ComponentType = 18
+	ComponentIntangibleProjectile     ComponentType = 19
+	ComponentUseRemainder             ComponentType = 22
+	ComponentGlider                   ComponentType = 30
+	ComponentTooltipStyle             ComponentType = 31
+	ComponentStoredEnchantments       ComponentType = 34
+	ComponentDyedColor                ComponentType = 35
+	ComponentMapColor                 ComponentType = 36
+	ComponentMapID                    ComponentType = 37
+	ComponentChargedProjectiles       ComponentType = 40
+	ComponentBundleContents           ComponentType = 41
+	ComponentOminousBottleAmplifier   ComponentType = 54
+	ComponentContainer                ComponentType = 66
+)
+
+// Name returns the identifier of the component type, e.g. "minecraft:damage".
+func (t ComponentType) Name() string {
+	if t < 0 || int(t) >= len(registryid.DataComponentType) {
+		return ""
+	}
+	return registryid.DataComponentType[t]
+}
+
+// ComponentTypeOf returns the ComponentType of the identifier, or -1 if it's unknown.
+func ComponentTypeOf(name string) ComponentType {
+	for i, v := range registryid.DataComponentType {
+		if v == name {
+			return ComponentType(i)
+		}
+	}
+	return -1
+}
+
+// Component is a data component of an item stack.
+//
+// The pointer of all components defined in this package implements [pk.FieldDecoder],
+// and the component itself is encoded by the NBT encoder when saved.
+type Component interface {
+	pk.FieldEncoder
+	ComponentType() ComponentType
+}
+
+// UnsupportedComponentErr is returned when a component without a known codec is
+// read from or written to the network.
+// Because components aren't prefixed with their length, such a stack cannot be skipped.
+type UnsupportedComponentErr struct {
+	Type ComponentType
+}
+
+func (u UnsupportedComponentErr) Error() string {
+	return fmt.Sprintf("item: unsupported component %s(%d)", u.Type.Name(), int32(u.Type))
+}
+
+type componentCodec struct {
+	decode    func(r io.Reader) (Component, int64, error)
+	decodeNBT func(m nbt.RawMessage) (Component, error)
+}
+
+var componentCodecs = map[ComponentType]componentCodec{
+	ComponentCustomData:               codecOf[CustomData](),
+	ComponentMaxStackSize:             codecOf[MaxStackSize](),
+	ComponentMaxDamage:                codecOf[MaxDamage](),
+	ComponentDamage:                   codecOf[Damage](),
+	ComponentUnbreakable:              codecOf[Unbreakable](),
+	ComponentCustomName:               codecOf[CustomName](),
+	ComponentItemName:                 codecOf[ItemName](),
+	ComponentItemModel:                codecOf[ItemModel](),
+	ComponentLore:                     codecOf[Lore](),
+	ComponentRarity:                   codecOf[Rarity](),
+	ComponentEnchantments:             codecOf[Enchantments](),
+	ComponentRepairCost:               codecOf[RepairCost](),
+	ComponentCreativeSlotLock:         codecOf[CreativeSlotLock](),
+	ComponentEnchantmentGlintOverride: codecOf[EnchantmentGlintOverride](),
+	ComponentIntangibleProjectile:     codecOf[IntangibleProjectile](),
+	ComponentUseRemainder:             codecOf[UseRemainder](),
+	ComponentGlider:                   codecOf[Glider](),
+	ComponentTooltipStyle:             codecOf[TooltipStyle](),
+	ComponentStoredEnchantments:       codecOf[StoredEnchantments](),
+	ComponentDyedColor:                codecOf[DyedColor](),
+	ComponentMapColor:                 codecOf[MapColor](),
+	ComponentMapID:                    codecOf[MapID](),
+	ComponentChargedProjectiles:       codecOf[ChargedProjectiles](),
+	ComponentBundleContents:           codecOf[BundleContents](),
+	ComponentOminousBottleAmplifier:   codecOf[OminousBottleAmplifier](),
+	ComponentContainer:                codecOf[Container](),
+}
+
+func codecOf[T Component, P interface {
+	*T
+	pk.FieldDecoder
+}]() componentCodec {
+	return componentCodec{
+		decode: func(r io.Reader) (Component, int64, error) {
+			var v T
+			n, err := P(&v).ReadFrom(r)
+			return v, n, err
+		},
+		decodeNBT: func(m nbt.RawMessage) (Component, error) {
+			var v T
+			err := m.Unmarshal(P(&v))
+			return v, err
+		},
+	}
+}
+
+// ReadComponent reads a component of type t from the network.
+func ReadComponent(t ComponentType, r io.Reader) (Component, int64, error) {
+	codec, ok := componentCodecs[t]
+	if !ok {
+		return nil, 0, UnsupportedComponentErr{Type: t}
+	}
+	return codec.decode(r)
+}
+
+// RawComponent is a component without a known codec.
+// It's only produced when decoding NBT, and can't be sent on the network.
+type RawComponent struct {
+	Type ComponentType
+	Data nbt.RawMessage
+}
+
+func (r RawComponent) ComponentType() ComponentType { return r.Type }
+
+func (r RawComponent) WriteTo(io.Writer) (int64, error) {
+	return 0, UnsupportedComponentErr{Type: r.Type}
+}
+
+func (r RawComponent) TagType() byte                { return r.Data.TagType() }
+func (r RawComponent) MarshalNBT(w io.Writer) error { return r.Data.MarshalNBT(w) }
+
+// componentFromNBT decodes a component saved in NBT.
+// Unknown components are kept as RawComponent.
+func componentFromNBT(t ComponentType, m nbt.RawMessage) (Component, error) {
+	codec, ok := componentCodecs[t]
+	if !ok {
+		return RawComponent{Type: t, Data: m}, nil
+	}
+	return codec.decodeNBT(m)
+}
+
+// componentToNBT encodes a component into NBT.
+func componentToNBT(c Component) (nbt.RawMessage, error) {
+	data, err := nbt.Marshal(c)
+	if err != nil {
+		return nbt.RawMessage{}, err
+	}
+	// skip the tag type and the empty tag name
+	return nbt.RawMessage{Type: data[0], Data: data[3:]}, nil
+}
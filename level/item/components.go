@@ -0,0 +1,303 @@
+package item
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	"git.konjactw.dev/falloutBot/go-mc/chat"
+	"git.konjactw.dev/falloutBot/go-mc/nbt"
+	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
+)
+
+type (
+	// CustomData is arbitrary NBT data, usually a compound.
+	CustomData struct{ nbt.RawMessage }
+
+	MaxStackSize           int32
+	MaxDamage              int32
+	Damage                 int32
+	RepairCost             int32
+	MapID                  int32
+	OminousBottleAmplifier int32
+
+	// The following components don't carry any data. Their presence is the meaning.
+
+	Unbreakable          struct{}
+	CreativeSlotLock     struct{}
+	IntangibleProjectile struct{}
+	Glider               struct{}
+
+	CustomName struct{ chat.Message }
+	ItemName   struct{ chat.Message }
+	Lore       []chat.Message
+
+	// ItemModel is the identifier of the item model.
+	ItemModel string
+	// TooltipStyle is the identifier of the tooltip sprites.
+	TooltipStyle string
+
+	EnchantmentGlintOverride bool
+
+	// DyedColor is the RGB color of a leather armor.
+	DyedColor int32
+	// MapColor is the RGB color of a filled map.
+	MapColor int32
+
+	// UseRemainder is the item that replaces the stack after it's used up.
+	UseRemainder struct{ Stack }
+
+	// ChargedProjectiles is the projectiles loaded into a crossbow.
+	ChargedProjectiles []Stack
+	// BundleContents is the items stored in a bundle.
+	BundleContents []Stack
+)
+
+func (CustomData) ComponentType() ComponentType             { return ComponentCustomData }
+func (MaxStackSize) ComponentType() ComponentType           { return ComponentMaxStackSize }
+func (MaxDamage) ComponentType() ComponentType              { return ComponentMaxDamage }
+func (Damage) ComponentType() ComponentType                 { return ComponentDamage }
+func (RepairCost) ComponentType() ComponentType             { return ComponentRepairCost }
+func (MapID) ComponentType() ComponentType                  { return ComponentMapID }
+func (OminousBottleAmplifier) ComponentType() ComponentType { return ComponentOminousBottleAmplifier }
+func (Unbreakable) ComponentType() ComponentType            { return ComponentUnbreakable }
+func (CreativeSlotLock) ComponentType() ComponentType       { return ComponentCreativeSlotLock }
+func (IntangibleProjectile) ComponentType() ComponentType   { return ComponentIntangibleProjectile }
+func (Glider) ComponentType() ComponentType                 { return ComponentGlider }
+func (CustomName) ComponentType() ComponentType             { return ComponentCustomName }
+func (ItemName) ComponentType() ComponentType               { return ComponentItemName }
+func (Lore) ComponentType() ComponentType                   { return ComponentLore }
+func (ItemModel) ComponentType() ComponentType              { return ComponentItemModel }
+func (TooltipStyle) ComponentType() ComponentType           { return ComponentTooltipStyle }
+func (EnchantmentGlintOverride) ComponentType() ComponentType {
+	return ComponentEnchantmentGlintOverride
+}
+func (DyedColor) ComponentType() ComponentType          { return ComponentDyedColor }
+func (MapColor) ComponentType() ComponentType           { return ComponentMapColor }
+func (UseRemainder) ComponentType() ComponentType       { return ComponentUseRemainder }
+func (ChargedProjectiles) ComponentType() ComponentType { return ComponentChargedProjectiles }
+func (BundleContents) ComponentType() ComponentType     { return ComponentBundleContents }
+
+func (c CustomData) WriteTo(w io.Writer) (int64, error)   { return pk.NBT(c.RawMessage).WriteTo(w) }
+func (c *CustomData) ReadFrom(r io.Reader) (int64, error) { return pk.NBT(&c.RawMessage).ReadFrom(r) }
+
+func (c MaxStackSize) WriteTo(w io.Writer) (int64, error)           { return pk.VarInt(c).WriteTo(w) }
+func (c *MaxStackSize) ReadFrom(r io.Reader) (int64, error)         { return (*pk.VarInt)(c).ReadFrom(r) }
+func (c MaxDamage) WriteTo(w io.Writer) (int64, error)              { return pk.VarInt(c).WriteTo(w) }
+func (c *MaxDamage) ReadFrom(r io.Reader) (int64, error)            { return (*pk.VarInt)(c).ReadFrom(r) }
+func (c Damage) WriteTo(w io.Writer) (int64, error)                 { return pk.VarInt(c).WriteTo(w) }
+func (c *Damage) ReadFrom(r io.Reader) (int64, error)               { return (*pk.VarInt)(c).ReadFrom(r) }
+func (c RepairCost) WriteTo(w io.Writer) (int64, error)             { return pk.VarInt(c).WriteTo(w) }
+func (c *RepairCost) ReadFrom(r io.Reader) (int64, error)           { return (*pk.VarInt)(c).ReadFrom(r) }
+func (c MapID) WriteTo(w io.Writer) (int64, error)                  { return pk.VarInt(c).WriteTo(w) }
+func (c *MapID) ReadFrom(r io.Reader) (int64, error)                { return (*pk.VarInt)(c).ReadFrom(r) }
+func (c OminousBottleAmplifier) WriteTo(w io.Writer) (int64, error) { return pk.VarInt(c).WriteTo(w) }
+func (c *OminousBottleAmplifier) ReadFrom(r io.Reader) (int64, error) {
+	return (*pk.VarInt)(c).ReadFrom(r)
+}
+
+func (Unbreakable) WriteTo(io.Writer) (int64, error)            { return 0, nil }
+func (*Unbreakable) ReadFrom(io.Reader) (int64, error)          { return 0, nil }
+func (CreativeSlotLock) WriteTo(io.Writer) (int64, error)       { return 0, nil }
+func (*CreativeSlotLock) ReadFrom(io.Reader) (int64, error)     { return 0, nil }
+func (IntangibleProjectile) WriteTo(io.Writer) (int64, error)   { return 0, nil }
+func (*IntangibleProjectile) ReadFrom(io.Reader) (int64, error) { return 0, nil }
+func (Glider) WriteTo(io.Writer) (int64, error)                 { return 0, nil }
+func (*Glider) ReadFrom(io.Reader) (int64, error)               { return 0, nil }
+
+func (c Lore) WriteTo(w io.Writer) (int64, error)   { return pk.Array(c).WriteTo(w) }
+func (c *Lore) ReadFrom(r io.Reader) (int64, error) { return pk.Array(c).ReadFrom(r) }
+
+func (c ItemModel) WriteTo(w io.Writer) (int64, error)      { return pk.Identifier(c).WriteTo(w) }
+func (c *ItemModel) ReadFrom(r io.Reader) (int64, error)    { return (*pk.Identifier)(c).ReadFrom(r) }
+func (c TooltipStyle) WriteTo(w io.Writer) (int64, error)   { return pk.Identifier(c).WriteTo(w) }
+func (c *TooltipStyle) ReadFrom(r io.Reader) (int64, error) { return (*pk.Identifier)(c).ReadFrom(r) }
+
+func (c EnchantmentGlintOverride) WriteTo(w io.Writer) (int64, error) {
+	return pk.Boolean(c).WriteTo(w)
+}
+
+func (c *EnchantmentGlintOverride) ReadFrom(r io.Reader) (int64, error) {
+	return (*pk.Boolean)(c).ReadFrom(r)
+}
+
+func (c DyedColor) WriteTo(w io.Writer) (int64, error)   { return pk.Int(c).WriteTo(w) }
+func (c *DyedColor) ReadFrom(r io.Reader) (int64, error) { return (*pk.Int)(c).ReadFrom(r) }
+func (c MapColor) WriteTo(w io.Writer) (int64, error)    { return pk.Int(c).WriteTo(w) }
+func (c *MapColor) ReadFrom(r io.Reader) (int64, error)  { return (*pk.Int)(c).ReadFrom(r) }
+
+func (c ChargedProjectiles) WriteTo(w io.Writer) (int64, error)   { return pk.Array(c).WriteTo(w) }
+func (c *ChargedProjectiles) ReadFrom(r io.Reader) (int64, error) { return pk.Array(c).ReadFrom(r) }
+func (c BundleContents) WriteTo(w io.Writer) (int64, error)       { return pk.Array(c).WriteTo(w) }
+func (c *BundleContents) ReadFrom(r io.Reader) (int64, error)     { return pk.Array(c).ReadFrom(r) }
+
+// Rarity affects the default color of the item name.
+type Rarity int32
+
+const (
+	Common Rarity = iota
+	Uncommon
+	Rare
+	Epic
+)
+
+var rarityNames = [...]string{"common", "uncommon", "rare", "epic"}
+
+func (Rarity) ComponentType() ComponentType           { return ComponentRarity }
+func (c Rarity) WriteTo(w io.Writer) (int64, error)   { return pk.VarInt(c).WriteTo(w) }
+func (c *Rarity) ReadFrom(r io.Reader) (int64, error) { return (*pk.VarInt)(c).ReadFrom(r) }
+
+func (c Rarity) MarshalText() ([]byte, error) {
+	if c < 0 || int(c) >= len(rarityNames) {
+		return nil, errors.New("item: invalid rarity")
+	}
+	return []byte(rarityNames[c]), nil
+}
+
+func (c *Rarity) UnmarshalText(text []byte) error {
+	for i, v := range rarityNames {
+		if v == string(text) {
+			*c = Rarity(i)
+			return nil
+		}
+	}
+	return errors.New("item: unknown rarity " + string(text))
+}
+
+// Holder is a reference to an entry of a data-driven registry, such as enchantments.
+// Only ID is used on the network, and only Key is used in NBT.
+// Because the IDs are assigned when the registries are synchronized,
+// converting between them is up to the user.
+type Holder struct {
+	ID  int32
+	Key string
+}
+
+// EnchantmentLevel is an enchantment with its level.
+type EnchantmentLevel struct {
+	Enchantment Holder
+	Level       int32
+}
+
+func (e EnchantmentLevel) WriteTo(w io.Writer) (int64, error) {
+	return pk.Tuple{pk.VarInt(e.Enchantment.ID), pk.VarInt(e.Level)}.WriteTo(w)
+}
+
+func (e *EnchantmentLevel) ReadFrom(r io.Reader) (int64, error) {
+	return pk.Tuple{(*pk.VarInt)(&e.Enchantment.ID), (*pk.VarInt)(&e.Level)}.ReadFrom(r)
+}
+
+type (
+	// Enchantments is the enchantments applied to the item.
+	Enchantments []EnchantmentLevel
+	// StoredEnchantments is the enchantments stored in an enchanted book.
+	StoredEnchantments []EnchantmentLevel
+)
+
+func (Enchantments) ComponentType() ComponentType       { return ComponentEnchantments }
+func (StoredEnchantments) ComponentType() ComponentType { return ComponentStoredEnchantments }
+
+func (e Enchantments) WriteTo(w io.Writer) (int64, error)         { return pk.Array(e).WriteTo(w) }
+func (e *Enchantments) ReadFrom(r io.Reader) (int64, error)       { return pk.Array(e).ReadFrom(r) }
+func (e StoredEnchantments) WriteTo(w io.Writer) (int64, error)   { return pk.Array(e).WriteTo(w) }
+func (e *StoredEnchantments) ReadFrom(r io.Reader) (int64, error) { return pk.Array(e).ReadFrom(r) }
+
+func (Enchantments) TagType() byte       { return nbt.TagCompound }
+func (StoredEnchantments) TagType() byte { return nbt.TagCompound }
+
+func (e Enchantments) MarshalNBT(w io.Writer) error {
+	return marshalEnchantments(w, e)
+}
+
+func (e *Enchantments) UnmarshalNBT(tagType byte, r nbt.DecoderReader) error {
+	return unmarshalEnchantments(tagType, r, (*[]EnchantmentLevel)(e))
+}
+
+func (e StoredEnchantments) MarshalNBT(w io.Writer) error {
+	return marshalEnchantments(w, e)
+}
+
+func (e *StoredEnchantments) UnmarshalNBT(tagType byte, r nbt.DecoderReader) error {
+	return unmarshalEnchantments(tagType, r, (*[]EnchantmentLevel)(e))
+}
+
+// In NBT, enchantments are saved as a compound from the enchantment key to the level.
+func marshalEnchantments(w io.Writer, levels []EnchantmentLevel) error {
+	m := make(map[string]int32, len(levels))
+	for _, v := range levels {
+		m[v.Enchantment.Key] = v.Level
+	}
+	data, err := nbt.Marshal(m)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data[3:])
+	return err
+}
+
+func unmarshalEnchantments(tagType byte, r nbt.DecoderReader, levels *[]EnchantmentLevel) error {
+	// Re-combine the tagType into the reader, and create a nbt decoder
+	decoder := nbt.NewDecoder(io.MultiReader(bytes.NewReader([]byte{tagType}), r))
+	decoder.NetworkFormat(true)
+	var m map[string]int32
+	if _, err := decoder.Decode(&m); err != nil {
+		return err
+	}
+	*levels = (*levels)[:0]
+	for key, level := range m {
+		*levels = append(*levels, EnchantmentLevel{Enchantment: Holder{Key: key}, Level: level})
+	}
+	return nil
+}
+
+// Container is the items inside a container block item, such as a shulker box.
+// The index of the slice is the slot, and empty slots are empty stacks.
+type Container []Stack
+
+func (Container) ComponentType() ComponentType { return ComponentContainer }
+
+func (c Container) WriteTo(w io.Writer) (int64, error)   { return pk.Array(c).WriteTo(w) }
+func (c *Container) ReadFrom(r io.Reader) (int64, error) { return pk.Array(c).ReadFrom(r) }
+
+// containerSlot is how a slot of Container is saved in NBT.
+type containerSlot struct {
+	Slot int32 `nbt:"slot"`
+	Item Stack `nbt:"item"`
+}
+
+func (Container) TagType() byte { return nbt.TagList }
+
+func (c Container) MarshalNBT(w io.Writer) error {
+	slots := make([]containerSlot, 0, len(c))
+	for i, v := range c {
+		if !v.IsEmpty() {
+			slots = append(slots, containerSlot{Slot: int32(i), Item: v})
+		}
+	}
+	data, err := nbt.Marshal(slots)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data[3:])
+	return err
+}
+
+func (c *Container) UnmarshalNBT(tagType byte, r nbt.DecoderReader) error {
+	decoder := nbt.NewDecoder(io.MultiReader(bytes.NewReader([]byte{tagType}), r))
+	decoder.NetworkFormat(true)
+	var slots []containerSlot
+	if _, err := decoder.Decode(&slots); err != nil {
+		return err
+	}
+	*c = (*c)[:0]
+	for _, v := range slots {
+		if v.Slot < 0 || v.Slot > 255 {
+			return errors.New("item: container slot out of range")
+		}
+		for int(v.Slot) >= len(*c) {
+			*c = append(*c, Stack{})
+		}
+		(*c)[v.Slot] = v.Item
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package item
+
+import (
+	"io"
+	"slices"
+
+	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
+)
+
+// HashedStack is how the client describes a stack in the ContainerClick packet.
+// Instead of the component data, only their hashes are sent.
+type HashedStack struct {
+	ID    ID
+	Count int32
+	// Added is the hashes of the added components.
+	Added map[ComponentType]int32
+	// Removed is the types of the removed components.
+	Removed []ComponentType
+}
+
+// ComponentHasher calculates the hash of a component the same as the client does.
+// The vanilla client uses CRC32C over the codec encoding of the component.
+type ComponentHasher func(c Component) int32
+
+// IsEmpty reports whether the hashed stack is an empty slot.
+func (h HashedStack) IsEmpty() bool {
+	return h.Count <= 0
+}
+
+// Hashed converts the stack to a HashedStack using the hasher.
+func (s Stack) Hashed(hasher ComponentHasher) (h HashedStack) {
+	if s.IsEmpty() {
+		return
+	}
+	h.ID, h.Count = s.ID, s.Count
+	added, removed := s.split()
+	if len(added) > 0 {
+		h.Added = make(map[ComponentType]int32, len(added))
+	}
+	for _, c := range added {
+		h.Added[c.ComponentType()] = hasher(c)
+	}
+	h.Removed = removed
+	return
+}
+
+// Matches reports whether the hashed stack describes the stack.
+//
+// If hasher is nil, only the item, count and the types of the components are compared.
+func (h HashedStack) Matches(s Stack, hasher ComponentHasher) bool {
+	if h.IsEmpty() || s.IsEmpty() {
+		return h.IsEmpty() == s.IsEmpty()
+	}
+	if h.ID != s.ID || h.Count != s.Count {
+		return false
+	}
+	added, removed := s.split()
+	if len(added) != len(h.Added) || !slices.Equal(removed, sortedTypes(h.Removed)) {
+		return false
+	}
+	for _, c := range added {
+		hash, ok := h.Added[c.ComponentType()]
+		if !ok || hasher != nil && hasher(c) != hash {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedTypes(types []ComponentType) []ComponentType {
+	types = slices.Clone(types)
+	slices.Sort(types)
+	return types
+}
+
+func (h HashedStack) WriteTo(w io.Writer) (n int64, err error) {
+	if h.IsEmpty() {
+		return pk.Boolean(false).WriteTo(w)
+	}
+	types := make([]ComponentType, 0, len(h.Added))
+	for t := range h.Added {
+		types = append(types, t)
+	}
+	slices.Sort(types)
+
+	n, err = pk.Tuple{
+		pk.Boolean(true),
+		pk.VarInt(h.ID),
+		pk.VarInt(h.Count),
+		pk.VarInt(len(types)),
+	}.WriteTo(w)
+	if err != nil {
+		return
+	}
+	var n1 int64
+	for _, t := range types {
+		n1, err = pk.Tuple{pk.VarInt(t), pk.Int(h.Added[t])}.WriteTo(w)
+		n += n1
+		if err != nil {
+			return
+		}
+	}
+	n1, err = pk.VarInt(len(h.Removed)).WriteTo(w)
+	n += n1
+	if err != nil {
+		return
+	}
+	for _, t := range h.Removed {
+		n1, err = pk.VarInt(t).WriteTo(w)
+		n += n1
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (h *HashedStack) ReadFrom(r io.Reader) (n int64, err error) {
+	*h = HashedStack{}
+	var has pk.Boolean
+	n, err = has.ReadFrom(r)
+	if err != nil || !has {
+		return
+	}
+	var (
+		id, count, length pk.VarInt
+		n1                int64
+	)
+	n1, err = pk.Tuple{&id, &count, &length}.ReadFrom(r)
+	n += n1
+	if err != nil {
+		return
+	}
+	h.ID, h.Count = ID(id), int32(count)
+	if length < 0 || length > MaxComponents {
+		return n, errTooManyComponents
+	}
+	if length > 0 {
+		h.Added = make(map[ComponentType]int32)
+	}
+	var (
+		t    pk.VarInt
+		hash pk.Int
+	)
+	for i := 0; i < int(length); i++ {
+		n1, err = pk.Tuple{&t, &hash}.ReadFrom(r)
+		n += n1
+		if err != nil {
+			return
+		}
+		h.Added[ComponentType(t)] = int32(hash)
+	}
+	n1, err = length.ReadFrom(r)
+	n += n1
+	if err != nil {
+		return
+	}
+	if length < 0 || length > MaxComponents {
+		return n, errTooManyComponents
+	}
+	for i := 0; i < int(length); i++ {
+		n1, err = t.ReadFrom(r)
+		n += n1
+		if err != nil {
+			return
+		}
+		h.Removed = append(h.Removed, ComponentType(t))
+	}
+	return
+}
@@ -0,0 +1,282 @@
+package item
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"git.konjactw.dev/falloutBot/go-mc/nbt"
+	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
+	"git.konjactw.dev/falloutBot/go-mc/save"
+)
+
+// Stack is a stack of items, also known as Slot in the protocol.
+//
+// A Stack with Count <= 0 is empty, and other fields are ignored.
+type Stack struct {
+	ID    ID
+	Count int32
+	// Components is the patch applied to the default components of the item.
+	// A nil Component means the default component of that type is removed.
+	Components map[ComponentType]Component
+}
+
+// IsEmpty reports whether the stack is an empty slot.
+func (s Stack) IsEmpty() bool {
+	return s.Count <= 0 || s.ID == 0 // minecraft:air
+}
+
+// Get returns the component of type t in the patch, or nil if it's absent or removed.
+func (s Stack) Get(t ComponentType) Component {
+	return s.Components[t]
+}
+
+// Set adds or replaces a component in the patch.
+func (s *Stack) Set(c Component) {
+	if s.Components == nil {
+		s.Components = make(map[ComponentType]Component)
+	}
+	s.Components[c.ComponentType()] = c
+}
+
+// Remove marks the default component of type t removed.
+func (s *Stack) Remove(t ComponentType) {
+	if s.Components == nil {
+		s.Components = make(map[ComponentType]Component)
+	}
+	s.Components[t] = nil
+}
+
+// Clone returns a copy of the stack that doesn't share the component patch with s.
+func (s Stack) Clone() Stack {
+	s.Components = maps.Clone(s.Components)
+	return s
+}
+
+// IsSameItem reports whether two stacks have the same item and components,
+// which means they can be merged into one stack. The counts are ignored.
+func (s Stack) IsSameItem(o Stack) bool {
+	if s.ID != o.ID || len(s.Components) != len(o.Components) {
+		return false
+	}
+	for t, c := range s.Components {
+		c2, ok := o.Components[t]
+		if !ok || !reflect.DeepEqual(c, c2) {
+			return false
+		}
+	}
+	return true
+}
+
+// split returns the added components and the removed component types, both sorted by type.
+func (s Stack) split() (added []Component, removed []ComponentType) {
+	types := make([]ComponentType, 0, len(s.Components))
+	for t := range s.Components {
+		types = append(types, t)
+	}
+	slices.Sort(types)
+	for _, t := range types {
+		if c := s.Components[t]; c != nil {
+			added = append(added, c)
+		} else {
+			removed = append(removed, t)
+		}
+	}
+	return
+}
+
+// WriteTo encodes the stack in the network format.
+func (s Stack) WriteTo(w io.Writer) (n int64, err error) {
+	if s.IsEmpty() {
+		return pk.VarInt(0).WriteTo(w)
+	}
+	added, removed := s.split()
+	n, err = pk.Tuple{
+		pk.VarInt(s.Count),
+		pk.VarInt(s.ID),
+		pk.VarInt(len(added)),
+		pk.VarInt(len(removed)),
+	}.WriteTo(w)
+	if err != nil {
+		return
+	}
+	var n1 int64
+	for _, c := range added {
+		n1, err = pk.Tuple{pk.VarInt(c.ComponentType()), c}.WriteTo(w)
+		n += n1
+		if err != nil {
+			return
+		}
+	}
+	for _, t := range removed {
+		n1, err = pk.VarInt(t).WriteTo(w)
+		n += n1
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// MaxComponents is the max number of the added or the removed components of a stack read from the network.
+// The counts are sent by the peer, so they are checked before reading the components.
+const MaxComponents = 256
+
+var errTooManyComponents = errors.New("item: too many components")
+
+// ReadFrom decodes the stack in the network format.
+func (s *Stack) ReadFrom(r io.Reader) (n int64, err error) {
+	*s = Stack{}
+	n, err = (*pk.VarInt)(&s.Count).ReadFrom(r)
+	if err != nil || s.Count <= 0 {
+		return
+	}
+	var (
+		id             pk.VarInt
+		added, removed pk.VarInt
+		n1             int64
+	)
+	n1, err = pk.Tuple{&id, &added, &removed}.ReadFrom(r)
+	n += n1
+	if err != nil {
+		return
+	}
+	s.ID = ID(id)
+	if added < 0 || removed < 0 {
+		return n, errors.New("item: negative number of components")
+	}
+	if added > MaxComponents || removed > MaxComponents {
+		return n, errTooManyComponents
+	}
+	if added+removed > 0 {
+		s.Components = make(map[ComponentType]Component)
+	}
+	var t pk.VarInt
+	for i := 0; i < int(added); i++ {
+		n1, err = t.ReadFrom(r)
+		n += n1
+		if err != nil {
+			return
+		}
+		var c Component
+		c, n1, err = ReadComponent(ComponentType(t), r)
+		n += n1
+		if err != nil {
+			return
+		}
+		s.Components[ComponentType(t)] = c
+	}
+	for i := 0; i < int(removed); i++ {
+		n1, err = t.ReadFrom(r)
+		n += n1
+		if err != nil {
+			return
+		}
+		s.Components[ComponentType(t)] = nil
+	}
+	return
+}
+
+// stackNBT is how a stack is saved.
+type stackNBT struct {
+	ID         string                    `nbt:"id"`
+	Count      int32                     `nbt:"count"`
+	Components map[string]nbt.RawMessage `nbt:"components,omitempty"`
+}
+
+func (s Stack) toNBT() (v stackNBT, err error) {
+	i, ok := ToID[s.ID]
+	if !ok {
+		return v, errors.New("item: unknown item id")
+	}
+	v.ID = i.Name()
+	v.Count = s.Count
+	if len(s.Components) > 0 {
+		v.Components = make(map[string]nbt.RawMessage, len(s.Components))
+	}
+	for t, c := range s.Components {
+		if c == nil {
+			// removed components are saved as "!name": {}
+			v.Components["!"+t.Name()] = nbt.RawMessage{Type: nbt.TagCompound, Data: []byte{nbt.TagEnd}}
+			continue
+		}
+		v.Components[t.Name()], err = componentToNBT(c)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (s *Stack) fromNBT(v stackNBT) error {
+	i, ok := FromID[v.ID]
+	if !ok {
+		return errors.New("item: unknown item " + v.ID)
+	}
+	*s = Stack{ID: i.ID(), Count: v.Count}
+	if len(v.Components) > 0 {
+		s.Components = make(map[ComponentType]Component, len(v.Components))
+	}
+	for name, data := range v.Components {
+		name, removed := strings.CutPrefix(name, "!")
+		t := ComponentTypeOf(name)
+		if t < 0 {
+			return errors.New("item: unknown component " + name)
+		}
+		if removed {
+			s.Components[t] = nil
+			continue
+		}
+		c, err := componentFromNBT(t, data)
+		if err != nil {
+			return err
+		}
+		s.Components[t] = c
+	}
+	return nil
+}
+
+func (s Stack) TagType() byte { return nbt.TagCompound }
+
+func (s Stack) MarshalNBT(w io.Writer) error {
+	v, err := s.toNBT()
+	if err != nil {
+		return err
+	}
+	data, err := nbt.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data[3:])
+	return err
+}
+
+func (s *Stack) UnmarshalNBT(tagType byte, r nbt.DecoderReader) error {
+	decoder := nbt.NewDecoder(io.MultiReader(bytes.NewReader([]byte{tagType}), r))
+	decoder.NetworkFormat(true)
+	var v stackNBT
+	if _, err := decoder.Decode(&v); err != nil {
+		return err
+	}
+	return s.fromNBT(v)
+}
+
+// StackFromSave converts an item saved in player data or container to a Stack.
+func StackFromSave(i *save.Item) (s Stack, err error) {
+	err = s.fromNBT(stackNBT{ID: i.ID, Count: i.Count, Components: i.Components})
+	return
+}
+
+// StackToSave converts a Stack to the saved format. The Slot field of dst is kept unchanged.
+func StackToSave(s Stack, dst *save.Item) error {
+	v, err := s.toNBT()
+	if err != nil {
+		return err
+	}
+	dst.ID, dst.Count, dst.Components = v.ID, v.Count, v.Components
+	return nil
+}
@@ -0,0 +1,145 @@
+package item
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"git.konjactw.dev/falloutBot/go-mc/chat"
+	"git.konjactw.dev/falloutBot/go-mc/data/registryid"
+	"git.konjactw.dev/falloutBot/go-mc/nbt"
+	"git.konjactw.dev/falloutBot/go-mc/save"
+)
+
+func TestComponentType(t *testing.T) {
+	for typ := range componentCodecs {
+		c, _, err := ReadComponent(typ, bytes.NewReader(make([]byte, 16)))
+		if err != nil {
+			t.Errorf("decode %s: %v", typ.Name(), err)
+			continue
+		}
+		if c.ComponentType() != typ {
+			t.Errorf("component %s has type %s", typ.Name(), c.ComponentType().Name())
+		}
+	}
+	if ComponentContainer.Name() != "minecraft:container" ||
+		ComponentOminousBottleAmplifier.Name() != "minecraft:ominous_bottle_amplifier" ||
+		ComponentStoredEnchantments.Name() != "minecraft:stored_enchantments" {
+		t.Error("component types don't match registryid.DataComponentType")
+	}
+	if ComponentTypeOf(registryid.DataComponentType[3]) != ComponentDamage {
+		t.Error("ComponentTypeOf returns a wrong type")
+	}
+}
+
+func newTestStack() Stack {
+	s := Stack{ID: Stone{}.ID(), Count: 64}
+	s.Set(Damage(3))
+	s.Set(CustomName{chat.Text("Rock")})
+	s.Set(Lore{chat.Text("line 1"), chat.Text("line 2")})
+	s.Set(Rarity(Epic))
+	s.Set(Unbreakable{})
+	s.Set(Container{{}, {ID: Stone{}.ID(), Count: 1}})
+	s.Remove(ComponentMaxStackSize)
+	return s
+}
+
+func TestStack_ReadFrom(t *testing.T) {
+	want := newTestStack()
+	var buf bytes.Buffer
+	if _, err := want.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var got Stack
+	if _, err := got.ReadFrom(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("%d bytes left unread", buf.Len())
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("stack not match: get %v, want %v", got, want)
+	}
+}
+
+func TestStack_MarshalNBT(t *testing.T) {
+	want := newTestStack()
+	want.Set(Enchantments{{Enchantment: Holder{Key: "minecraft:sharpness"}, Level: 5}})
+	data, err := nbt.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Stack
+	if err := nbt.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("stack not match: get %v, want %v", got, want)
+	}
+
+	var saved save.Item
+	if err := StackToSave(want, &saved); err != nil {
+		t.Fatal(err)
+	}
+	if saved.ID != "minecraft:stone" || saved.Components["!minecraft:max_stack_size"].Type != nbt.TagCompound {
+		t.Errorf("unexpected saved item: %v", saved)
+	}
+	got, err = StackFromSave(&saved)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("stack not match: get %v, want %v", got, want)
+	}
+}
+
+func TestHashedStack_Matches(t *testing.T) {
+	s := newTestStack()
+	hasher := func(c Component) int32 { return int32(c.ComponentType()) }
+	h := s.Hashed(hasher)
+
+	var buf bytes.Buffer
+	if _, err := h.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var got HashedStack
+	if _, err := got.ReadFrom(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !got.Matches(s, hasher) {
+		t.Error("hashed stack should match")
+	}
+	s.Count--
+	if got.Matches(s, nil) {
+		t.Error("hashed stack shouldn't match")
+	}
+	if !(HashedStack{}).Matches(Stack{}, hasher) {
+		t.Error("empty stacks should match")
+	}
+}
+
+func TestStack_ReadFrom_tooManyComponents(t *testing.T) {
+	for _, data := range [][]byte{
+		// Stack: count 1, id 1, 0x7fffffff added, 0 removed
+		{1, 1, 0xff, 0xff, 0xff, 0xff, 0x07, 0},
+		// Stack: count 1, id 1, 0 added, 257 removed
+		{1, 1, 0, 0x81, 0x02},
+	} {
+		var s Stack
+		if _, err := s.ReadFrom(bytes.NewReader(data)); !errors.Is(err, errTooManyComponents) {
+			t.Errorf("decode % x: %v", data, err)
+		}
+	}
+	for _, data := range [][]byte{
+		// HashedStack: present, id 1, count 1, 50000000 added
+		{1, 1, 1, 0x80, 0xe1, 0xeb, 0x17},
+		// HashedStack: present, id 1, count 1, 0 added, 0x7fffffff removed
+		{1, 1, 1, 0, 0xff, 0xff, 0xff, 0xff, 0x07},
+	} {
+		var h HashedStack
+		if _, err := h.ReadFrom(bytes.NewReader(data)); !errors.Is(err, errTooManyComponents) {
+			t.Errorf("decode % x: %v", data, err)
+		}
+	}
+}
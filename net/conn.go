@@ -44,9 +44,21 @@ type Conn struct {
 	io.Reader
 	io.Writer
 
+	// Observer is notified of every packet read or written successfully.
+	// This is an optional field and can be set to nil.
+	Observer PacketObserver
+
 	threshold int
 }
 
+// PacketObserver watches the packets passing through a Conn, for example to collect metrics.
+// The methods may be called concurrently if the Conn is read and written in different goroutines.
+// The Data of the packet must not be retained or modified.
+type PacketObserver interface {
+	PacketRead(p pk.Packet)
+	PacketWritten(p pk.Packet)
+}
+
 var DefaultDialer = Dialer{}
 
 // DialMC create a Minecraft connection
@@ -220,12 +232,20 @@ func (c *Conn) Close() error { return c.Socket.Close() }
 
 // ReadPacket read a Packet from Conn.
 func (c *Conn) ReadPacket(p *pk.Packet) error {
-	return p.UnPack(c.Reader, c.threshold)
+	err := p.UnPack(c.Reader, c.threshold)
+	if err == nil && c.Observer != nil {
+		c.Observer.PacketRead(*p)
+	}
+	return err
 }
 
 // WritePacket write a Packet to Conn.
 func (c *Conn) WritePacket(p pk.Packet) error {
-	return p.Pack(c.Writer, c.threshold)
+	err := p.Pack(c.Writer, c.threshold)
+	if err == nil && c.Observer != nil {
+		c.Observer.PacketWritten(p)
+	}
+	return err
 }
 
 // SetCipher load the decode/encode stream to this Conn
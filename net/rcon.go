@@ -5,15 +5,18 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"math"
 	"math/rand"
 	"net"
+	"strings"
+	"unicode/utf8"
 )
 
 const MaxRCONPackageSize = 4096
 
 // DialRCON connect to a RCON server and return the connection after login.
 // We promise the returned RCONClientConn is an RCONConn, so you can convert
-// them by type assertions if you need call the ReadPacket() or WritePacket() methods.
+// them by type assertions if you need call the ReadPacket(), WritePacket() or Exec() methods.
 func DialRCON(addr string, password string) (client RCONClientConn, err error) {
 	c := &RCONConn{ReqID: rand.Int31()}
 	client = c
@@ -59,7 +62,7 @@ func (r *RCONConn) ReadPacket() (RequestID, Type int32, Payload string, err erro
 	var Length int32
 	err = binary.Read(r, binary.LittleEndian, &Length)
 	if err != nil {
-		err = fmt.Errorf("read packet length fail: %v", err)
+		err = fmt.Errorf("read packet length fail: %w", err)
 		return
 	}
 
@@ -128,6 +131,45 @@ func (r *RCONConn) Resp() (resp string, err error) {
 	return
 }
 
+// Exec sends the command and reads its whole response, which may be split into multiple packets.
+// An empty packet with another request ID is sent after the command as a probe.
+// The server responds to the requests in order, and answers the probe with a single packet,
+// so the packets before the probe's response are joined as the command's response.
+func (r *RCONConn) Exec(cmd string) (string, error) {
+	probeID := r.probeID()
+	if err := r.WritePacket(r.ReqID, 2, cmd); err != nil {
+		return "", err
+	}
+	if err := r.WritePacket(probeID, 0, ""); err != nil {
+		return "", err
+	}
+
+	var resp strings.Builder
+	for {
+		ReqID, Type, Payload, err := r.ReadPacket()
+		if err != nil {
+			return resp.String(), err
+		}
+		switch {
+		case ReqID == probeID:
+			return resp.String(), nil
+		case ReqID != r.ReqID:
+			return resp.String(), errors.New("req ID not match")
+		case Type != 0:
+			return resp.String(), fmt.Errorf("packet type wrong: %d", Type)
+		}
+		resp.WriteString(Payload)
+	}
+}
+
+// probeID returns the request ID of the probe sent by Exec, which never equals ReqID.
+func (r *RCONConn) probeID() int32 {
+	if r.ReqID == math.MaxInt32 {
+		return 0
+	}
+	return r.ReqID + 1
+}
+
 func (r *RCONConn) AcceptLogin(password string) error {
 	R, T, P, err := r.ReadPacket()
 	if err != nil {
@@ -157,24 +199,45 @@ func (r *RCONConn) AcceptLogin(password string) error {
 	return nil
 }
 
+// AcceptCmd reads the next command.
+// The requests of other types are answered with "Unknown request" like the vanilla server does,
+// which are the probes of the clients to detect the end of the response, see Exec.
 func (r *RCONConn) AcceptCmd() (string, error) {
-	R, T, P, err := r.ReadPacket()
-	if err != nil {
-		return P, err
-	}
-
-	r.ReqID = R
-
-	// Check packet type
-	if T != 2 {
-		return P, fmt.Errorf("not a command packet: %d", T)
+	for {
+		R, T, P, err := r.ReadPacket()
+		if err != nil {
+			return P, err
+		}
+		if T == 2 {
+			r.ReqID = R
+			return P, nil
+		}
+		if err := r.WritePacket(R, 0, fmt.Sprintf("Unknown request %x", T)); err != nil {
+			return "", err
+		}
 	}
-
-	return P, nil
 }
 
+// RespCmd sends the response of the last command.
+// The response too large for a packet is split into multiple packets, see MaxRCONPackageSize.
 func (r *RCONConn) RespCmd(resp string) error {
-	return r.WritePacket(r.ReqID, 0, resp)
+	const maxPayload = MaxRCONPackageSize - (4 + 4 + 2)
+	for {
+		n := len(resp)
+		if n > maxPayload {
+			// don't split a UTF-8 character
+			n = maxPayload
+			for n > 0 && !utf8.RuneStart(resp[n]) {
+				n--
+			}
+		}
+		if err := r.WritePacket(r.ReqID, 0, resp[:n]); err != nil {
+			return err
+		}
+		if resp = resp[n:]; resp == "" {
+			return nil
+		}
+	}
 }
 
 type RCONClientConn interface {
@@ -2,7 +2,11 @@ package net
 
 import (
 	"fmt"
+	"math"
+	"net"
+	"strings"
 	"testing"
+	"unicode/utf8"
 )
 
 func Test(t *testing.T) {
@@ -135,3 +139,90 @@ func ExampleDialRCON() {
 		break
 	}
 }
+
+func TestRCONConn_RespCmd(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	resp := strings.Repeat("a", MaxRCONPackageSize) + strings.Repeat("好", 2000)
+	go func() {
+		conn := &RCONConn{Conn: server, ReqID: 7}
+		_ = conn.RespCmd(resp)
+	}()
+
+	conn := &RCONConn{Conn: client, ReqID: 7}
+	var got strings.Builder
+	for got.Len() < len(resp) {
+		_, _, part, err := conn.ReadPacket()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !utf8.ValidString(part) {
+			t.Fatal("a character is split")
+		}
+		got.WriteString(part)
+	}
+	if got.String() != resp {
+		t.Error("response is different")
+	}
+}
+
+func TestRCONConn_Exec(t *testing.T) {
+	l, err := ListenRCON("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	resp := strings.Repeat("a", MaxRCONPackageSize*2)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			cmd, err := conn.AcceptCmd()
+			if err != nil {
+				return
+			}
+			if cmd == "dump" {
+				err = conn.RespCmd(resp)
+			} else {
+				err = conn.RespCmd(cmd)
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	client, err := net.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	conn := &RCONConn{Conn: client, ReqID: 7}
+	got, err := conn.Exec("dump")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != resp {
+		t.Errorf("got a response of %d bytes, want %d", len(got), len(resp))
+	}
+
+	// the probe of the last request ID doesn't overflow
+	conn.ReqID = math.MaxInt32
+	if got, err := conn.Exec("echo"); err != nil || got != "echo" {
+		t.Errorf("got %q, %v", got, err)
+	}
+	// the response of the probe is consumed, Cmd and Resp still work after Exec
+	if err := conn.Cmd("hi"); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := conn.Resp(); err != nil || got != "hi" {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
@@ -0,0 +1,117 @@
+package realms
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Backup is a backup of the world in the active slot.
+type Backup struct {
+	BackupID         string
+	LastModifiedDate int64 // Unix milliseconds
+	Size             int64
+	Metadata         map[string]string
+}
+
+// ListBackups returns the backups of the world.
+// You must own this server.
+func (r *Realms) ListBackups(ctx context.Context, s Server) ([]Backup, error) {
+	var resp struct {
+		Backups []Backup
+	}
+	err := r.request(ctx, http.MethodGet, fmt.Sprintf("/worlds/%d/backups", s.ID), nil, &resp)
+	return resp.Backups, err
+}
+
+// DownloadInfo is where to download the latest backup of a slot.
+type DownloadInfo struct {
+	DownloadLink     string
+	ResourcePackURL  *string `json:"resourcePackUrl"`
+	ResourcePackHash *string
+}
+
+// Download returns the link of the latest backup of the slot, which is 1 to 4.
+// A RetryErr is returned if the backup is being prepared.
+// You must own this server.
+func (r *Realms) Download(ctx context.Context, s Server, slot int) (info DownloadInfo, err error) {
+	err = r.request(ctx, http.MethodGet, fmt.Sprintf("/worlds/%d/slot/%d/download", s.ID, slot), nil, &info)
+	return
+}
+
+// DownloadBackup downloads the latest backup of the slot, and writes the archive (.tar.gz) to w.
+// You must own this server.
+func (r *Realms) DownloadBackup(ctx context.Context, s Server, slot int, w io.Writer) (int64, error) {
+	info, err := r.Download(ctx, s, slot)
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, info.DownloadLink, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := r.c.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp); err != nil {
+		return 0, err
+	}
+	return io.Copy(w, resp.Body)
+}
+
+// UploadInfo is where to upload a world.
+type UploadInfo struct {
+	WorldClosed    bool
+	Token          string
+	UploadEndpoint string
+	Port           int
+}
+
+// UploadWorld replaces the world of the slot, which is 1 to 4,
+// with the archive of a world directory in the .tar.gz format.
+// The world is closed during the upload.
+// You must own this server.
+func (r *Realms) UploadWorld(ctx context.Context, s Server, slot int, archive io.Reader, size int64) error {
+	var info UploadInfo
+	if err := r.request(ctx, http.MethodPut, fmt.Sprintf("/worlds/%d/backups/upload", s.ID), nil, &info); err != nil {
+		return err
+	}
+
+	endpoint := info.UploadEndpoint
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "http://" + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+	if info.Port != 0 && u.Port() == "" {
+		u.Host += ":" + strconv.Itoa(info.Port)
+	}
+	u.Path = fmt.Sprintf("/upload/%d/%d", s.ID, slot)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), archive)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", "application/octet-stream")
+	// The upload server isn't the Domain, so the cookies are added manually, without the jar.
+	for _, c := range r.cookies {
+		req.AddCookie(c)
+	}
+	req.AddCookie(&http.Cookie{Name: "token", Value: info.Token})
+	client := http.Client{Transport: r.c.Transport}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return checkStatus(resp)
+}
@@ -14,7 +14,7 @@ func (r *Realms) Available() (ok bool, err error) {
 //	if the client is running a snapshot, it returns OTHER,
 //	else it returns COMPATIBLE.
 func (r *Realms) Compatible() (string, error) {
-	resp, err := r.c.Get(Domain + "/mco/client/compatible")
+	resp, err := r.c.Get(r.url + "/mco/client/compatible")
 	if err != nil {
 		return "", err
 	}
@@ -28,7 +28,7 @@ func (r *Realms) Compatible() (string, error) {
 // TOS is what to join Realms servers you must agree to.
 // Call this function will set this flag.
 func (r *Realms) TOS() error {
-	resp, err := r.c.Post(Domain+"/mco/tos/agreed", "application/json", nil)
+	resp, err := r.c.Post(r.url+"/mco/tos/agreed", "application/json", nil)
 	if err != nil {
 		return err
 	}
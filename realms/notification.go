@@ -0,0 +1,44 @@
+package realms
+
+import (
+	"context"
+	"net/http"
+)
+
+// News returns the link of the Realms news page.
+func (r *Realms) News(ctx context.Context) (string, error) {
+	var resp struct{ NewsLink string }
+	err := r.request(ctx, http.MethodGet, "/mco/v1/news", nil, &resp)
+	return resp.NewsLink, err
+}
+
+// Notification is a message from Realms, shown in the Realms screen.
+type Notification struct {
+	NotificationUUID string `json:"notificationUuid"`
+	Dismissable      bool
+	Seen             bool
+	// Type is "visitUrl" or "infoPopup".
+	Type string
+	// URL and ButtonText are set for the "visitUrl" notifications.
+	URL        string `json:"url"`
+	ButtonText map[string]any
+	// Message is the translatable text of the notification.
+	Message map[string]any
+}
+
+// Notifications returns the notifications of the user.
+func (r *Realms) Notifications(ctx context.Context) ([]Notification, error) {
+	var resp struct{ Notifications []Notification }
+	err := r.request(ctx, http.MethodGet, "/notifications", nil, &resp)
+	return resp.Notifications, err
+}
+
+// MarkNotificationsSeen marks the notifications as seen by their UUIDs.
+func (r *Realms) MarkNotificationsSeen(ctx context.Context, uuids ...string) error {
+	return r.request(ctx, http.MethodPost, "/notifications/seen", uuids, nil)
+}
+
+// DismissNotifications removes the dismissable notifications by their UUIDs.
+func (r *Realms) DismissNotifications(ctx context.Context, uuids ...string) error {
+	return r.request(ctx, http.MethodPost, "/notifications/dismiss", uuids, nil)
+}
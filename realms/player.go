@@ -0,0 +1,66 @@
+package realms
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Uninvite removes the player from the server's invited players.
+// You must own this server.
+func (r *Realms) Uninvite(ctx context.Context, s Server, uuid string) error {
+	return r.request(ctx, http.MethodDelete, fmt.Sprintf("/invites/%d/invite/%s", s.ID, uuid), nil, nil)
+}
+
+// Leave removes yourself from the invited players of the server.
+func (r *Realms) Leave(ctx context.Context, s Server) error {
+	return r.request(ctx, http.MethodDelete, fmt.Sprintf("/invites/%d", s.ID), nil, nil)
+}
+
+// Op makes the player an operator, and returns the operators of the server.
+// You must own this server.
+func (r *Realms) Op(ctx context.Context, s Server, uuid string) ([]string, error) {
+	var resp struct{ Ops []string }
+	err := r.request(ctx, http.MethodPost, fmt.Sprintf("/ops/%d/%s", s.ID, uuid), nil, &resp)
+	return resp.Ops, err
+}
+
+// Deop removes the player from the operators, and returns the operators of the server.
+// You must own this server.
+func (r *Realms) Deop(ctx context.Context, s Server, uuid string) ([]string, error) {
+	var resp struct{ Ops []string }
+	err := r.request(ctx, http.MethodDelete, fmt.Sprintf("/ops/%d/%s", s.ID, uuid), nil, &resp)
+	return resp.Ops, err
+}
+
+// PendingInvite is an invitation to a Realms server, which you haven't accepted.
+type PendingInvite struct {
+	InvitationID   string
+	WorldName      string
+	WorldOwnerName string
+	WorldOwnerUUID string `json:"worldOwnerUuid"`
+	Date           int64  // Unix milliseconds
+}
+
+// PendingInvites returns the invitations you haven't accepted or rejected.
+func (r *Realms) PendingInvites(ctx context.Context) ([]PendingInvite, error) {
+	var resp struct{ Invites []PendingInvite }
+	err := r.request(ctx, http.MethodGet, "/invites/pending", nil, &resp)
+	return resp.Invites, err
+}
+
+// PendingInvitesCount returns the number of invitations you haven't accepted or rejected.
+func (r *Realms) PendingInvitesCount(ctx context.Context) (count int, err error) {
+	err = r.request(ctx, http.MethodGet, "/invites/count/pending", nil, &count)
+	return
+}
+
+// AcceptInvite accepts the invitation, then the server is listed in Worlds.
+func (r *Realms) AcceptInvite(ctx context.Context, invitationID string) error {
+	return r.request(ctx, http.MethodPut, "/invites/accept/"+invitationID, nil, nil)
+}
+
+// RejectInvite rejects the invitation.
+func (r *Realms) RejectInvite(ctx context.Context, invitationID string) error {
+	return r.request(ctx, http.MethodPut, "/invites/reject/"+invitationID, nil, nil)
+}
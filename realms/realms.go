@@ -2,34 +2,67 @@ package realms
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/cookiejar"
 	"net/url"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type Realms struct {
-	c http.Client
+	c   http.Client
+	url string
+	// cookies are also sent to the upload server, which isn't the Domain.
+	cookies []*http.Cookie
 }
 
 type Error struct {
 	ErrorCode int
 	ErrorMsg  string
+	// StatusCode is the HTTP status of the response, only set by the methods with a context.
+	StatusCode int `json:"-"`
 }
 
 func (e *Error) Error() string {
 	return fmt.Sprintf("[%d] %s", e.ErrorCode, e.ErrorMsg)
 }
 
+// RetryErr is returned when the Realms server is busy preparing the request,
+// such as starting the world or generating the download link. Retry after the duration.
+type RetryErr struct {
+	After time.Duration
+}
+
+func (r RetryErr) Error() string {
+	return fmt.Sprintf("realms: retry after %v", r.After)
+}
+
 // Domain is the URL of Realms API server
 // Panic if it cannot be parsed by url.Parse().
 var Domain = "https://pc.realms.minecraft.net"
 
 // New create a new Realms c with version, username, accessToken and UUID without dashes.
 func New(version, user, astk, uuid string) *Realms {
+	return NewWithURL(Domain, version, user, astk, uuid)
+}
+
+// NewWithURL is the same as New, but requests the Realms API server at baseURL instead of Domain,
+// which can be a local fake server for testing.
+// Panic if baseURL cannot be parsed by url.Parse().
+func NewWithURL(baseURL, version, user, astk, uuid string) *Realms {
 	r := &Realms{
-		c: http.Client{},
+		c:   http.Client{},
+		url: strings.TrimSuffix(baseURL, "/"),
+		cookies: []*http.Cookie{
+			{Name: "user", Value: user},
+			{Name: "version", Value: version},
+			{Name: "sid", Value: "token:" + astk + ":" + uuid},
+		},
 	}
 
 	var err error
@@ -38,22 +71,18 @@ func New(version, user, astk, uuid string) *Realms {
 		panic(err)
 	}
 
-	d, err := url.Parse(Domain)
+	d, err := url.Parse(r.url)
 	if err != nil {
-		panic("cannot parse realms.Domain as url: " + err.Error())
+		panic("cannot parse realms URL: " + err.Error())
 	}
 
-	r.c.Jar.SetCookies(d, []*http.Cookie{
-		{Name: "user", Value: user},
-		{Name: "version", Value: version},
-		{Name: "sid", Value: "token:" + astk + ":" + uuid},
-	})
+	r.c.Jar.SetCookies(d, r.cookies)
 
 	return r
 }
 
 func (r *Realms) get(endpoint string, resp any) error {
-	rawResp, err := r.c.Get(Domain + endpoint)
+	rawResp, err := r.c.Get(r.url + endpoint)
 	if err != nil {
 		return err
 	}
@@ -73,7 +102,7 @@ func (r *Realms) post(endpoint string, payload, resp any) error {
 		return err
 	}
 
-	rawResp, err := r.c.Post(Domain+endpoint, "application/json", bytes.NewReader(data))
+	rawResp, err := r.c.Post(r.url+endpoint, "application/json", bytes.NewReader(data))
 	if err != nil {
 		return err
 	}
@@ -85,3 +114,54 @@ func (r *Realms) post(endpoint string, payload, resp any) error {
 
 	return nil
 }
+
+// request sends a request with the context, and decodes the JSON response into resp if it's not nil.
+// The payload is encoded as JSON if it's not nil.
+// An *Error is returned if the server responds an error status.
+func (r *Realms) request(ctx context.Context, method, endpoint string, payload, resp any) error {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, r.url+endpoint, body)
+	if err != nil {
+		return err
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	rawResp, err := r.c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer rawResp.Body.Close()
+
+	if err := checkStatus(rawResp); err != nil {
+		return err
+	}
+	if resp == nil {
+		return nil
+	}
+	return json.NewDecoder(rawResp.Body).Decode(resp)
+}
+
+// checkStatus returns a RetryErr or *Error if the response isn't successful.
+func checkStatus(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	if resp.StatusCode == http.StatusServiceUnavailable {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			return RetryErr{After: time.Duration(seconds) * time.Second}
+		}
+	}
+	e := &Error{StatusCode: resp.StatusCode}
+	if err := json.NewDecoder(resp.Body).Decode(e); err != nil || e.ErrorMsg == "" {
+		e.ErrorMsg = http.StatusText(resp.StatusCode)
+	}
+	return e
+}
@@ -1,7 +1,17 @@
 package realms
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
 	"time"
 )
 
@@ -34,3 +44,90 @@ func ExampleRealms() {
 	time.Sleep(time.Second * 5)
 	fmt.Println(r.Address(servers[0]))
 }
+
+func TestRealms_fake(t *testing.T) {
+	var (
+		open   bool
+		slot   int
+		ops    = []string{"owner"}
+		upload []byte
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /worlds/1/open", func(w http.ResponseWriter, r *http.Request) {
+		if c, err := r.Cookie("sid"); err != nil || c.Value != "token:astk:uuid" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"errorCode":401,"errorMsg":"Unauthorized"}`))
+			return
+		}
+		open = true
+		_, _ = w.Write([]byte("true"))
+	})
+	mux.HandleFunc("PUT /worlds/1/slot/{slot}", func(w http.ResponseWriter, r *http.Request) {
+		slot, _ = strconv.Atoi(r.PathValue("slot"))
+		_, _ = w.Write([]byte("true"))
+	})
+	mux.HandleFunc("POST /ops/1/{uuid}", func(w http.ResponseWriter, r *http.Request) {
+		ops = append(ops, r.PathValue("uuid"))
+		_ = json.NewEncoder(w).Encode(map[string]any{"ops": ops})
+	})
+	mux.HandleFunc("GET /worlds/1/slot/2/download", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"downloadLink": "http://" + r.Host + "/archive"})
+	})
+	mux.HandleFunc("GET /worlds/1/slot/3/download", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "5")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	mux.HandleFunc("GET /archive", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("world"))
+	})
+	mux.HandleFunc("PUT /worlds/1/backups/upload", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"worldClosed": true, "token": "upload", "uploadEndpoint": r.Host})
+	})
+	mux.HandleFunc("POST /upload/1/4", func(w http.ResponseWriter, r *http.Request) {
+		if c, err := r.Cookie("token"); err != nil || c.Value != "upload" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		upload, _ = io.ReadAll(r.Body)
+	})
+	mux.HandleFunc("GET /invites/pending", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"invites":[{"invitationId":"42","worldName":"Realm","worldOwnerName":"jeb_"}]}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	ctx := context.Background()
+	s := Server{ID: 1}
+	r := NewWithURL(ts.URL, "1.21.5", "Name", "astk", "uuid")
+	if err := r.Open(ctx, s); err != nil || !open {
+		t.Errorf("open world: %v", err)
+	}
+	if err := r.SwitchSlot(ctx, s, 2); err != nil || slot != 2 {
+		t.Errorf("switch slot: %v", err)
+	}
+	if ops, err := r.Op(ctx, s, "player"); err != nil || len(ops) != 2 {
+		t.Errorf("op player: %v, %v", ops, err)
+	}
+	var archive bytes.Buffer
+	if _, err := r.DownloadBackup(ctx, s, 2, &archive); err != nil || archive.String() != "world" {
+		t.Errorf("download backup: %q, %v", archive.String(), err)
+	}
+	if _, err := r.Download(ctx, s, 3); !errors.As(err, new(RetryErr)) {
+		t.Errorf("want RetryErr, got %v", err)
+	}
+	if err := r.UploadWorld(ctx, s, 4, strings.NewReader("new world"), 9); err != nil || string(upload) != "new world" {
+		t.Errorf("upload world: %q, %v", upload, err)
+	}
+	if invites, err := r.PendingInvites(ctx); err != nil || len(invites) != 1 || invites[0].InvitationID != "42" {
+		t.Errorf("pending invites: %+v, %v", invites, err)
+	}
+	var realmsErr *Error
+	if err := r.Close(ctx, s); !errors.As(err, &realmsErr) || realmsErr.StatusCode != http.StatusNotFound {
+		t.Errorf("want *Error, got %v", err)
+	}
+
+	unauthorized := NewWithURL(ts.URL, "1.21.5", "Name", "wrong", "uuid")
+	if err := unauthorized.Open(ctx, s); !errors.As(err, &realmsErr) || realmsErr.ErrorCode != 401 {
+		t.Errorf("want *Error 401, got %v", err)
+	}
+}
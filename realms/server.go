@@ -96,6 +96,8 @@ func (r *Realms) Address(s Server) (string, error) {
 }
 
 // Backups returns a list of backups for the world.
+//
+// Deprecated: The backup IDs aren't integers, use ListBackups.
 func (r *Realms) Backups(s Server) ([]int, error) {
 	var bs []int
 	err := r.get(fmt.Sprintf("/worlds/%d/backups", s.ID), &bs)
@@ -103,18 +105,6 @@ func (r *Realms) Backups(s Server) ([]int, error) {
 	return bs, err
 }
 
-//func (r *Realms) Download() (link, resURL, resHash string) {
-//	var resp struct {
-//		DownloadLink     string
-//		ResourcePackURL  *string
-//		ResourcePackHash *string
-//		*Error
-//	}
-// TODO: What's the $WORLD(1-4) means?
-//	err := r.get(fmt.Sprintf("/worlds/$ID/slot/$WORLD(1-4)/download", s.ID), &resp)
-//
-//}
-
 // Ops returns a list of operators for this server.
 // You must own this server to view this.
 func (r *Realms) Ops(s Server) (ops []string, err error) {
@@ -0,0 +1,55 @@
+package realms
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Open opens the world, so the invited players can join it.
+// You must own this server.
+func (r *Realms) Open(ctx context.Context, s Server) error {
+	return r.request(ctx, http.MethodPut, fmt.Sprintf("/worlds/%d/open", s.ID), nil, nil)
+}
+
+// Close closes the world, so no one can join it.
+// You must own this server.
+func (r *Realms) Close(ctx context.Context, s Server) error {
+	return r.request(ctx, http.MethodPut, fmt.Sprintf("/worlds/%d/close", s.ID), nil, nil)
+}
+
+// LevelType is the world generator used by ResetWorld.
+type LevelType int
+
+const (
+	LevelDefault LevelType = iota
+	LevelFlat
+	LevelLargeBiomes
+	LevelAmplified
+)
+
+// ResetOptions are how the world of the active slot is regenerated.
+type ResetOptions struct {
+	Seed string `json:"seed"`
+	// WorldTemplateID is the template to use, -1 to generate a new world.
+	WorldTemplateID    int64     `json:"worldTemplateId"`
+	LevelType          LevelType `json:"levelType"`
+	GenerateStructures bool      `json:"generateStructures"`
+	// Experiments are the enabled experimental data packs.
+	Experiments []string `json:"experiments"`
+}
+
+// ResetWorld regenerates the world of the active slot. The old world is lost, except for its backups.
+// You must own this server.
+func (r *Realms) ResetWorld(ctx context.Context, s Server, opts ResetOptions) error {
+	if opts.Experiments == nil {
+		opts.Experiments = []string{}
+	}
+	return r.request(ctx, http.MethodPost, fmt.Sprintf("/worlds/%d/reset", s.ID), opts, nil)
+}
+
+// SwitchSlot changes the active world to the slot, which is 1 to 4.
+// You must own this server.
+func (r *Realms) SwitchSlot(ctx context.Context, s Server, slot int) error {
+	return r.request(ctx, http.MethodPut, fmt.Sprintf("/worlds/%d/slot/%d", s.ID, slot), nil, nil)
+}
@@ -69,11 +69,13 @@ type PlayerData struct {
 	} `nbt:"recipeBook"`
 }
 
+// Item is an item stack saved in the 1.20.5+ format.
+// Components are kept raw, use item.StackFromSave to decode them.
 type Item struct {
-	Count byte
-	Slot  byte
-	ID    string         `nbt:"id"`
-	Tag   map[string]any `nbt:"tag"`
+	Slot       byte                      `nbt:"Slot"`
+	ID         string                    `nbt:"id"`
+	Count      int32                     `nbt:"count"`
+	Components map[string]nbt.RawMessage `nbt:"components,omitempty"`
 }
 
 func ReadPlayerData(r io.Reader) (data PlayerData, err error) {
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"bytes"
+	"context"
 	"crypto/aes"
 	"crypto/rand"
 	"crypto/rsa"
@@ -11,8 +12,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
-	"net/http"
+	"log/slog"
+	"slices"
 	"strings"
 
 	"github.com/google/uuid"
@@ -21,6 +22,7 @@ import (
 	"git.konjactw.dev/falloutBot/go-mc/net"
 	"git.konjactw.dev/falloutBot/go-mc/net/CFB8"
 	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
+	"git.konjactw.dev/falloutBot/go-mc/server/internal/discard"
 	"git.konjactw.dev/falloutBot/go-mc/yggdrasil/user"
 )
 
@@ -33,8 +35,30 @@ type (
 
 const verifyTokenLen = 16
 
-// Encrypt a connection, with authentication
-func Encrypt(conn *net.Conn, name string, serverKey *rsa.PrivateKey) (*Resp, error) {
+// Options configures Encrypt. The zero value authenticates players by the MojangSessionServer.
+type Options struct {
+	// SessionService verifies the player. MojangSessionServer is used if it's nil.
+	SessionService SessionService
+	// IP is the player's address sent to the session service if it's not empty,
+	// which is used to prevent proxy connections.
+	IP string
+	// Logger receives the records of the progress. This is an optional field and can be set to nil.
+	Logger *slog.Logger
+}
+
+// Encrypt a connection, with authentication.
+//
+// ErrNotJoined is returned if the player isn't verified by the session service,
+// and ErrAuthServersDown is returned if the session service is unavailable.
+func Encrypt(ctx context.Context, conn *net.Conn, name string, serverKey *rsa.PrivateKey, opts Options) (*Resp, error) {
+	logger := opts.Logger
+	if logger == nil {
+		logger = discard.Logger
+	}
+	var session SessionService = MojangSessionServer
+	if opts.SessionService != nil {
+		session = opts.SessionService
+	}
 	publicKey, err := x509.MarshalPKIXPublicKey(&serverKey.PublicKey)
 	if err != nil {
 		return nil, err
@@ -52,9 +76,12 @@ func Encrypt(conn *net.Conn, name string, serverKey *rsa.PrivateKey) (*Resp, err
 		return nil, err
 	}
 
+	logger.Debug("encryption request sent")
+
 	// encryption response
 	SharedSecret, err := encryptionResponse(conn, serverKey, verifyToken)
 	if err != nil {
+		logger.Debug("invalid encryption response", "error", err)
 		return nil, err
 	}
 
@@ -68,11 +95,17 @@ func Encrypt(conn *net.Conn, name string, serverKey *rsa.PrivateKey) (*Resp, err
 		CFB8.NewCFB8Encrypt(block, SharedSecret),
 		CFB8.NewCFB8Decrypt(block, SharedSecret),
 	)
+	logger.Debug("encryption enabled")
 	hash := authDigest("", SharedSecret, publicKey)
-	resp, err := authentication(name, hash) // auth
-	if err != nil {
-		return nil, errors.New("auth servers down")
+	resp, err := session.HasJoined(ctx, name, hash, opts.IP) // auth
+	if errors.Is(err, ErrNotJoined) {
+		logger.Info("player not verified by session server", "name", name)
+		return nil, err
+	} else if err != nil {
+		logger.Error("session server request error", "name", name, "error", err)
+		return nil, ErrAuthServersDown
 	}
+	logger.Debug("player authenticated", "name", resp.Name, "uuid", resp.ID)
 
 	return resp, nil
 }
@@ -83,6 +116,7 @@ func encryptionRequest(conn *net.Conn, publicKey, verifyToken []byte) error {
 		pk.String(""),
 		pk.ByteArray(publicKey),
 		pk.ByteArray(verifyToken),
+		pk.Boolean(true), // should authenticate
 	))
 }
 
@@ -121,24 +155,6 @@ func encryptionResponse(conn *net.Conn, serverKey *rsa.PrivateKey, verifyToken [
 	return sharedSecret, nil
 }
 
-func authentication(name, hash string) (*Resp, error) {
-	resp, err := http.Get("https://sessionserver.mojang.com/session/minecraft/hasJoined?username=" + name + "&serverId=" + hash)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	var Resp Resp
-	err = json.Unmarshal(body, &Resp)
-
-	return &Resp, err
-}
-
 // authDigest computes a special SHA-1 digest required for Minecraft web
 // authentication on Premium servers (online-mode=true).
 // Source: http://wiki.vg/Protocol_Encryption#Server
@@ -189,8 +205,12 @@ type Resp struct {
 
 // Texture unmarshal the base64 encoded texture of Resp
 func (r *Resp) Texture() (t user.Texture, err error) {
+	i := slices.IndexFunc(r.Properties, func(p user.Property) bool { return p.Name == "textures" })
+	if i < 0 {
+		return t, errors.New("textures property not found")
+	}
 	var texture []byte
-	texture, err = base64.StdEncoding.DecodeString(r.Properties[0].Value)
+	texture, err = base64.StdEncoding.DecodeString(r.Properties[i].Value)
 	if err != nil {
 		return
 	}
@@ -1,10 +1,17 @@
 package auth
 
 import (
+	"bytes"
 	"encoding/json"
+	"io"
+	stdnet "net"
 	"testing"
 
 	"github.com/google/uuid"
+
+	"git.konjactw.dev/falloutBot/go-mc/data/packetid"
+	"git.konjactw.dev/falloutBot/go-mc/net"
+	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
 )
 
 func TestResp(t *testing.T) {
@@ -56,3 +63,46 @@ func TestResp(t *testing.T) {
 			wantCAPE)
 	}
 }
+
+func TestEncryptionRequest(t *testing.T) {
+	server, client := stdnet.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	publicKey, verifyToken := []byte{1, 2, 3}, []byte{4, 5, 6, 7}
+	errs := make(chan error, 1)
+	go func() { errs <- encryptionRequest(net.WrapConn(server), publicKey, verifyToken) }()
+
+	var p pk.Packet
+	if err := net.WrapConn(client).ReadPacket(&p); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-errs; err != nil {
+		t.Fatal(err)
+	}
+	if packetid.ClientboundPacketID(p.ID) != packetid.ClientboundLoginHello {
+		t.Fatalf("want encryption request, get %#02X", p.ID)
+	}
+
+	var (
+		serverID               pk.String
+		gotPublicKey, gotToken pk.ByteArray
+		shouldAuthenticate     pk.Boolean
+		r                      = bytes.NewReader(p.Data)
+	)
+	for _, f := range []io.ReaderFrom{&serverID, &gotPublicKey, &gotToken, &shouldAuthenticate} {
+		if _, err := f.ReadFrom(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if serverID != "" || !bytes.Equal(gotPublicKey, publicKey) || !bytes.Equal(gotToken, verifyToken) {
+		t.Errorf("server id %q, public key %v, verify token %v", serverID, gotPublicKey, gotToken)
+	}
+	// the client authenticates with the session server if the field is true
+	if !shouldAuthenticate {
+		t.Error("should authenticate isn't set")
+	}
+	if r.Len() != 0 {
+		t.Errorf("%d bytes left after the should authenticate field", r.Len())
+	}
+}
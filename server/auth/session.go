@@ -0,0 +1,273 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	stdnet "net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"git.konjactw.dev/falloutBot/go-mc/yggdrasil/user"
+)
+
+var (
+	// ErrNotJoined is returned by SessionService.HasJoined if the player didn't join the server,
+	// which means the player's account isn't verified.
+	ErrNotJoined = errors.New("player has not joined the server")
+	// ErrAuthServersDown is returned by Encrypt if the session service can't be reached.
+	ErrAuthServersDown = errors.New("auth servers down")
+)
+
+// SessionService verifies the players in online mode.
+type SessionService interface {
+	// HasJoined checks if the player has joined the server identified by serverID,
+	// and returns the player's profile.
+	// If ip isn't empty, the player must join from this address.
+	// ErrNotJoined is returned if the player is not verified.
+	HasJoined(ctx context.Context, name, serverID, ip string) (*Resp, error)
+}
+
+// SessionServer is a SessionService calling the HTTP API of a Yggdrasil session server.
+type SessionServer struct {
+	// URL is the root of the session server API, without the trailing "/session/minecraft".
+	URL string
+	// Client is used to send the requests. http.DefaultClient is used if it's nil.
+	Client *http.Client
+	// Timeout limits the time of each request. No limit if it's zero.
+	Timeout time.Duration
+	// MaxRetries is the number of retries when the server is unreachable or responds 5xx.
+	MaxRetries int
+	// RetryInterval is the wait time before the first retry, and doubled for each next retry.
+	RetryInterval time.Duration
+}
+
+// MojangSessionServer is the session server of the official Minecraft accounts.
+var MojangSessionServer = &SessionServer{
+	URL:           "https://sessionserver.mojang.com",
+	Timeout:       time.Second * 10,
+	MaxRetries:    2,
+	RetryInterval: time.Millisecond * 500,
+}
+
+// AuthlibInjector returns a SessionServer of an authlib-injector compatible authentication server.
+// The apiRoot is the same URL passed to the -javaagent option of authlib-injector,
+// such as "https://example.com/api/yggdrasil".
+func AuthlibInjector(apiRoot string) *SessionServer {
+	return &SessionServer{
+		URL:           strings.TrimSuffix(apiRoot, "/") + "/sessionserver",
+		Timeout:       MojangSessionServer.Timeout,
+		MaxRetries:    MojangSessionServer.MaxRetries,
+		RetryInterval: MojangSessionServer.RetryInterval,
+	}
+}
+
+// HasJoined implements SessionService for SessionServer
+func (s *SessionServer) HasJoined(ctx context.Context, name, serverID, ip string) (*Resp, error) {
+	query := url.Values{"username": {name}, "serverId": {serverID}}
+	if ip != "" {
+		query.Set("ip", ip)
+	}
+	reqURL := strings.TrimSuffix(s.URL, "/") + "/session/minecraft/hasJoined?" + query.Encode()
+
+	interval := s.RetryInterval
+	for retry := 0; ; retry++ {
+		resp, err := s.hasJoined(ctx, reqURL)
+		if err == nil || errors.Is(err, ErrNotJoined) || retry >= s.MaxRetries || ctx.Err() != nil {
+			return resp, err
+		}
+		var statusErr sessionStatusErr
+		if errors.As(err, &statusErr) && statusErr.code < 500 {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(interval):
+			interval *= 2
+		}
+	}
+}
+
+func (s *SessionServer) hasJoined(ctx context.Context, reqURL string) (*Resp, error) {
+	if s.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.Timeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNoContent:
+		return nil, ErrNotJoined
+	default:
+		return nil, sessionStatusErr{code: resp.StatusCode}
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var profile Resp
+	if err := json.Unmarshal(body, &profile); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+type sessionStatusErr struct {
+	code int
+}
+
+func (s sessionStatusErr) Error() string {
+	return fmt.Sprintf("session server responds status %d", s.code)
+}
+
+// LocalSessionServer is an in-process session server, which can be used to test online-mode logins offline.
+//
+// It implements SessionService, so it can be used by the server directly.
+// It's also an http.Handler implementing the "join" and "hasJoined" API of the Yggdrasil session server,
+// so clients can join it over HTTP, and SessionServer can use it as the URL.
+type LocalSessionServer struct {
+	// profiles is the registered players by access token.
+	profiles map[string]Resp
+	// joined is the profiles joined servers by serverID and the name.
+	joined map[localJoinKey]localJoin
+	lock   sync.Mutex
+}
+
+type localJoinKey struct {
+	serverID, name string
+}
+
+type localJoin struct {
+	profile Resp
+	ip      string
+}
+
+func NewLocalSessionServer() *LocalSessionServer {
+	return &LocalSessionServer{
+		profiles: make(map[string]Resp),
+		joined:   make(map[localJoinKey]localJoin),
+	}
+}
+
+// AddProfile registers the player, and it can join servers with the accessToken.
+func (l *LocalSessionServer) AddProfile(accessToken string, profile Resp) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.profiles[accessToken] = profile
+}
+
+// Join is what a client does before responding the encryption request.
+// The serverID is the hash computed by the client. The ip is recorded if it's not empty.
+func (l *LocalSessionServer) Join(accessToken string, profileID uuid.UUID, serverID, ip string) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	profile, ok := l.profiles[accessToken]
+	if !ok || profile.ID != profileID {
+		return errors.New("invalid access token")
+	}
+	l.joined[localJoinKey{serverID: serverID, name: profile.Name}] = localJoin{profile: profile, ip: ip}
+	return nil
+}
+
+// HasJoined implements SessionService for LocalSessionServer.
+// Each join can only be verified once.
+func (l *LocalSessionServer) HasJoined(_ context.Context, name, serverID, ip string) (*Resp, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	key := localJoinKey{serverID: serverID, name: name}
+	join, ok := l.joined[key]
+	if !ok || (ip != "" && join.ip != "" && ip != join.ip) {
+		return nil, ErrNotJoined
+	}
+	delete(l.joined, key)
+	profile := join.profile
+	profile.Properties = append([]user.Property(nil), profile.Properties...)
+	return &profile, nil
+}
+
+// ServeHTTP implements http.Handler for LocalSessionServer
+func (l *LocalSessionServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/session/minecraft/join":
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		var req struct {
+			AccessToken     string `json:"accessToken"`
+			SelectedProfile string `json:"selectedProfile"`
+			ServerID        string `json:"serverId"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeYggdrasilErr(w, http.StatusBadRequest, "IllegalArgumentException", err.Error())
+			return
+		}
+		id, err := uuid.Parse(req.SelectedProfile)
+		if err != nil {
+			writeYggdrasilErr(w, http.StatusBadRequest, "IllegalArgumentException", err.Error())
+			return
+		}
+		ip, _, _ := stdnet.SplitHostPort(r.RemoteAddr)
+		if err := l.Join(req.AccessToken, id, req.ServerID, ip); err != nil {
+			writeYggdrasilErr(w, http.StatusForbidden, "ForbiddenOperationException", "Invalid token.")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case "/session/minecraft/hasJoined":
+		query := r.URL.Query()
+		profile, err := l.HasJoined(r.Context(), query.Get("username"), query.Get("serverId"), query.Get("ip"))
+		if err != nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		type property struct {
+			Name      string `json:"name"`
+			Value     string `json:"value"`
+			Signature string `json:"signature,omitempty"`
+		}
+		properties := make([]property, len(profile.Properties))
+		for i, p := range profile.Properties {
+			properties[i] = property(p)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(struct {
+			ID         string     `json:"id"`
+			Name       string     `json:"name"`
+			Properties []property `json:"properties"`
+		}{
+			ID:         strings.ReplaceAll(profile.ID.String(), "-", ""),
+			Name:       profile.Name,
+			Properties: properties,
+		})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func writeYggdrasilErr(w http.ResponseWriter, code int, err, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err, "errorMessage": msg})
+}
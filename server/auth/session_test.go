@@ -0,0 +1,162 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	stdnet "net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"git.konjactw.dev/falloutBot/go-mc/data/packetid"
+	"git.konjactw.dev/falloutBot/go-mc/net"
+	"git.konjactw.dev/falloutBot/go-mc/net/CFB8"
+	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
+	"git.konjactw.dev/falloutBot/go-mc/yggdrasil/user"
+)
+
+var testProfile = Resp{
+	Name:       "jeb_",
+	ID:         uuid.MustParse("853c80ef-3c37-49fd-aa49-938b674adae6"),
+	Properties: []user.Property{{Name: "textures", Value: "e30=", Signature: "c2ln"}},
+}
+
+func TestSessionServer_HasJoined(t *testing.T) {
+	local := NewLocalSessionServer()
+	local.AddProfile("token", testProfile)
+	ts := httptest.NewServer(local)
+	defer ts.Close()
+
+	body, _ := json.Marshal(map[string]string{
+		"accessToken":     "token",
+		"selectedProfile": "853c80ef3c3749fdaa49938b674adae6",
+		"serverId":        "-1a2b&c",
+	})
+	resp, err := http.Post(ts.URL+"/session/minecraft/join", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("join responds status %d", resp.StatusCode)
+	}
+
+	s := &SessionServer{URL: ts.URL}
+	if _, err := s.HasJoined(context.Background(), "jeb_", "-1a2b&c", "10.0.0.1"); !errors.Is(err, ErrNotJoined) {
+		t.Errorf("join from another ip should fail, got %v", err)
+	}
+	profile, err := s.HasJoined(context.Background(), "jeb_", "-1a2b&c", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile.Name != testProfile.Name || profile.ID != testProfile.ID || len(profile.Properties) != 1 ||
+		profile.Properties[0] != testProfile.Properties[0] {
+		t.Errorf("profile mismatch: %+v", profile)
+	}
+	if _, err := s.HasJoined(context.Background(), "jeb_", "-1a2b&c", ""); !errors.Is(err, ErrNotJoined) {
+		t.Errorf("each join should be verified only once, got %v", err)
+	}
+}
+
+func TestSessionServer_retry(t *testing.T) {
+	var requests atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	s := &SessionServer{URL: ts.URL, MaxRetries: 2}
+	if _, err := s.HasJoined(context.Background(), "jeb_", "0", ""); !errors.Is(err, ErrNotJoined) {
+		t.Errorf("want ErrNotJoined, got %v", err)
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("want 2 requests, got %d", n)
+	}
+}
+
+func TestEncrypt(t *testing.T) {
+	local := NewLocalSessionServer()
+	local.AddProfile("token", testProfile)
+	serverKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serverSide, clientSide := stdnet.Pipe()
+	defer serverSide.Close()
+	defer clientSide.Close()
+	client := net.WrapConn(clientSide)
+	clientErr := make(chan error, 1)
+	go func() { clientErr <- fakeClientJoin(client, local) }()
+
+	resp, err := Encrypt(context.Background(), net.WrapConn(serverSide), "jeb_", serverKey, Options{SessionService: local})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := <-clientErr; err != nil {
+		t.Fatal(err)
+	}
+	if resp.ID != testProfile.ID {
+		t.Errorf("uuid doesn't match: %v, want %v", resp.ID, testProfile.ID)
+	}
+}
+
+// fakeClientJoin does what a client does when it receives the encryption request.
+func fakeClientJoin(conn *net.Conn, local *LocalSessionServer) error {
+	var p pk.Packet
+	if err := conn.ReadPacket(&p); err != nil {
+		return err
+	}
+	var (
+		serverID                 pk.String
+		publicKey, verifyToken   pk.ByteArray
+		shouldAuthenticate       pk.Boolean
+		sharedSecret             = make([]byte, 16)
+		encryptedSecret, encrypt []byte
+	)
+	if err := p.Scan(&serverID, &publicKey, &verifyToken, &shouldAuthenticate); err != nil {
+		return err
+	}
+	key, err := x509.ParsePKIXPublicKey(publicKey)
+	if err != nil {
+		return err
+	}
+	_, _ = rand.Read(sharedSecret)
+	if encryptedSecret, err = rsa.EncryptPKCS1v15(rand.Reader, key.(*rsa.PublicKey), sharedSecret); err != nil {
+		return err
+	}
+	if encrypt, err = rsa.EncryptPKCS1v15(rand.Reader, key.(*rsa.PublicKey), verifyToken); err != nil {
+		return err
+	}
+	err = local.Join("token", testProfile.ID, authDigest(string(serverID), sharedSecret, publicKey), "")
+	if err != nil {
+		return err
+	}
+	err = conn.WritePacket(pk.Marshal(
+		packetid.ServerboundLoginKey,
+		pk.ByteArray(encryptedSecret),
+		pk.ByteArray(encrypt),
+	))
+	if err != nil {
+		return err
+	}
+	block, err := aes.NewCipher(sharedSecret)
+	if err != nil {
+		return err
+	}
+	conn.SetCipher(CFB8.NewCFB8Encrypt(block, sharedSecret), CFB8.NewCFB8Decrypt(block, sharedSecret))
+	return nil
+}
@@ -0,0 +1,161 @@
+package server
+
+import (
+	"encoding/json"
+	"net"
+	"time"
+
+	"github.com/google/uuid"
+
+	"git.konjactw.dev/falloutBot/go-mc/chat"
+)
+
+// banTimeLayout is the time format used by the vanilla ban lists.
+const banTimeLayout = "2006-01-02 15:04:05 -0700"
+
+// BanTime is a time encoded in the format of the vanilla ban lists.
+// The zero BanTime is encoded as "forever".
+type BanTime struct{ time.Time }
+
+// MarshalJSON overrides the method promoted from time.Time.
+func (b BanTime) MarshalJSON() ([]byte, error) {
+	if b.IsZero() {
+		return json.Marshal("forever")
+	}
+	return json.Marshal(b.Format(banTimeLayout))
+}
+
+// UnmarshalJSON overrides the method promoted from time.Time.
+func (b *BanTime) UnmarshalJSON(data []byte) (err error) {
+	var text string
+	if err = json.Unmarshal(data, &text); err != nil {
+		return
+	}
+	if text == "forever" {
+		b.Time = time.Time{}
+		return nil
+	}
+	b.Time, err = time.Parse(banTimeLayout, text)
+	return
+}
+
+// BanInfo is the common fields of the ban lists.
+type BanInfo struct {
+	Created BanTime `json:"created"`
+	Source  string  `json:"source"`
+	// Expires is zero if the ban never expires.
+	Expires BanTime `json:"expires"`
+	Reason  string  `json:"reason"`
+}
+
+// Expired reports whether the ban is expired.
+func (b *BanInfo) Expired() bool {
+	return !b.Expires.IsZero() && b.Expires.Before(time.Now())
+}
+
+// message renders the reason with the translation key, and the expiry time if it has one.
+func (b *BanInfo) message(key string) chat.Message {
+	msg := chat.TranslateMsg(key, chat.Text(b.Reason))
+	if !b.Expires.IsZero() {
+		msg.Extra = append(msg.Extra, chat.TranslateMsg(
+			"multiplayer.disconnect.banned.expiration",
+			chat.Text(b.Expires.Format(banTimeLayout)),
+		))
+	}
+	return msg
+}
+
+// PlayerBan is an entry of banned-players.json.
+type PlayerBan struct {
+	UUID uuid.UUID `json:"uuid"`
+	Name string    `json:"name"`
+	BanInfo
+}
+
+// BanList is a LoginChecker rejecting the players in banned-players.json.
+type BanList struct {
+	*jsonList[PlayerBan]
+}
+
+// NewBanList loads the banned players from the file, usually banned-players.json.
+// The file is created when the list is modified if it doesn't exist.
+func NewBanList(path string) (*BanList, error) {
+	l, err := newJSONList(path, func(e *PlayerBan) string { return e.UUID.String() })
+	return &BanList{l}, err
+}
+
+// CheckPlayer implements LoginChecker for BanList
+func (b *BanList) CheckPlayer(_ string, id uuid.UUID, _ int32) (ok bool, reason chat.Message) {
+	if e, ok := b.get(id.String()); ok && !e.Expired() {
+		return false, e.message("multiplayer.disconnect.banned.reason")
+	}
+	return true, chat.Message{}
+}
+
+// Ban adds the player to the list. The Created time is set to now if it's zero.
+func (b *BanList) Ban(e PlayerBan) error {
+	if e.Created.IsZero() {
+		e.Created = BanTime{time.Now()}
+	}
+	return b.put(e)
+}
+
+func (b *BanList) Pardon(id uuid.UUID) error {
+	return b.remove(id.String())
+}
+
+// IPBan is an entry of banned-ips.json.
+type IPBan struct {
+	IP string `json:"ip"`
+	BanInfo
+}
+
+// IPBanList is a LoginChecker rejecting the addresses in banned-ips.json.
+// It only implements LoginAddrChecker, all players pass CheckPlayer.
+type IPBanList struct {
+	*jsonList[IPBan]
+}
+
+// NewIPBanList loads the banned addresses from the file, usually banned-ips.json.
+// The file is created when the list is modified if it doesn't exist.
+func NewIPBanList(path string) (*IPBanList, error) {
+	l, err := newJSONList(path, func(e *IPBan) string { return e.IP })
+	return &IPBanList{l}, err
+}
+
+// CheckPlayer implements LoginChecker for IPBanList
+func (b *IPBanList) CheckPlayer(string, uuid.UUID, int32) (ok bool, reason chat.Message) {
+	return true, chat.Message{}
+}
+
+// CheckAddr implements LoginAddrChecker for IPBanList
+func (b *IPBanList) CheckAddr(addr net.Addr) (ok bool, reason chat.Message) {
+	if e, ok := b.get(addrIP(addr)); ok && !e.Expired() {
+		return false, e.message("multiplayer.disconnect.banned_ip.reason")
+	}
+	return true, chat.Message{}
+}
+
+// Ban adds the address to the list. The Created time is set to now if it's zero.
+func (b *IPBanList) Ban(e IPBan) error {
+	if e.Created.IsZero() {
+		e.Created = BanTime{time.Now()}
+	}
+	return b.put(e)
+}
+
+func (b *IPBanList) Pardon(ip string) error {
+	return b.remove(ip)
+}
+
+// addrIP returns the IP of the address without the port.
+func addrIP(addr net.Addr) string {
+	if tcp, ok := addr.(*net.TCPAddr); ok {
+		return tcp.IP.String()
+	}
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	return host
+}
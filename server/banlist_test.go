@@ -0,0 +1,154 @@
+package server
+
+import (
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// copyFixture copies the file in testdata to a temporary directory, so it can be modified.
+func copyFixture(t *testing.T, name string) string {
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestBanTime_MarshalJSON(t *testing.T) {
+	for _, text := range []string{
+		`"forever"`,
+		`"2024-03-01 12:30:00 +0000"`,
+		`"2024-03-02 08:00:00 +0800"`,
+		`"2020-01-02 00:00:00 -0500"`,
+	} {
+		var b BanTime
+		if err := json.Unmarshal([]byte(text), &b); err != nil {
+			t.Errorf("unmarshal %s: %v", text, err)
+			continue
+		}
+		if (text == `"forever"`) != b.IsZero() {
+			t.Errorf("unmarshal %s: get %v", text, b.Time)
+		}
+		data, err := json.Marshal(b)
+		if err != nil {
+			t.Errorf("marshal %s: %v", text, err)
+		} else if string(data) != text {
+			t.Errorf("want %s, get %s", text, data)
+		}
+	}
+
+	if data, _ := json.Marshal(BanTime{}); string(data) != `"forever"` {
+		t.Errorf("zero BanTime: %s", data)
+	}
+	var b BanTime
+	if err := json.Unmarshal([]byte(`"2024-03-01T12:30:00Z"`), &b); err == nil {
+		t.Error("RFC 3339 time should be rejected")
+	}
+}
+
+func TestBanList_expired(t *testing.T) {
+	bans, err := NewBanList(filepath.Join(t.TempDir(), "banned-players.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	forever, temporary, expired := uuid.New(), uuid.New(), uuid.New()
+	for _, e := range []PlayerBan{
+		{UUID: forever, Name: "forever"},
+		{UUID: temporary, Name: "temporary", BanInfo: BanInfo{Expires: BanTime{time.Now().Add(time.Hour)}}},
+		{UUID: expired, Name: "expired", BanInfo: BanInfo{Expires: BanTime{time.Now().Add(-time.Hour)}}},
+	} {
+		if err := bans.Ban(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if ok, reason := bans.CheckPlayer("forever", forever, 0); ok {
+		t.Error("player banned forever should be rejected")
+	} else if reason.Translate != "multiplayer.disconnect.banned.reason" || len(reason.Extra) != 0 {
+		t.Errorf("reason of ban without expiry: %#v", reason)
+	}
+	if ok, reason := bans.CheckPlayer("temporary", temporary, 0); ok {
+		t.Error("player banned for an hour should be rejected")
+	} else if len(reason.Extra) != 1 || reason.Extra[0].Translate != "multiplayer.disconnect.banned.expiration" {
+		t.Errorf("reason of ban with expiry: %#v", reason)
+	}
+	if ok, _ := bans.CheckPlayer("expired", expired, 0); !ok {
+		t.Error("player with expired ban should be allowed")
+	}
+	if ok, _ := bans.CheckPlayer("other", uuid.New(), 0); !ok {
+		t.Error("player not banned should be allowed")
+	}
+
+	for _, e := range bans.Entries() {
+		if e.Created.IsZero() {
+			t.Errorf("created time of %s isn't set", e.Name)
+		}
+	}
+}
+
+func TestBanList_vanilla(t *testing.T) {
+	bans, err := NewBanList(copyFixture(t, "banned-players.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := bans.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("want 2 entries, get %d", len(entries))
+	}
+	notch := entries[0]
+	if notch.UUID != uuid.MustParse("069a79f4-44e9-4726-a5be-fca90e38aaf5") || notch.Name != "Notch" ||
+		notch.Source != "Server" || notch.Reason != "Banned by an operator." || !notch.Expires.IsZero() {
+		t.Errorf("Notch: %+v", notch)
+	}
+	if want := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC); !notch.Created.Equal(want) {
+		t.Errorf("created: want %v, get %v", want, notch.Created)
+	}
+	if want := time.Date(2098, 12, 31, 16, 0, 0, 0, time.UTC); !entries[1].Expires.Equal(want) {
+		t.Errorf("expires: want %v, get %v", want, entries[1].Expires)
+	}
+	if ok, _ := bans.CheckPlayer("jeb_", entries[1].UUID, 0); ok {
+		t.Error("jeb_ should be rejected")
+	}
+}
+
+func TestIPBanList_vanilla(t *testing.T) {
+	path := copyFixture(t, "banned-ips.json")
+	bans, err := NewIPBanList(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, reason := bans.CheckAddr(&net.TCPAddr{IP: net.IPv4(192, 168, 1, 10), Port: 54321}); ok {
+		t.Error("banned address should be rejected")
+	} else if reason.Translate != "multiplayer.disconnect.banned_ip.reason" {
+		t.Errorf("reason: %#v", reason)
+	}
+	if ok, _ := bans.CheckAddr(&net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 54321}); !ok {
+		t.Error("address with expired ban should be allowed")
+	}
+
+	// saving the unchanged entries keeps the file as it was written by the vanilla server
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := bans.Ban(bans.Entries()[0]); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("want:\n%s\nget:\n%s", want, got)
+	}
+}
@@ -0,0 +1,304 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"image/png"
+	"io/fs"
+	"log/slog"
+	stdnet "net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+
+	"git.konjactw.dev/falloutBot/go-mc/chat"
+	"git.konjactw.dev/falloutBot/go-mc/net"
+	"git.konjactw.dev/falloutBot/go-mc/server/command"
+)
+
+// Bootstrap builds a Server from the files in the server directory, like the vanilla server does:
+//
+//   - server.properties
+//   - server-icon.png
+//   - whitelist.json, ops.json, banned-players.json and banned-ips.json
+//
+// The Server it built responds to list pings if "enable-status" is on, and handles the login,
+// the ConfigHandler and GamePlay should be set by the caller before listening.
+// The Chat and the RCON server can be created by NewChat and ServeRCON.
+//
+// Some properties can be changed without a restart by editing server.properties,
+// they are "motd", "max-players", "hide-online-players", "white-list" and "enforce-whitelist".
+// Others are only read once when the Bootstrap is created.
+type Bootstrap struct {
+	Server     *Server
+	PlayerList *PlayerList
+	Whitelist  *Whitelist
+	Ops        *OpList
+	Bans       *BanList
+	IPBans     *IPBanList
+
+	path    string
+	props   atomic.Pointer[Properties]
+	favIcon string
+	// modTime is the modification time of server.properties when it's loaded.
+	modTime    time.Time
+	reloadLock sync.Mutex
+}
+
+// NewBootstrap loads the files in dir and builds the Server.
+// The server.properties is created with the default values if it doesn't exist.
+func NewBootstrap(dir string) (b *Bootstrap, err error) {
+	b = &Bootstrap{path: filepath.Join(dir, "server.properties")}
+	props, err := LoadProperties(b.path)
+	if err != nil {
+		return nil, err
+	}
+	if info, err := os.Stat(b.path); err == nil {
+		b.modTime = info.ModTime()
+	}
+	b.props.Store(&props)
+
+	if b.favIcon, err = loadFavIcon(filepath.Join(dir, "server-icon.png")); err != nil {
+		return nil, err
+	}
+	if b.Whitelist, err = NewWhitelist(filepath.Join(dir, "whitelist.json")); err != nil {
+		return nil, err
+	}
+	if b.Ops, err = NewOpList(filepath.Join(dir, "ops.json")); err != nil {
+		return nil, err
+	}
+	if b.Bans, err = NewBanList(filepath.Join(dir, "banned-players.json")); err != nil {
+		return nil, err
+	}
+	if b.IPBans, err = NewIPBanList(filepath.Join(dir, "banned-ips.json")); err != nil {
+		return nil, err
+	}
+	b.PlayerList = NewPlayerList(props.MaxPlayers)
+
+	b.Server = &Server{
+		Logger:           slog.Default(),
+		AcceptsTransfers: props.AcceptsTransfers,
+		LoginHandler: &MojangLoginHandler{
+			OnlineMode:              props.OnlineMode,
+			EnforceSecureProfile:    props.EnforceSecureProfile,
+			PreventProxyConnections: props.PreventProxyConnections,
+			Threshold:               props.NetworkCompressionThreshold,
+			LoginChecker:            b,
+			Logger:                  slog.Default(),
+		},
+	}
+	if props.EnableStatus {
+		b.Server.ListPingHandler = b
+	}
+	return b, nil
+}
+
+// loadFavIcon reads the icon file, returns empty string if it doesn't exist.
+func loadFavIcon(path string) (string, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	img, err := png.Decode(f)
+	if err != nil {
+		return "", err
+	}
+	return EncodeFavIcon(img)
+}
+
+// Properties returns the current properties.
+func (b *Bootstrap) Properties() Properties {
+	return *b.props.Load()
+}
+
+// Addr returns the address to listen on, which is "server-ip:server-port".
+func (b *Bootstrap) Addr() string {
+	props := b.props.Load()
+	return stdnet.JoinHostPort(props.ServerIP, strconv.Itoa(props.ServerPort))
+}
+
+// Listen starts the Server on Addr.
+func (b *Bootstrap) Listen() error {
+	return b.Server.Listen(b.Addr())
+}
+
+// NewChat creates the Chat of the server, which enforces the secure chat if "enforce-secure-chat" is on.
+// The public keys of the players are only checked for the expiry time in offline mode.
+func (b *Bootstrap) NewChat(policy ChatPolicy) *Chat {
+	props := b.props.Load()
+	c := NewChat(policy)
+	c.EnforceSecureChat = props.EnforceSecureChat
+	if !props.OnlineMode {
+		c.KeyValidator = nil
+	}
+	return c
+}
+
+// ServeRCON listens on "server-ip:rcon.port" and executes the commands of the RCON clients on g,
+// if "enable-rcon" is on. Otherwise, it returns nil immediately.
+// It returns the ctx's error when the ctx is done, see command.Graph.ServeRCON.
+func (b *Bootstrap) ServeRCON(ctx context.Context, g *command.Graph) error {
+	props := b.props.Load()
+	if !props.EnableRCON {
+		return nil
+	}
+	if props.RCONPassword == "" {
+		return errors.New("no rcon.password set in server.properties, rcon disabled")
+	}
+	l, err := net.ListenRCON(stdnet.JoinHostPort(props.ServerIP, strconv.Itoa(props.RCONPort)))
+	if err != nil {
+		return err
+	}
+	loggerOr(b.Server.Logger).Info("rcon listening", "addr", l.Addr())
+	return g.ServeRCON(ctx, l, props.RCONPassword)
+}
+
+// Reload reads server.properties again if it's modified, and applies the runtime changeable properties.
+// The json lists are also reloaded.
+func (b *Bootstrap) Reload() error {
+	err := errors.Join(
+		b.reloadProperties(),
+		b.Whitelist.Reload(),
+		b.Ops.Reload(),
+		b.Bans.Reload(),
+		b.IPBans.Reload(),
+	)
+	b.enforceWhitelist()
+	return err
+}
+
+func (b *Bootstrap) reloadProperties() error {
+	b.reloadLock.Lock()
+	defer b.reloadLock.Unlock()
+	info, err := os.Stat(b.path)
+	if err != nil {
+		return err
+	}
+	if info.ModTime().Equal(b.modTime) {
+		return nil
+	}
+	f, err := os.Open(b.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	props, err := ReadProperties(f)
+	if err != nil {
+		return err
+	}
+
+	// Copy the properties which can't be changed at runtime from the current one,
+	// so Properties always reports the values in use.
+	old := b.props.Load()
+	props.ServerIP, props.ServerPort = old.ServerIP, old.ServerPort
+	props.OnlineMode, props.EnforceSecureProfile = old.OnlineMode, old.EnforceSecureProfile
+	props.NetworkCompressionThreshold = old.NetworkCompressionThreshold
+	props.EnableStatus, props.AcceptsTransfers = old.EnableStatus, old.AcceptsTransfers
+	props.EnableRCON, props.RCONPort, props.RCONPassword = old.EnableRCON, old.RCONPort, old.RCONPassword
+	props.EnforceSecureChat = old.EnforceSecureChat
+
+	b.modTime = info.ModTime()
+	b.props.Store(&props)
+	b.PlayerList.SetMaxPlayer(props.MaxPlayers)
+	return nil
+}
+
+// enforceWhitelist kicks the online players not in the whitelist if "enforce-whitelist" is on.
+func (b *Bootstrap) enforceWhitelist() {
+	props := b.props.Load()
+	if !props.WhiteList || !props.EnforceWhitelist {
+		return
+	}
+	var kicked []PlayerListClient
+	b.PlayerList.Range(func(c PlayerListClient, p PlayerSample) {
+		if ok, _ := b.checkWhitelist(p.ID); !ok {
+			kicked = append(kicked, c)
+		}
+	})
+	// Disconnect outside Range, the client may leave the PlayerList synchronously.
+	for _, c := range kicked {
+		c.SendDisconnect(chat.TranslateMsg("multiplayer.disconnect.not_whitelisted"))
+	}
+}
+
+// Run implement Component for Bootstrap, which reloads the modified files periodically.
+// Errors are logged by the Server's Logger, and the previous values are kept.
+func (b *Bootstrap) Run(ctx context.Context) {
+	ticker := time.NewTicker(jsonListReloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := b.Reload(); err != nil {
+				loggerOr(b.Server.Logger).Warn("reload server config error", "error", err)
+			}
+		}
+	}
+}
+
+// CheckAddr implements LoginAddrChecker for Bootstrap
+func (b *Bootstrap) CheckAddr(addr stdnet.Addr) (ok bool, reason chat.Message) {
+	return b.IPBans.CheckAddr(addr)
+}
+
+// CheckPlayer implements LoginChecker for Bootstrap.
+// The players are checked by the ban list, the whitelist and the player limit in order.
+func (b *Bootstrap) CheckPlayer(name string, id uuid.UUID, protocol int32) (ok bool, reason chat.Message) {
+	if ok, reason = b.Bans.CheckPlayer(name, id, protocol); !ok {
+		return
+	}
+	if ok, reason = b.checkWhitelist(id); !ok {
+		return
+	}
+	return b.Ops.BypassPlayerLimit(b.PlayerList).CheckPlayer(name, id, protocol)
+}
+
+func (b *Bootstrap) checkWhitelist(id uuid.UUID) (ok bool, reason chat.Message) {
+	if !b.props.Load().WhiteList {
+		return true, chat.Message{}
+	}
+	return b.Ops.Bypass(b.Whitelist).CheckPlayer("", id, 0)
+}
+
+func (b *Bootstrap) Name() string {
+	return ProtocolName
+}
+
+func (b *Bootstrap) Protocol(int32) int {
+	return ProtocolVersion
+}
+
+func (b *Bootstrap) MaxPlayer() int {
+	return b.PlayerList.MaxPlayer()
+}
+
+func (b *Bootstrap) OnlinePlayer() int {
+	return b.PlayerList.OnlinePlayer()
+}
+
+// PlayerSamples returns nothing if "hide-online-players" is on.
+func (b *Bootstrap) PlayerSamples() []PlayerSample {
+	if b.props.Load().HideOnlinePlayers {
+		return nil
+	}
+	return b.PlayerList.PlayerSamples()
+}
+
+func (b *Bootstrap) Description() *chat.Message {
+	motd := chat.Text(b.props.Load().MOTD)
+	return &motd
+}
+
+func (b *Bootstrap) FavIcon() string {
+	return b.favIcon
+}
@@ -0,0 +1,43 @@
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"git.konjactw.dev/falloutBot/go-mc/server/command"
+)
+
+func TestNewBootstrap(t *testing.T) {
+	dir := t.TempDir()
+	props := "enable-status=false\naccepts-transfers=true\nenforce-secure-chat=false\nonline-mode=false\n"
+	if err := os.WriteFile(filepath.Join(dir, "server.properties"), []byte(props), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	b, err := NewBootstrap(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Server.ListPingHandler != nil {
+		t.Error("status should be disabled")
+	}
+	if !b.Server.AcceptsTransfers {
+		t.Error("transfers should be accepted")
+	}
+	if c := b.NewChat(ChatPolicy{}); c.EnforceSecureChat || c.KeyValidator != nil {
+		t.Error("secure chat shouldn't be enforced in offline mode")
+	}
+	// rcon is disabled by default
+	if err := b.ServeRCON(context.Background(), command.NewGraph()); err != nil {
+		t.Error(err)
+	}
+
+	b, err = NewBootstrap(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Server.ListPingHandler == nil || b.Server.AcceptsTransfers || !b.NewChat(ChatPolicy{}).EnforceSecureChat {
+		t.Error("unexpected defaults")
+	}
+}
@@ -0,0 +1,469 @@
+package server
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"git.konjactw.dev/falloutBot/go-mc/chat"
+	"git.konjactw.dev/falloutBot/go-mc/chat/sign"
+	"git.konjactw.dev/falloutBot/go-mc/data/packetid"
+	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
+	"git.konjactw.dev/falloutBot/go-mc/yggdrasil/user"
+)
+
+// lastSeenCount is the size of the window of acknowledged messages.
+const lastSeenCount = 20
+
+// maxTrackedMessages is how many messages can be sent to a client without being acknowledged.
+const maxTrackedMessages = 4096
+
+// ChatMode decides how the chat messages are broadcast.
+type ChatMode byte
+
+const (
+	// SignedChat forwards the signatures, so the clients are able to verify the messages.
+	SignedChat ChatMode = iota
+	// DisguisedChat sends the messages as DisguisedChat packets without signatures.
+	// The messages are still verified if they are signed.
+	DisguisedChat
+	// SystemChat sends the messages as SystemChat packets, which is rendered by the server.
+	SystemChat
+)
+
+// ChatPolicy is the options of Chat.
+type ChatPolicy struct {
+	// EnforceSecureChat disconnects the players who send unsigned messages.
+	EnforceSecureChat bool
+	// UnsignedFallback allows players without a chat session to send unsigned messages,
+	// if EnforceSecureChat is false. Otherwise, their messages are rejected.
+	UnsignedFallback bool
+	Mode             ChatMode
+}
+
+// ChatClient is the connection sending and receiving the chat messages.
+type ChatClient interface {
+	SendPacket(p pk.Packet)
+	SendDisconnect(reason chat.Message)
+}
+
+// Chat is the secure chat pipeline.
+// It receives the chat sessions and messages from clients, verifies them, and broadcasts them.
+type Chat struct {
+	ChatPolicy
+	// ChatType is the index of the chat type in the registry, minecraft:chat by default.
+	ChatType int32
+	// KeyValidator verifies the public key of the player's chat session.
+	// If it's nil, only the expiry time is checked, which is suitable for offline mode.
+	KeyValidator func(id uuid.UUID, key *user.PublicKey) bool
+
+	updateSession []func(c ChatClient, session *sign.Session)
+
+	players map[ChatClient]*chatPlayer
+	// Only the field players and what they point to are protected by this Mutex.
+	playersLock sync.Mutex
+}
+
+type chatPlayer struct {
+	id   uuid.UUID
+	name chat.Message
+	// session is nil before the client sends a valid chat session.
+	session *sign.Session
+	// nextIndex is the index of the next message in the signature chain.
+	nextIndex int
+	lastSeen  lastSeenValidator
+	// globalIndex counts the PlayerChat packets sent to the player.
+	globalIndex int32
+}
+
+// NewChat creates a Chat validating the public keys by Mojang's signature.
+func NewChat(policy ChatPolicy) *Chat {
+	return &Chat{
+		ChatPolicy:   policy,
+		ChatType:     0,
+		KeyValidator: func(id uuid.UUID, key *user.PublicKey) bool { return key.VerifyProfile(id) },
+		players:      make(map[ChatClient]*chatPlayer),
+	}
+}
+
+// AddSessionUpdateHandler adds a function called after a client's chat session is validated,
+// which is usually used to send the session to other players by the tab list.
+func (c *Chat) AddSessionUpdateHandler(f func(client ChatClient, session *sign.Session)) {
+	c.updateSession = append(c.updateSession, f)
+}
+
+func (c *Chat) ClientJoin(client ChatClient, id uuid.UUID, name string) {
+	c.playersLock.Lock()
+	defer c.playersLock.Unlock()
+	c.players[client] = &chatPlayer{
+		id:       id,
+		name:     chat.Text(name),
+		lastSeen: newLastSeenValidator(),
+	}
+}
+
+func (c *Chat) ClientLeft(client ChatClient) {
+	c.playersLock.Lock()
+	defer c.playersLock.Unlock()
+	delete(c.players, client)
+}
+
+// HandleChatSessionUpdate handles the ServerboundChatSessionUpdate packet.
+func (c *Chat) HandleChatSessionUpdate(client ChatClient, p pk.Packet) error {
+	session := new(sign.Session)
+	if err := p.Scan(session); err != nil {
+		return err
+	}
+	c.playersLock.Lock()
+	player, ok := c.players[client]
+	if !ok {
+		c.playersLock.Unlock()
+		return errors.New("chat: client not found")
+	}
+	if session.PublicKey.Expired(time.Now()) {
+		c.playersLock.Unlock()
+		client.SendDisconnect(chat.TranslateMsg("multiplayer.disconnect.expired_public_key"))
+		return nil
+	}
+	if c.KeyValidator != nil && !c.KeyValidator(player.id, &session.PublicKey) {
+		c.playersLock.Unlock()
+		client.SendDisconnect(chat.TranslateMsg("multiplayer.disconnect.invalid_public_key_signature"))
+		return nil
+	}
+	session.InitValidate()
+	player.session = session
+	player.nextIndex = 0
+	c.playersLock.Unlock()
+
+	for _, f := range c.updateSession {
+		f(client, session)
+	}
+	return nil
+}
+
+// HandleChatAck handles the ServerboundChatAck packet.
+func (c *Chat) HandleChatAck(client ChatClient, p pk.Packet) error {
+	var offset pk.VarInt
+	if err := p.Scan(&offset); err != nil {
+		return err
+	}
+	c.playersLock.Lock()
+	defer c.playersLock.Unlock()
+	player, ok := c.players[client]
+	if !ok {
+		return errors.New("chat: client not found")
+	}
+	if err := player.lastSeen.applyOffset(int(offset)); err != nil {
+		client.SendDisconnect(chat.TranslateMsg("multiplayer.disconnect.chat_validation_failed"))
+	}
+	return nil
+}
+
+// HandleChat handles the ServerboundChat packet, and broadcasts the message if it's valid.
+func (c *Chat) HandleChat(client ChatClient, p pk.Packet) error {
+	var (
+		message   pk.String
+		timestamp pk.Long
+		salt      pk.Long
+		signature pk.Option[sign.Signature, *sign.Signature]
+		update    lastSeenUpdate
+	)
+	if err := p.Scan(&message, &timestamp, &salt, &signature, &update); err != nil {
+		return err
+	}
+
+	c.playersLock.Lock()
+	defer c.playersLock.Unlock()
+	player, ok := c.players[client]
+	if !ok {
+		return errors.New("chat: client not found")
+	}
+	lastSeen, err := player.lastSeen.applyUpdate(update)
+	if err != nil {
+		client.SendDisconnect(chat.TranslateMsg("multiplayer.disconnect.chat_validation_failed"))
+		return nil
+	}
+
+	msg := &sign.Message{
+		Signature: signature.Pointer(),
+		MessageBody: &sign.MessageBody{
+			PlainMsg:  string(message),
+			Timestamp: time.UnixMilli(int64(timestamp)),
+			Salt:      int64(salt),
+			LastSeen:  lastSeen,
+		},
+	}
+	switch {
+	case player.session != nil && player.session.PublicKey.Expired(time.Now()):
+		// The key may expire during the session, the client must update it before chatting.
+		client.SendDisconnect(chat.TranslateMsg("multiplayer.disconnect.expired_public_key"))
+		return nil
+	case player.session != nil && msg.Signature != nil:
+		msg.Prev = sign.Prev{
+			Index:   player.nextIndex,
+			Sender:  player.id,
+			Session: player.session.SessionID,
+		}
+		if !player.session.VerifyAndUpdate(msg) {
+			client.SendDisconnect(chat.TranslateMsg("multiplayer.disconnect.chat_validation_failed"))
+			return nil
+		}
+		player.nextIndex++
+	case c.EnforceSecureChat:
+		client.SendDisconnect(chat.TranslateMsg("multiplayer.disconnect.unsigned_chat"))
+		return nil
+	case !c.UnsignedFallback:
+		client.SendPacket(pk.Marshal(
+			packetid.ClientboundSystemChat,
+			chat.TranslateMsg("chat.disabled.missingProfileKey"),
+			pk.Boolean(false),
+		))
+		return nil
+	default:
+		// Unsigned messages are sent without a signature, and the chain is not involved.
+		msg.Signature = nil
+		msg.Prev = sign.Prev{Sender: player.id}
+	}
+	c.broadcast(player, msg)
+	return nil
+}
+
+// HandleChatCommandSigned handles the ServerboundChatCommandSigned packet, and verifies the signatures of the arguments.
+// The signable reports the signable arguments of the command, which is usually command.Graph.SignableArguments.
+//
+// It returns the command to be executed by the caller, and the signed messages of its arguments,
+// which can be broadcast by the command like "/say".
+// The cmd is empty if the client is disconnected because of the invalid signatures.
+func (c *Chat) HandleChatCommandSigned(client ChatClient, p pk.Packet, signable func(cmd string) ([]sign.Argument, error)) (cmd string, signed []*sign.Message, err error) {
+	var (
+		command    pk.String
+		timestamp  pk.Long
+		salt       pk.Long
+		signatures sign.ArgumentSignatures
+		update     lastSeenUpdate
+	)
+	if err := p.Scan(&command, &timestamp, &salt, &signatures, &update); err != nil {
+		return "", nil, err
+	}
+	// The signable may look into the game, so it's called without holding the lock.
+	args, argsErr := signable(string(command))
+
+	c.playersLock.Lock()
+	defer c.playersLock.Unlock()
+	player, ok := c.players[client]
+	if !ok {
+		return "", nil, errors.New("chat: client not found")
+	}
+	lastSeen, err := player.lastSeen.applyUpdate(update)
+	if err != nil {
+		client.SendDisconnect(chat.TranslateMsg("multiplayer.disconnect.chat_validation_failed"))
+		return "", nil, nil
+	}
+	if argsErr != nil {
+		// The command is invalid, and its error is reported when it's executed.
+		return string(command), nil, nil
+	}
+
+	body := &sign.MessageBody{
+		Timestamp: time.UnixMilli(int64(timestamp)),
+		Salt:      int64(salt),
+		LastSeen:  lastSeen,
+	}
+	switch {
+	case player.session != nil && player.session.PublicKey.Expired(time.Now()):
+		client.SendDisconnect(chat.TranslateMsg("multiplayer.disconnect.expired_public_key"))
+		return "", nil, nil
+	case player.session != nil && (len(args) > 0 || len(signatures) > 0):
+		prev := sign.Prev{
+			Index:   player.nextIndex,
+			Sender:  player.id,
+			Session: player.session.SessionID,
+		}
+		if signed, ok = player.session.VerifyArguments(prev, body, args, signatures); !ok {
+			client.SendDisconnect(chat.TranslateMsg("multiplayer.disconnect.chat_validation_failed"))
+			return "", nil, nil
+		}
+		player.nextIndex += len(args)
+	case len(args) > 0 && c.EnforceSecureChat:
+		client.SendDisconnect(chat.TranslateMsg("multiplayer.disconnect.unsigned_chat"))
+		return "", nil, nil
+	default:
+		// The arguments are unsigned, and the chain is not involved.
+		for _, arg := range args {
+			b := *body
+			b.PlainMsg = arg.Value
+			signed = append(signed, &sign.Message{Prev: sign.Prev{Sender: player.id}, MessageBody: &b})
+		}
+	}
+	return string(command), signed, nil
+}
+
+// broadcast sends the message to all players. The lock must be held.
+func (c *Chat) broadcast(sender *chatPlayer, msg *sign.Message) {
+	for client, player := range c.players {
+		switch c.Mode {
+		case SignedChat:
+			client.SendPacket(c.playerChatPacket(player, sender, msg))
+			if msg.Signature == nil {
+				break
+			}
+			if err := player.lastSeen.addPending(msg.Signature); err != nil {
+				client.SendDisconnect(chat.TranslateMsg("multiplayer.disconnect.too_many_pending_chats"))
+			}
+		case DisguisedChat:
+			client.SendPacket(pk.Marshal(
+				packetid.ClientboundDisguisedChat,
+				chat.Text(msg.PlainMsg),
+				pk.VarInt(c.ChatType+1),
+				sender.name,
+				pk.Option[chat.Message, *chat.Message]{},
+			))
+		case SystemChat:
+			client.SendPacket(pk.Marshal(
+				packetid.ClientboundSystemChat,
+				chat.TranslateMsg("chat.type.text", sender.name, chat.Text(msg.PlainMsg)),
+				pk.Boolean(false),
+			))
+		}
+	}
+}
+
+func (c *Chat) playerChatPacket(receiver, sender *chatPlayer, msg *sign.Message) pk.Packet {
+	// The signatures are never packed by the ID in the receiver's cache,
+	// which is always correct, at the cost of a little bandwidth.
+	lastSeen := make([]sign.PackedSignature, len(msg.LastSeen))
+	for i, s := range msg.LastSeen {
+		lastSeen[i] = sign.PackedSignature{ID: -1, Signature: s}
+	}
+	signature := pk.OptionEncoder[sign.Signature]{Has: msg.Signature != nil}
+	if msg.Signature != nil {
+		signature.Val = *msg.Signature
+	}
+	globalIndex := receiver.globalIndex
+	receiver.globalIndex++
+	return pk.Marshal(
+		packetid.ClientboundPlayerChat,
+		pk.VarInt(globalIndex),
+		pk.UUID(sender.id),
+		pk.VarInt(msg.Prev.Index),
+		signature,
+		pk.String(msg.PlainMsg),
+		pk.Long(msg.Timestamp.UnixMilli()),
+		pk.Long(msg.Salt),
+		pk.Array(lastSeen),
+		pk.Option[chat.Message, *chat.Message]{}, // unsigned content
+		&sign.FilterMask{Type: 0},                // pass through
+		pk.VarInt(c.ChatType+1),
+		sender.name,
+		pk.Option[chat.Message, *chat.Message]{}, // target name
+	)
+}
+
+// Broadcast sends a system message to all players.
+func (c *Chat) Broadcast(msg chat.Message) {
+	c.playersLock.Lock()
+	defer c.playersLock.Unlock()
+	p := pk.Marshal(packetid.ClientboundSystemChat, msg, pk.Boolean(false))
+	for client := range c.players {
+		client.SendPacket(p)
+	}
+}
+
+// lastSeenUpdate is the acknowledgement of the messages sent in chat packets.
+type lastSeenUpdate struct {
+	Offset       pk.VarInt
+	Acknowledged pk.FixedBitSet
+	// Checksum is 0 if the client doesn't want it to be checked.
+	Checksum pk.Byte
+}
+
+func (l *lastSeenUpdate) ReadFrom(r io.Reader) (int64, error) {
+	l.Acknowledged = pk.NewFixedBitSet(lastSeenCount)
+	return pk.Tuple{&l.Offset, l.Acknowledged, &l.Checksum}.ReadFrom(r)
+}
+
+// lastSeenValidator tracks the messages sent to a client, and validates its acknowledgements.
+// It has the same functional as net.minecraft.network.chat.LastSeenMessagesValidator.
+type lastSeenValidator struct {
+	// tracked is the messages sent to the client. The first lastSeenCount entries are the window.
+	// Ignored messages are nil.
+	tracked     []*lastSeenEntry
+	lastPending *sign.Signature
+}
+
+type lastSeenEntry struct {
+	signature *sign.Signature
+	pending   bool
+}
+
+func newLastSeenValidator() lastSeenValidator {
+	return lastSeenValidator{tracked: make([]*lastSeenEntry, lastSeenCount)}
+}
+
+// addPending tracks the message sent to the client.
+// It fails if the client doesn't acknowledge the messages, and has too many tracked messages.
+func (l *lastSeenValidator) addPending(s *sign.Signature) error {
+	if l.lastPending != nil && *s == *l.lastPending {
+		return nil
+	}
+	if len(l.tracked) >= maxTrackedMessages {
+		return errors.New("chat: too many unacknowledged messages")
+	}
+	l.tracked = append(l.tracked, &lastSeenEntry{signature: s, pending: true})
+	l.lastPending = s
+	return nil
+}
+
+func (l *lastSeenValidator) applyOffset(offset int) error {
+	if offset < 0 || offset > len(l.tracked)-lastSeenCount {
+		return errors.New("chat: advanced last seen window by offset beyond tracked messages")
+	}
+	l.tracked = l.tracked[offset:]
+	return nil
+}
+
+func (l *lastSeenValidator) applyUpdate(u lastSeenUpdate) (lastSeen []*sign.Signature, err error) {
+	if err = l.applyOffset(int(u.Offset)); err != nil {
+		return
+	}
+	for i := 0; i < lastSeenCount; i++ {
+		entry := l.tracked[i]
+		if u.Acknowledged.Get(i) {
+			if entry == nil {
+				return nil, errors.New("chat: last seen update acknowledged unknown or previously ignored message")
+			}
+			entry.pending = false
+			lastSeen = append(lastSeen, entry.signature)
+		} else {
+			if entry != nil && !entry.pending {
+				return nil, errors.New("chat: last seen update ignored previously acknowledged message")
+			}
+			l.tracked[i] = nil
+		}
+	}
+	if u.Checksum != 0 && u.Checksum != lastSeenChecksum(lastSeen) {
+		return nil, errors.New("chat: checksum mismatch on last seen update")
+	}
+	return
+}
+
+// lastSeenChecksum has the same functional as
+// net.minecraft.network.chat.LastSeenMessages#computeChecksum.
+func lastSeenChecksum(lastSeen []*sign.Signature) pk.Byte {
+	checksum := int32(1)
+	for _, s := range lastSeen {
+		// java.util.Arrays#hashCode(byte[])
+		hash := int32(1)
+		for _, b := range s {
+			hash = 31*hash + int32(int8(b))
+		}
+		checksum = 31*checksum + hash
+	}
+	if b := pk.Byte(checksum); b != 0 {
+		return b
+	}
+	return 1
+}
@@ -0,0 +1,153 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+
+	"git.konjactw.dev/falloutBot/go-mc/chat"
+	"git.konjactw.dev/falloutBot/go-mc/chat/sign"
+	"git.konjactw.dev/falloutBot/go-mc/data/packetid"
+	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
+)
+
+func testSignatures(n int) []*sign.Signature {
+	sigs := make([]*sign.Signature, n)
+	for i := range sigs {
+		sigs[i] = new(sign.Signature)
+		sigs[i][0] = byte(i + 1)
+	}
+	return sigs
+}
+
+// ack returns the update acknowledging the entries of the window, whose checksum is 0 if not checked.
+func ack(offset int, checksum pk.Byte, acked ...int) lastSeenUpdate {
+	u := lastSeenUpdate{Offset: pk.VarInt(offset), Acknowledged: pk.NewFixedBitSet(lastSeenCount), Checksum: checksum}
+	for _, i := range acked {
+		u.Acknowledged.Set(i, true)
+	}
+	return u
+}
+
+func TestLastSeenValidator(t *testing.T) {
+	sigs := testSignatures(3)
+	window := []int{lastSeenCount - 3, lastSeenCount - 2, lastSeenCount - 1}
+	for _, tc := range []struct {
+		name    string
+		updates []lastSeenUpdate
+		wantErr bool
+	}{
+		{name: "acknowledge", updates: []lastSeenUpdate{ack(3, 0, window...)}},
+		{name: "acknowledge later", updates: []lastSeenUpdate{ack(0, 0), ack(3, 0, window...)}},
+		{name: "ignore pending", updates: []lastSeenUpdate{ack(3, 0, window[:2]...)}},
+		{name: "checksum", updates: []lastSeenUpdate{ack(3, lastSeenChecksum(sigs), window...)}},
+		{name: "checksum mismatch", updates: []lastSeenUpdate{ack(3, lastSeenChecksum(sigs)+1, window...)}, wantErr: true},
+		{name: "offset beyond tracked", updates: []lastSeenUpdate{ack(4, 0)}, wantErr: true},
+		{name: "negative offset", updates: []lastSeenUpdate{ack(-1, 0)}, wantErr: true},
+		{name: "offset twice", updates: []lastSeenUpdate{ack(3, 0, window...), ack(1, 0)}, wantErr: true},
+		{name: "acknowledge unknown", updates: []lastSeenUpdate{ack(3, 0, 0)}, wantErr: true},
+		{name: "acknowledge ignored", updates: []lastSeenUpdate{ack(3, 0, window[:2]...), ack(0, 0, window...)}, wantErr: true},
+		{name: "ignore acknowledged", updates: []lastSeenUpdate{ack(3, 0, window...), ack(0, 0, window[:2]...)}, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			l := newLastSeenValidator()
+			for _, s := range sigs {
+				if err := l.addPending(s); err != nil {
+					t.Fatal(err)
+				}
+			}
+			var err error
+			for i, u := range tc.updates {
+				if _, err = l.applyUpdate(u); err != nil && i < len(tc.updates)-1 {
+					t.Fatalf("update %d: %v", i, err)
+				}
+			}
+			if (err != nil) != tc.wantErr {
+				t.Errorf("got error %v, want error %t", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestLastSeenValidator_lastSeen(t *testing.T) {
+	sigs := testSignatures(3)
+	l := newLastSeenValidator()
+	for _, s := range sigs {
+		_ = l.addPending(s)
+		// the same message isn't tracked twice
+		_ = l.addPending(s)
+	}
+	lastSeen, err := l.applyUpdate(ack(3, 0, lastSeenCount-3, lastSeenCount-1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lastSeen) != 2 || lastSeen[0] != sigs[0] || lastSeen[1] != sigs[2] {
+		t.Errorf("got %v", lastSeen)
+	}
+}
+
+func TestLastSeenValidator_tooManyTracked(t *testing.T) {
+	l := newLastSeenValidator()
+	sigs := testSignatures(maxTrackedMessages)
+	var err error
+	for _, s := range sigs {
+		if err = l.addPending(s); err != nil {
+			break
+		}
+	}
+	if err == nil || len(l.tracked) > maxTrackedMessages {
+		t.Errorf("tracked %d messages without acknowledgement: %v", len(l.tracked), err)
+	}
+}
+
+func TestLastSeenChecksum(t *testing.T) {
+	if got := lastSeenChecksum(nil); got != 1 {
+		t.Errorf("checksum of nothing: %d", got)
+	}
+	sigs := testSignatures(2)
+	if lastSeenChecksum(sigs) == lastSeenChecksum(sigs[:1]) {
+		t.Error("checksums of different messages should differ")
+	}
+	if lastSeenChecksum(sigs) == 0 {
+		t.Error("checksum can't be 0")
+	}
+}
+
+type chatClient struct {
+	packets      []pk.Packet
+	disconnected *chat.Message
+}
+
+func (c *chatClient) SendPacket(p pk.Packet)             { c.packets = append(c.packets, p) }
+func (c *chatClient) SendDisconnect(reason chat.Message) { c.disconnected = &reason }
+
+func TestChat_HandleChatCommandSigned(t *testing.T) {
+	c := NewChat(ChatPolicy{})
+	var client chatClient
+	c.ClientJoin(&client, uuid.New(), "Steve")
+
+	u := ack(0, 0)
+	p := pk.Marshal(
+		packetid.ServerboundChatCommandSigned,
+		pk.String("say hi"),
+		pk.Long(0),
+		pk.Long(0),
+		pk.VarInt(0), // no argument signatures
+		u.Offset, u.Acknowledged, u.Checksum,
+	)
+	// The signable may use the Chat, such as broadcasting the feedback of a command.
+	signable := func(cmd string) ([]sign.Argument, error) {
+		c.Broadcast(chat.Text("parsing " + cmd))
+		return []sign.Argument{{Name: "message", Value: "hi"}}, nil
+	}
+	cmd, signed, err := c.HandleChatCommandSigned(&client, p, signable)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.disconnected != nil {
+		t.Fatalf("disconnected: %s", client.disconnected.ClearString())
+	}
+	if cmd != "say hi" || len(signed) != 1 || signed[0].PlainMsg != "hi" || signed[0].Signature != nil {
+		t.Errorf("got %q %v", cmd, signed)
+	}
+}
@@ -0,0 +1,754 @@
+package command
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"git.konjactw.dev/falloutBot/go-mc/chat"
+	"git.konjactw.dev/falloutBot/go-mc/nbt"
+	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
+)
+
+// EntitySelector is the value of EntityParser and GameProfileParser.
+// It's either a player name, an entity UUID or a target selector like "@e[type=cow,limit=1]".
+type EntitySelector struct {
+	// Name is set if it's a player name.
+	Name string
+	// UUID is set if it's an entity UUID.
+	UUID uuid.UUID
+	// Selector is the variable after '@', which is 'p', 'a', 'r', 's', 'e' or 'n'. It's 0 if not a selector.
+	Selector byte
+	// Arguments are the arguments of the selector in order.
+	Arguments []SelectorArgument
+}
+
+// SelectorArgument is an argument of a target selector, such as "type=!player".
+type SelectorArgument struct {
+	Key, Value string
+}
+
+// Arg returns the value of the first argument with the key.
+func (e EntitySelector) Arg(key string) (string, bool) {
+	i := slices.IndexFunc(e.Arguments, func(a SelectorArgument) bool { return a.Key == key })
+	if i == -1 {
+		return "", false
+	}
+	return e.Arguments[i].Value, true
+}
+
+// EntityParser parses an EntitySelector.
+type EntityParser struct {
+	// Single only allows selecting one entity.
+	Single bool
+	// PlayersOnly only allows selecting players.
+	PlayersOnly bool
+}
+
+func (p EntityParser) WriteTo(w io.Writer) (int64, error) {
+	var flags byte
+	if p.Single {
+		flags |= 0x01
+	}
+	if p.PlayersOnly {
+		flags |= 0x02
+	}
+	return pk.Tuple{argumentType("minecraft:entity"), pk.Byte(flags)}.WriteTo(w)
+}
+
+func (p EntityParser) Parse(cmd string) (left string, value ParsedData, err error) {
+	left, selector, err := parseEntitySelector(cmd)
+	if err != nil {
+		return cmd, nil, err
+	}
+	if selector.Selector != 0 {
+		typ, _ := selector.Arg("type")
+		if p.Single && !selectsOne(selector) {
+			return cmd, nil, ParseErr{Pos: 0, Err: "only one entity is allowed, but the provided selector allows more than one"}
+		}
+		if p.PlayersOnly && typ != "player" && (selector.Selector == 'e' || selector.Selector == 'n') {
+			return cmd, nil, ParseErr{Pos: 0, Err: "only players may be affected by this command, but the provided selector includes entities"}
+		}
+	} else if p.PlayersOnly && selector.Name == "" {
+		return cmd, nil, ParseErr{Pos: 0, Err: "only players may be affected by this command, but the provided selector includes entities"}
+	}
+	return left, selector, nil
+}
+
+// selectsOne reports whether the selector selects at most one entity.
+// The limit defaults to 1 for @p, @r, @s and @n, and is unlimited for @a and @e.
+func selectsOne(selector EntitySelector) bool {
+	limit, ok := selector.Arg("limit")
+	if !ok {
+		return selector.Selector != 'a' && selector.Selector != 'e'
+	}
+	n, err := strconv.Atoi(limit)
+	return err == nil && n == 1
+}
+
+// GameProfileParser parses an EntitySelector selecting players, including the offline ones by name.
+type GameProfileParser struct{}
+
+func (GameProfileParser) WriteTo(w io.Writer) (int64, error) {
+	return argumentType("minecraft:game_profile").WriteTo(w)
+}
+
+func (GameProfileParser) Parse(cmd string) (left string, value ParsedData, err error) {
+	left, selector, err := parseEntitySelector(cmd)
+	if err != nil {
+		return cmd, nil, err
+	}
+	return left, selector, nil
+}
+
+func parseEntitySelector(cmd string) (left string, selector EntitySelector, err error) {
+	if !strings.HasPrefix(cmd, "@") {
+		word, left := readWord(cmd)
+		if word == "" {
+			return cmd, selector, ParseErr{Pos: 0, Err: "expected name or UUID"}
+		}
+		if id, err := uuid.Parse(word); err == nil && len(word) == 36 {
+			selector.UUID = id
+		} else if len(word) > 16 {
+			return cmd, selector, ParseErr{Pos: 0, Err: "invalid name or UUID"}
+		} else {
+			selector.Name = word
+		}
+		return left, selector, nil
+	}
+	if len(cmd) < 2 || !strings.ContainsRune("parsen", rune(cmd[1])) {
+		return cmd, selector, ParseErr{Pos: 1, Err: "unknown selector type"}
+	}
+	selector.Selector = cmd[1]
+	left = cmd[2:]
+	if !strings.HasPrefix(left, "[") {
+		return left, selector, nil
+	}
+	token, left, err := readGroup(left)
+	if err != nil {
+		return cmd, selector, ParseErr{Pos: 2 + err.(ParseErr).Pos, Err: err.(ParseErr).Err}
+	}
+	for _, arg := range splitTopLevel(token[1:len(token)-1], ',') {
+		key, value, ok := strings.Cut(arg, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return cmd, selector, ParseErr{Pos: 2, Err: "expected value for option '" + key + "'"}
+		}
+		selector.Arguments = append(selector.Arguments, SelectorArgument{Key: key, Value: strings.TrimSpace(value)})
+	}
+	return left, selector, nil
+}
+
+// Coord is a coordinate, which can be absolute, relative to the source like "~1",
+// or local to the source's rotation like "^1".
+type Coord struct {
+	Value    float64
+	Relative bool
+	Local    bool
+}
+
+// Resolve returns the absolute coordinate, the base is the source's coordinate for the relative ones.
+// Local coordinates need the rotation of the source, so they aren't resolved.
+func (c Coord) Resolve(base float64) float64 {
+	if c.Relative {
+		return base + c.Value
+	}
+	return c.Value
+}
+
+// BlockPos is the value of BlockPosParser.
+type BlockPos struct{ X, Y, Z Coord }
+
+// Vec3 is the value of Vec3Parser.
+type Vec3 struct{ X, Y, Z Coord }
+
+// ColumnPos is the value of ColumnPosParser.
+type ColumnPos struct{ X, Z Coord }
+
+// Rotation is the value of RotationParser.
+type Rotation struct{ Yaw, Pitch Coord }
+
+// BlockPosParser parses three integer coordinates.
+type BlockPosParser struct{}
+
+func (BlockPosParser) WriteTo(w io.Writer) (int64, error) {
+	return argumentType("minecraft:block_pos").WriteTo(w)
+}
+
+func (BlockPosParser) Parse(cmd string) (left string, value ParsedData, err error) {
+	left, c, err := parseCoords(cmd, 3, true, true)
+	if err != nil {
+		return cmd, nil, err
+	}
+	return left, BlockPos{c[0], c[1], c[2]}, nil
+}
+
+// Vec3Parser parses three coordinates.
+type Vec3Parser struct{}
+
+func (Vec3Parser) WriteTo(w io.Writer) (int64, error) {
+	return argumentType("minecraft:vec3").WriteTo(w)
+}
+
+func (Vec3Parser) Parse(cmd string) (left string, value ParsedData, err error) {
+	left, c, err := parseCoords(cmd, 3, true, false)
+	if err != nil {
+		return cmd, nil, err
+	}
+	return left, Vec3{c[0], c[1], c[2]}, nil
+}
+
+// ColumnPosParser parses the integer X and Z coordinates.
+type ColumnPosParser struct{}
+
+func (ColumnPosParser) WriteTo(w io.Writer) (int64, error) {
+	return argumentType("minecraft:column_pos").WriteTo(w)
+}
+
+func (ColumnPosParser) Parse(cmd string) (left string, value ParsedData, err error) {
+	left, c, err := parseCoords(cmd, 2, false, true)
+	if err != nil {
+		return cmd, nil, err
+	}
+	return left, ColumnPos{c[0], c[1]}, nil
+}
+
+// RotationParser parses the yaw and pitch angles.
+type RotationParser struct{}
+
+func (RotationParser) WriteTo(w io.Writer) (int64, error) {
+	return argumentType("minecraft:rotation").WriteTo(w)
+}
+
+func (RotationParser) Parse(cmd string) (left string, value ParsedData, err error) {
+	left, c, err := parseCoords(cmd, 2, false, false)
+	if err != nil {
+		return cmd, nil, err
+	}
+	return left, Rotation{c[0], c[1]}, nil
+}
+
+// parseCoords parses n coordinates separated by a space.
+// Local coordinates are allowed only if allowLocal, and must not be mixed with the other kinds.
+func parseCoords(cmd string, n int, allowLocal, integer bool) (left string, coords []Coord, err error) {
+	left = cmd
+	for i := range n {
+		if i > 0 {
+			if !strings.HasPrefix(left, " ") {
+				return cmd, nil, ParseErr{Pos: len(cmd) - len(left), Err: "incomplete, expected " + strconv.Itoa(n) + " coordinates"}
+			}
+			left = left[1:]
+		}
+		pos := len(cmd) - len(left)
+		var word string
+		word, left = readWord(left)
+		var c Coord
+		switch {
+		case strings.HasPrefix(word, "~"):
+			c.Relative, word = true, word[1:]
+		case strings.HasPrefix(word, "^"):
+			if !allowLocal {
+				return cmd, nil, ParseErr{Pos: pos, Err: "cannot use local coordinates"}
+			}
+			c.Local, word = true, word[1:]
+		case word == "":
+			return cmd, nil, ParseErr{Pos: pos, Err: "expected coordinate"}
+		}
+		if word != "" {
+			if integer && !c.Relative && !c.Local {
+				v, err := strconv.ParseInt(word, 10, 32)
+				if err != nil {
+					return cmd, nil, ParseErr{Pos: pos, Err: "invalid integer '" + word + "'"}
+				}
+				c.Value = float64(v)
+			} else if c.Value, err = strconv.ParseFloat(word, 64); err != nil {
+				return cmd, nil, ParseErr{Pos: pos, Err: "invalid double '" + word + "'"}
+			}
+		}
+		if i > 0 && c.Local != coords[0].Local {
+			return cmd, nil, ParseErr{Pos: pos, Err: "cannot mix world & local coordinates"}
+		}
+		coords = append(coords, c)
+	}
+	return left, coords, nil
+}
+
+// ResourceLocation is an identifier like "minecraft:stone". The namespace is always present.
+type ResourceLocation string
+
+// Namespace returns the part before ':'.
+func (r ResourceLocation) Namespace() string {
+	namespace, _, _ := strings.Cut(string(r), ":")
+	return namespace
+}
+
+// Path returns the part after ':'.
+func (r ResourceLocation) Path() string {
+	_, path, _ := strings.Cut(string(r), ":")
+	return path
+}
+
+// ResourceLocationParser parses a ResourceLocation. The default namespace is "minecraft".
+type ResourceLocationParser struct{}
+
+func (ResourceLocationParser) WriteTo(w io.Writer) (int64, error) {
+	return argumentType("minecraft:resource_location").WriteTo(w)
+}
+
+func (ResourceLocationParser) Parse(cmd string) (left string, value ParsedData, err error) {
+	id, left, err := readResourceLocation(cmd)
+	if err != nil {
+		return cmd, nil, err
+	}
+	return left, id, nil
+}
+
+// readResourceLocation reads the characters allowed in resource locations, and validates them.
+func readResourceLocation(cmd string) (ResourceLocation, string, error) {
+	end := strings.IndexFunc(cmd, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || strings.ContainsRune("_-.:/", r))
+	})
+	if end == -1 {
+		end = len(cmd)
+	}
+	s := cmd[:end]
+	if s == "" {
+		return "", cmd, ParseErr{Pos: 0, Err: "expected resource location"}
+	}
+	namespace, path, ok := strings.Cut(s, ":")
+	if !ok {
+		namespace, path = "minecraft", s
+	} else if namespace == "" {
+		namespace = "minecraft"
+	}
+	if path == "" || strings.ContainsAny(namespace, "/:") || strings.Contains(path, ":") {
+		return "", cmd, ParseErr{Pos: 0, Err: "invalid resource location '" + s + "'"}
+	}
+	return ResourceLocation(namespace + ":" + path), cmd[end:], nil
+}
+
+// Resource is the value of ResourceParser and ResourceKeyParser.
+type Resource struct {
+	Registry ResourceLocation
+	ID       ResourceLocation
+}
+
+// ResourceParser parses the ID of an entry in the registry, such as "minecraft:entity_type".
+type ResourceParser struct {
+	Registry string
+}
+
+func (p ResourceParser) WriteTo(w io.Writer) (int64, error) {
+	return pk.Tuple{argumentType("minecraft:resource"), pk.Identifier(p.Registry)}.WriteTo(w)
+}
+
+func (p ResourceParser) Parse(cmd string) (left string, value ParsedData, err error) {
+	return parseResource(cmd, p.Registry)
+}
+
+// ResourceKeyParser is like ResourceParser, but the client doesn't validate the entry.
+// It's used for the registries which aren't synchronized to the clients.
+type ResourceKeyParser struct {
+	Registry string
+}
+
+func (p ResourceKeyParser) WriteTo(w io.Writer) (int64, error) {
+	return pk.Tuple{argumentType("minecraft:resource_key"), pk.Identifier(p.Registry)}.WriteTo(w)
+}
+
+func (p ResourceKeyParser) Parse(cmd string) (left string, value ParsedData, err error) {
+	return parseResource(cmd, p.Registry)
+}
+
+func parseResource(cmd, registry string) (left string, value ParsedData, err error) {
+	id, left, err := readResourceLocation(cmd)
+	if err != nil {
+		return cmd, nil, err
+	}
+	reg, _, _ := readResourceLocation(registry)
+	return left, Resource{Registry: reg, ID: id}, nil
+}
+
+// DimensionParser parses the ID of a dimension as a ResourceLocation.
+type DimensionParser struct{}
+
+func (DimensionParser) WriteTo(w io.Writer) (int64, error) {
+	return argumentType("minecraft:dimension").WriteTo(w)
+}
+
+func (DimensionParser) Parse(cmd string) (left string, value ParsedData, err error) {
+	return ResourceLocationParser{}.Parse(cmd)
+}
+
+// BlockState is the value of BlockStateParser, like "minecraft:chest[facing=north]{Items:[]}".
+type BlockState struct {
+	Name       ResourceLocation
+	Properties map[string]string
+	// NBT is the SNBT of the block entity data, empty if not present.
+	NBT string
+}
+
+// BlockStateParser parses a BlockState.
+type BlockStateParser struct{}
+
+func (BlockStateParser) WriteTo(w io.Writer) (int64, error) {
+	return argumentType("minecraft:block_state").WriteTo(w)
+}
+
+func (BlockStateParser) Parse(cmd string) (left string, value ParsedData, err error) {
+	name, left, err := readResourceLocation(cmd)
+	if err != nil {
+		return cmd, nil, err
+	}
+	state := BlockState{Name: name}
+	if strings.HasPrefix(left, "[") {
+		var token string
+		if token, left, err = readGroup(left); err != nil {
+			return cmd, nil, err
+		}
+		state.Properties = make(map[string]string)
+		for _, property := range splitTopLevel(token[1:len(token)-1], ',') {
+			key, value, ok := strings.Cut(property, "=")
+			key = strings.TrimSpace(key)
+			if !ok || key == "" {
+				return cmd, nil, ParseErr{Pos: len(name), Err: "expected value for property '" + key + "'"}
+			}
+			state.Properties[key] = strings.TrimSpace(value)
+		}
+	}
+	if strings.HasPrefix(left, "{") {
+		if state.NBT, left, err = readGroup(left); err != nil {
+			return cmd, nil, err
+		}
+	}
+	return left, state, nil
+}
+
+// ItemStack is the value of ItemStackParser, like "minecraft:diamond_sword[damage=5]".
+type ItemStack struct {
+	Name ResourceLocation
+	// Components are the data components in the brackets without parsing, empty if not present.
+	Components string
+}
+
+// ItemStackParser parses an ItemStack.
+type ItemStackParser struct{}
+
+func (ItemStackParser) WriteTo(w io.Writer) (int64, error) {
+	return argumentType("minecraft:item_stack").WriteTo(w)
+}
+
+func (ItemStackParser) Parse(cmd string) (left string, value ParsedData, err error) {
+	name, left, err := readResourceLocation(cmd)
+	if err != nil {
+		return cmd, nil, err
+	}
+	item := ItemStack{Name: name}
+	if strings.HasPrefix(left, "[") {
+		var token string
+		if token, left, err = readGroup(left); err != nil {
+			return cmd, nil, err
+		}
+		item.Components = token[1 : len(token)-1]
+	}
+	return left, item, nil
+}
+
+// ComponentParser parses a text component in SNBT or JSON as a chat.Message.
+type ComponentParser struct{}
+
+func (ComponentParser) WriteTo(w io.Writer) (int64, error) {
+	return argumentType("minecraft:component").WriteTo(w)
+}
+
+func (ComponentParser) Parse(cmd string) (left string, value ParsedData, err error) {
+	token, left, err := readBalanced(cmd)
+	if err != nil {
+		return cmd, nil, err
+	}
+	if token == "" {
+		return cmd, nil, ParseErr{Pos: 0, Err: "expected text component"}
+	}
+	var msg chat.Message
+	if json.Unmarshal([]byte(token), &msg) == nil {
+		return left, msg, nil
+	}
+	data, err := nbt.Marshal(nbt.StringifiedMessage(token))
+	if err == nil {
+		err = nbt.Unmarshal(data, &msg)
+	}
+	if err != nil {
+		return cmd, nil, ParseErr{Pos: 0, Err: "invalid text component: " + err.Error()}
+	}
+	return left, msg, nil
+}
+
+// MessageParser parses the rest of the command as a string, which may contain target selectors.
+type MessageParser struct{}
+
+func (MessageParser) WriteTo(w io.Writer) (int64, error) {
+	return argumentType("minecraft:message").WriteTo(w)
+}
+
+func (MessageParser) Parse(cmd string) (left string, value ParsedData, err error) {
+	return "", cmd, nil
+}
+
+// TimeParser parses a duration in ticks as an int32, which may have a unit: "d" for days, "s" for seconds, or "t" for ticks.
+type TimeParser struct {
+	// Min is the minimum ticks.
+	Min int32
+}
+
+func (p TimeParser) WriteTo(w io.Writer) (int64, error) {
+	return pk.Tuple{argumentType("minecraft:time"), pk.Int(p.Min)}.WriteTo(w)
+}
+
+func (p TimeParser) Parse(cmd string) (left string, value ParsedData, err error) {
+	word, left := readWord(cmd)
+	scale := 1.0
+	switch {
+	case strings.HasSuffix(word, "d"):
+		scale, word = 24000, word[:len(word)-1]
+	case strings.HasSuffix(word, "s"):
+		scale, word = 20, word[:len(word)-1]
+	case strings.HasSuffix(word, "t"):
+		word = word[:len(word)-1]
+	}
+	v, err := strconv.ParseFloat(word, 64)
+	if err != nil {
+		return cmd, nil, ParseErr{Pos: 0, Err: "invalid time '" + word + "'"}
+	}
+	ticks := int32(math.Round(v * scale))
+	if ticks < p.Min {
+		return cmd, nil, ParseErr{Pos: 0, Err: "tick count must not be less than " + strconv.Itoa(int(p.Min)) + ", found " + strconv.Itoa(int(ticks))}
+	}
+	return left, ticks, nil
+}
+
+// UUIDParser parses a hyphenated UUID as a uuid.UUID.
+type UUIDParser struct{}
+
+func (UUIDParser) WriteTo(w io.Writer) (int64, error) {
+	return argumentType("minecraft:uuid").WriteTo(w)
+}
+
+func (UUIDParser) Parse(cmd string) (left string, value ParsedData, err error) {
+	word, left := readWord(cmd)
+	id, err := uuid.Parse(word)
+	if err != nil || len(word) != 36 {
+		return cmd, nil, ParseErr{Pos: 0, Err: "invalid UUID"}
+	}
+	return left, id, nil
+}
+
+// GameMode is the value of GameModeParser, the same as the ID of the game mode on the protocol.
+type GameMode int32
+
+const (
+	Survival GameMode = iota
+	Creative
+	Adventure
+	Spectator
+)
+
+var gameModeNames = []string{"survival", "creative", "adventure", "spectator"}
+
+func (g GameMode) String() string {
+	if g >= 0 && int(g) < len(gameModeNames) {
+		return gameModeNames[g]
+	}
+	return "GameMode(" + strconv.Itoa(int(g)) + ")"
+}
+
+// GameModeParser parses the name of a GameMode.
+type GameModeParser struct{}
+
+func (GameModeParser) WriteTo(w io.Writer) (int64, error) {
+	return argumentType("minecraft:gamemode").WriteTo(w)
+}
+
+func (GameModeParser) Parse(cmd string) (left string, value ParsedData, err error) {
+	word, left := readWord(cmd)
+	i := slices.Index(gameModeNames, word)
+	if i == -1 {
+		return cmd, nil, ParseErr{Pos: 0, Err: "unknown game mode '" + word + "'"}
+	}
+	return left, GameMode(i), nil
+}
+
+func (GameModeParser) Suggest(remaining string) []string {
+	return filterPrefix(gameModeNames, remaining)
+}
+
+// ColorParser parses the name of a chat color, such as chat.Red, or "reset".
+type ColorParser struct{}
+
+var colorNames = []string{
+	chat.Black, chat.DarkBlue, chat.DarkGreen, chat.DarkAqua,
+	chat.DarkRed, chat.DarkPurple, chat.Gold, chat.Gray,
+	chat.DarkGray, chat.Blue, chat.Green, chat.Aqua,
+	chat.Red, chat.LightPurple, chat.Yellow, chat.White,
+	"reset",
+}
+
+func (ColorParser) WriteTo(w io.Writer) (int64, error) {
+	return argumentType("minecraft:color").WriteTo(w)
+}
+
+func (ColorParser) Parse(cmd string) (left string, value ParsedData, err error) {
+	word, left := readWord(cmd)
+	if !slices.Contains(colorNames, word) {
+		return cmd, nil, ParseErr{Pos: 0, Err: "unknown color '" + word + "'"}
+	}
+	return left, word, nil
+}
+
+func (ColorParser) Suggest(remaining string) []string {
+	return filterPrefix(colorNames, remaining)
+}
+
+// ObjectiveParser parses the name of a scoreboard objective as a string.
+type ObjectiveParser struct{}
+
+func (ObjectiveParser) WriteTo(w io.Writer) (int64, error) {
+	return argumentType("minecraft:objective").WriteTo(w)
+}
+
+func (ObjectiveParser) Parse(cmd string) (left string, value ParsedData, err error) {
+	word, left := readWord(cmd)
+	if word == "" {
+		return cmd, nil, ParseErr{Pos: 0, Err: "expected objective"}
+	}
+	return left, word, nil
+}
+
+// Swizzle is the value of SwizzleParser, which axes are selected.
+type Swizzle struct{ X, Y, Z bool }
+
+// SwizzleParser parses a combination of the axes, like "xz".
+type SwizzleParser struct{}
+
+func (SwizzleParser) WriteTo(w io.Writer) (int64, error) {
+	return argumentType("minecraft:swizzle").WriteTo(w)
+}
+
+func (SwizzleParser) Parse(cmd string) (left string, value ParsedData, err error) {
+	word, left := readWord(cmd)
+	var s Swizzle
+	for i, c := range word {
+		var axis *bool
+		switch c {
+		case 'x':
+			axis = &s.X
+		case 'y':
+			axis = &s.Y
+		case 'z':
+			axis = &s.Z
+		default:
+			return cmd, nil, ParseErr{Pos: i, Err: "invalid swizzle, expected combination of 'x', 'y' and 'z'"}
+		}
+		if *axis {
+			return cmd, nil, ParseErr{Pos: i, Err: "invalid swizzle, expected combination of 'x', 'y' and 'z'"}
+		}
+		*axis = true
+	}
+	if word == "" {
+		return cmd, nil, ParseErr{Pos: 0, Err: "invalid swizzle, expected combination of 'x', 'y' and 'z'"}
+	}
+	return left, s, nil
+}
+
+// readBalanced reads a token until a whitespace outside the brackets, braces and quotes.
+func readBalanced(cmd string) (token, left string, err error) {
+	return scanBalanced(cmd, false)
+}
+
+// readGroup reads the brackets or braces at the beginning of cmd, until its matching end.
+func readGroup(cmd string) (token, left string, err error) {
+	return scanBalanced(cmd, true)
+}
+
+func scanBalanced(cmd string, group bool) (token, left string, err error) {
+	var (
+		depth   []byte
+		quote   byte
+		escaped bool
+	)
+	for i := 0; i < len(cmd); i++ {
+		c := cmd[i]
+		switch {
+		case quote != 0:
+			if escaped {
+				escaped = false
+			} else if c == '\\' {
+				escaped = true
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[' || c == '{':
+			depth = append(depth, c)
+		case c == ']' || c == '}':
+			open := byte('[')
+			if c == '}' {
+				open = '{'
+			}
+			if len(depth) == 0 || depth[len(depth)-1] != open {
+				return "", cmd, ParseErr{Pos: i, Err: "unexpected '" + string(c) + "'"}
+			}
+			depth = depth[:len(depth)-1]
+			if group && len(depth) == 0 {
+				return cmd[:i+1], cmd[i+1:], nil
+			}
+		case c == ' ' && len(depth) == 0:
+			return cmd[:i], cmd[i:], nil
+		}
+	}
+	if quote != 0 || len(depth) > 0 {
+		return "", cmd, ParseErr{Pos: len(cmd), Err: "unexpected end of input"}
+	}
+	return cmd, "", nil
+}
+
+// splitTopLevel splits s by sep, except in the brackets, braces and quotes.
+func splitTopLevel(s string, sep byte) (parts []string) {
+	var (
+		depth   int
+		quote   byte
+		escaped bool
+		start   int
+	)
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if escaped {
+				escaped = false
+			} else if c == '\\' {
+				escaped = true
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[' || c == '{':
+			depth++
+		case c == ']' || c == '}':
+			depth--
+		case c == sep && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	if strings.TrimSpace(s[start:]) != "" || len(parts) > 0 {
+		parts = append(parts, s[start:])
+	}
+	return
+}
@@ -5,6 +5,31 @@ func (g *Graph) AppendLiteral(child *Literal) *Graph {
 	return g
 }
 
+// AppendLiteral adds a child to the built node,
+// which is needed by the nodes redirecting to their ancestors, like "/execute".
+func (l *Literal) AppendLiteral(child *Literal) *Literal {
+	l.Children = append(l.Children, child.index)
+	return l
+}
+
+// AppendArgument adds a child to the built node, see Literal.AppendLiteral.
+func (l *Literal) AppendArgument(child *Argument) *Literal {
+	l.Children = append(l.Children, child.index)
+	return l
+}
+
+// AppendLiteral adds a child to the built node, see Literal.AppendLiteral.
+func (a *Argument) AppendLiteral(child *Literal) *Argument {
+	a.Children = append(a.Children, child.index)
+	return a
+}
+
+// AppendArgument adds a child to the built node, see Literal.AppendLiteral.
+func (a *Argument) AppendArgument(child *Argument) *Argument {
+	a.Children = append(a.Children, child.index)
+	return a
+}
+
 // Literal create a new LiteralNode in the Graph.
 func (g *Graph) Literal(str string) LiteralBuilder {
 	index := int32(len(g.nodes))
@@ -53,6 +78,34 @@ func (n LiteralBuilder) Unhandle() *Literal {
 	return n.HandleFunc(unhandledCmd)
 }
 
+// Redirect makes the rest of the command parsed from the children of the target,
+// like "/tp" redirects to "/teleport", and "/execute run" redirects to the root.
+// The modifier can be nil, otherwise it decides the contexts of the rest of the command.
+// The node isn't executable unless its Run is also set.
+func (n LiteralBuilder) Redirect(target Target, modifier RedirectModifier) *Literal {
+	n.current.setRedirect(target, modifier, false)
+	return (*Literal)(n.current)
+}
+
+// Fork is like Redirect, but the rest of the command is executed with each context returned by the modifier,
+// and the errors of some contexts don't stop the others, like "/execute as @a".
+func (n LiteralBuilder) Fork(target Target, modifier RedirectModifier) *Literal {
+	n.current.setRedirect(target, modifier, true)
+	return (*Literal)(n.current)
+}
+
+// Requires sets the requirement of the node, which hides it and its children from the sources not allowed.
+func (n LiteralBuilder) Requires(r Requirement) LiteralBuilder {
+	n.current.requires = r
+	return n
+}
+
+// Describes sets the Description of the node.
+func (n LiteralBuilder) Describes(desc string) LiteralBuilder {
+	n.current.Description = desc
+	return n
+}
+
 type ArgumentBuilder struct {
 	current *Node
 }
@@ -76,6 +129,49 @@ func (n ArgumentBuilder) Unhandle() *Argument {
 	return n.HandleFunc(unhandledCmd)
 }
 
+// Redirect makes the rest of the command parsed from the children of the target,
+// like "/tp" redirects to "/teleport", and "/execute run" redirects to the root.
+// The modifier can be nil, otherwise it decides the contexts of the rest of the command.
+// The node isn't executable unless its Run is also set.
+func (n ArgumentBuilder) Redirect(target Target, modifier RedirectModifier) *Argument {
+	n.current.setRedirect(target, modifier, false)
+	return (*Argument)(n.current)
+}
+
+// Fork is like Redirect, but the rest of the command is executed with each context returned by the modifier,
+// and the errors of some contexts don't stop the others, like "/execute as @a".
+func (n ArgumentBuilder) Fork(target Target, modifier RedirectModifier) *Argument {
+	n.current.setRedirect(target, modifier, true)
+	return (*Argument)(n.current)
+}
+
+// Requires sets the requirement of the node, see LiteralBuilder.Requires.
+func (n ArgumentBuilder) Requires(r Requirement) ArgumentBuilder {
+	n.current.requires = r
+	return n
+}
+
+// Describes sets the Description of the node.
+func (n ArgumentBuilder) Describes(desc string) ArgumentBuilder {
+	n.current.Description = desc
+	return n
+}
+
+// Suggests makes the client ask the server for the suggestions of the argument, which are returned by f.
+func (n ArgumentBuilder) Suggests(f SuggestFunc) ArgumentBuilder {
+	n.current.SuggestionsType = AskServer
+	n.current.suggest = f
+	return n
+}
+
+// SuggestsType makes the client compute the suggestions of the argument itself,
+// the type is one of AllRecipes, AvailableSounds and SummonableEntities.
+func (n ArgumentBuilder) SuggestsType(typ string) ArgumentBuilder {
+	n.current.SuggestionsType = typ
+	n.current.suggest = nil
+	return n
+}
+
 type LiteralBuilderWithLiteral struct {
 	n LiteralBuilder
 }
@@ -92,6 +188,16 @@ func (n LiteralBuilderWithLiteral) Unhandle() *Literal {
 	return n.n.Unhandle()
 }
 
+// Redirect makes the node redirect to the target, see LiteralBuilder.Redirect.
+func (n LiteralBuilderWithLiteral) Redirect(target Target, modifier RedirectModifier) *Literal {
+	return n.n.Redirect(target, modifier)
+}
+
+// Fork makes the node fork to the target, see LiteralBuilder.Fork.
+func (n LiteralBuilderWithLiteral) Fork(target Target, modifier RedirectModifier) *Literal {
+	return n.n.Fork(target, modifier)
+}
+
 type LiteralBuilderWithArgument struct {
 	n LiteralBuilder
 }
@@ -104,6 +210,16 @@ func (n LiteralBuilderWithArgument) Unhandle() *Literal {
 	return n.n.Unhandle()
 }
 
+// Redirect makes the node redirect to the target, see LiteralBuilder.Redirect.
+func (n LiteralBuilderWithArgument) Redirect(target Target, modifier RedirectModifier) *Literal {
+	return n.n.Redirect(target, modifier)
+}
+
+// Fork makes the node fork to the target, see LiteralBuilder.Fork.
+func (n LiteralBuilderWithArgument) Fork(target Target, modifier RedirectModifier) *Literal {
+	return n.n.Fork(target, modifier)
+}
+
 type ArgumentBuilderWithLiteral struct {
 	n ArgumentBuilder
 }
@@ -120,6 +236,16 @@ func (n ArgumentBuilderWithLiteral) Unhandle() *Argument {
 	return n.n.Unhandle()
 }
 
+// Redirect makes the node redirect to the target, see LiteralBuilder.Redirect.
+func (n ArgumentBuilderWithLiteral) Redirect(target Target, modifier RedirectModifier) *Argument {
+	return n.n.Redirect(target, modifier)
+}
+
+// Fork makes the node fork to the target, see LiteralBuilder.Fork.
+func (n ArgumentBuilderWithLiteral) Fork(target Target, modifier RedirectModifier) *Argument {
+	return n.n.Fork(target, modifier)
+}
+
 type ArgumentBuilderWithArgument struct {
 	n ArgumentBuilder
 }
@@ -131,3 +257,13 @@ func (n ArgumentBuilderWithArgument) HandleFunc(f HandlerFunc) *Argument {
 func (n ArgumentBuilderWithArgument) Unhandle() *Argument {
 	return n.n.Unhandle()
 }
+
+// Redirect makes the node redirect to the target, see LiteralBuilder.Redirect.
+func (n ArgumentBuilderWithArgument) Redirect(target Target, modifier RedirectModifier) *Argument {
+	return n.n.Redirect(target, modifier)
+}
+
+// Fork makes the node fork to the target, see LiteralBuilder.Fork.
+func (n ArgumentBuilderWithArgument) Fork(target Target, modifier RedirectModifier) *Argument {
+	return n.n.Fork(target, modifier)
+}
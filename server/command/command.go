@@ -3,7 +3,11 @@ package command
 import (
 	"context"
 	"errors"
+	"slices"
 	"strings"
+	"unicode"
+
+	"git.konjactw.dev/falloutBot/go-mc/chat"
 )
 
 const (
@@ -26,27 +30,45 @@ func NewGraph() *Graph {
 	return &g
 }
 
-func (g *Graph) Execute(ctx context.Context, cmd string) error {
-	var args []ParsedData
-	node := g.nodes[0] // root
+// Execute parses and runs the cmd as the source.
+// The nodes the source can't use are treated as if they don't exist.
+//
+// The errors of parsing the cmd are SyntaxErr, and the errors returned by the handlers are returned as is.
+// Use ErrorMessage to render the error for the source.
+func (g *Graph) Execute(ctx context.Context, src Source, cmd string) error {
+	return g.execute(ctx, src, cmd, g.nodes[0], cmd, nil, nil)
+}
+
+// execute parses the cmd, the rest of the input, from the node, and runs the command.
+// The path and args are the nodes and values parsed before the node.
+func (g *Graph) execute(ctx context.Context, src Source, input string, node *Node, cmd string, path []*Node, args []ParsedData) error {
+	src = sourceOf(ctx, src)
 	for {
 		// parser command
 		left, value, err := node.parse(cmd)
 		if err != nil {
-			return err
+			return syntaxErr(input, len(input)-len(cmd), err)
 		}
+		path = append(path, node)
 		args = append(args, value)
-		left = strings.TrimSpace(left)
-		if len(left) == 0 {
-			return node.Run(ctx, args)
+		left = strings.TrimLeftFunc(left, unicode.IsSpace)
+		cursor := len(input) - len(left)
+		if len(strings.TrimSpace(left)) == 0 {
+			if node.Run == nil {
+				return SyntaxErr{Input: input, Cursor: cursor, Msg: chat.TranslateMsg("command.unknown.command")}
+			}
+			return node.Run(newContext(ctx, src, input, path, args), args)
+		}
+		if node.kind&hasRedirect != 0 {
+			return g.redirect(ctx, src, input, node, left, path, args)
 		}
 		// find next node
 		next, err := node.next(left)
 		if err != nil {
-			return err
+			return syntaxErr(input, cursor, err)
 		}
-		if next == 0 {
-			return errors.New("command contains extra text: " + left)
+		if next == 0 || !g.nodes[next].canUse(src) {
+			return unknownErr(input, cursor, node)
 		}
 
 		cmd = left
@@ -54,8 +76,118 @@ func (g *Graph) Execute(ctx context.Context, cmd string) error {
 	}
 }
 
+// unknownErr is the SyntaxErr of the unknown command or argument after the node.
+func unknownErr(input string, cursor int, node *Node) error {
+	key := "command.unknown.argument"
+	if node.kind&0x03 == RootNode {
+		key = "command.unknown.command"
+	}
+	return SyntaxErr{Input: input, Cursor: cursor, Msg: chat.TranslateMsg(key)}
+}
+
+// redirect continues parsing the rest of the command from the children of the node's redirect target,
+// with the contexts returned by the node's modifier.
+//
+// The args are restarted from the path to the target, as if the command is typed from the target.
+// So the handlers can always find their arguments at the same index.
+func (g *Graph) redirect(ctx context.Context, src Source, input string, node *Node, left string, path []*Node, args []ParsedData) error {
+	target := g.nodes[node.redirect]
+	contexts := []context.Context{ctx}
+	if node.modifier != nil {
+		var err error
+		if contexts, err = node.modifier(newContext(ctx, src, input, path, args), args); err != nil {
+			return err
+		}
+	}
+	cursor := len(input) - len(left)
+	next, err := target.next(left)
+	if err != nil {
+		return syntaxErr(input, cursor, err)
+	}
+	if next == 0 || !target.canUse(src) || !g.nodes[next].canUse(src) {
+		return unknownErr(input, cursor, target)
+	}
+
+	prefix := g.prefix(target)
+	var errs []error
+	for _, ctx := range contexts {
+		err := g.execute(ctx, src, input, g.nodes[next], left, prefix, values(prefix))
+		if err != nil && !node.fork {
+			return err
+		}
+		errs = append(errs, err)
+	}
+	// A fork succeeds if any of its branches succeeds.
+	if slices.Contains(errs, nil) {
+		return nil
+	}
+	return errors.Join(errs...)
+}
+
+// prefix returns the nodes along the path from the root to the target, including the target.
+func (g *Graph) prefix(target *Node) []*Node {
+	// breadth-first search the path from the root
+	parents := map[int32]int32{0: -1}
+	queue := []int32{0}
+	for len(queue) > 0 && queue[0] != target.index {
+		for _, child := range g.nodes[queue[0]].Children {
+			if _, visited := parents[child]; !visited {
+				parents[child] = queue[0]
+				queue = append(queue, child)
+			}
+		}
+		queue = queue[1:]
+	}
+	var path []*Node
+	for i := target.index; i != -1; i = parents[i] {
+		path = append(path, g.nodes[i])
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// values returns the args of the path returned by prefix.
+// The values of the argument nodes on the path are unknown, which are nil.
+func values(path []*Node) []ParsedData {
+	args := make([]ParsedData, len(path))
+	for i, n := range path {
+		if n.kind&0x03 == LiteralNode {
+			args[i] = LiteralData(n.Name)
+		}
+	}
+	return args
+}
+
+// RedirectModifier is called when the command is redirected with the *Context of the parsed part,
+// and returns the contexts used to execute the rest of the command, see WithSource.
+// The command is executed once with each context, so a fork can run the command several times, or none.
+type RedirectModifier func(ctx context.Context, args []ParsedData) ([]context.Context, error)
+
+// Target is the target of redirects, which is a *Literal, an *Argument or the root node by *Graph.
+type Target interface {
+	node() *Node
+}
+
+func (g *Graph) node() *Node    { return g.nodes[0] }
+func (l *Literal) node() *Node  { return (*Node)(l) }
+func (a *Argument) node() *Node { return (*Node)(a) }
+
+// setRedirect makes the node redirect to the target.
+func (n *Node) setRedirect(target Target, modifier RedirectModifier, fork bool) {
+	t := target.node()
+	if t.g != n.g {
+		panic("command: redirect to a node in another graph")
+	}
+	n.kind |= hasRedirect
+	n.redirect = t.index
+	n.modifier = modifier
+	n.fork = fork
+}
+
 type ParsedData any
 
+// HandlerFunc handles a command with the values of the nodes from the root, including the literals.
+// The ctx is a *Context, see Handle.
 type HandlerFunc func(ctx context.Context, args []ParsedData) error
 
 // Node is the node of the Graph. There are 3 kinds of node: Root, Literal and Argument.
@@ -69,6 +201,18 @@ type Node struct {
 	SuggestionsType string
 	Parser          Parser
 	Run             HandlerFunc
+	// Description is the help text of the node, which isn't sent to the clients.
+	Description string
+
+	// redirect is the index of the target node, only used if the kind has the hasRedirect flag.
+	redirect int32
+	modifier RedirectModifier
+	// fork ignores the errors of each context, see RedirectModifier.
+	fork bool
+	// requires decides who can use the node, nil for everyone.
+	requires Requirement
+	// suggest is the SuggestFunc of the argument node with the AskServer suggestions type.
+	suggest SuggestFunc
 }
 type (
 	Literal  Node
@@ -1,8 +1,12 @@
 package command
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"log"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -26,8 +30,88 @@ func TestRoot_Run(t *testing.T) {
 		HandleFunc(handleFunc),
 	)
 
-	err := g.Execute(context.TODO(), "me Tnze Xi_Xi_Mi")
+	err := g.Execute(context.TODO(), PermissionLevel(0), "me Tnze Xi_Xi_Mi")
 	if err != nil {
 		t.Fatal(err)
 	}
 }
+
+type senderKey struct{}
+
+func TestGraph_redirect(t *testing.T) {
+	var (
+		teleported []ParsedData
+		said       []string
+	)
+	g := NewGraph()
+	teleport := g.Literal("teleport").
+		AppendArgument(g.Argument("target", StringParser(0)).
+			HandleFunc(func(ctx context.Context, args []ParsedData) error {
+				teleported = args
+				return nil
+			})).
+		Unhandle()
+	say := g.Literal("say").
+		AppendArgument(g.Argument("message", StringParser(2)).
+			HandleFunc(func(ctx context.Context, args []ParsedData) error {
+				sender, _ := ctx.Value(senderKey{}).(string)
+				if sender == "bad" {
+					return errors.New("bad sender")
+				}
+				said = append(said, sender+": "+args[2].(string))
+				return nil
+			})).
+		Unhandle()
+
+	// execute as <targets> ... | execute run ...
+	execute := g.Literal("execute").HandleFunc(nil)
+	as := g.Literal("as").
+		AppendArgument(g.Argument("targets", StringParser(0)).
+			Fork(execute, func(ctx context.Context, args []ParsedData) ([]context.Context, error) {
+				var contexts []context.Context
+				for _, sender := range strings.Split(args[len(args)-1].(string), ",") {
+					contexts = append(contexts, context.WithValue(ctx, senderKey{}, sender))
+				}
+				return contexts, nil
+			})).
+		HandleFunc(nil)
+	run := g.Literal("run").Redirect(g, nil)
+	execute.AppendLiteral(as).AppendLiteral(run)
+
+	g.AppendLiteral(teleport).
+		AppendLiteral(g.Literal("tp").Redirect(teleport, nil)).
+		AppendLiteral(say).
+		AppendLiteral(execute)
+
+	if err := g.Execute(context.TODO(), PermissionLevel(0), "tp Steve"); err != nil {
+		t.Fatal(err)
+	}
+	if len(teleported) != 3 || teleported[1] != LiteralData("teleport") || teleported[2] != "Steve" {
+		t.Errorf("alias should be parsed as the target, got %v", teleported)
+	}
+
+	err := g.Execute(context.TODO(), PermissionLevel(0), "execute as Alex,bad,Steve as Alex,Steve run say hi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"Alex: hi", "Steve: hi", "Alex: hi", "Steve: hi", "Alex: hi", "Steve: hi"}; !slices.Equal(said, want) {
+		t.Errorf("said %v, want %v", said, want)
+	}
+	if err := g.Execute(context.TODO(), PermissionLevel(0), "execute as bad run say hi"); err == nil {
+		t.Error("fork without any successful branch should fail")
+	}
+	if err := g.Execute(context.TODO(), PermissionLevel(0), "execute run"); err == nil {
+		t.Error("incomplete command should fail")
+	}
+
+	tp := g.nodes[slices.IndexFunc(g.nodes, func(n *Node) bool { return n.Name == "tp" })]
+	var buf bytes.Buffer
+	if _, err := tp.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	// flags: literal | has redirect, no children, redirect index, name
+	want := []byte{LiteralNode | hasRedirect, 0, byte(teleport.index), 2, 't', 'p'}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("redirect node encoded as % x, want % x", buf.Bytes(), want)
+	}
+}
@@ -9,9 +9,10 @@ type Client interface {
 	SendPacket(p pk.Packet)
 }
 
-// ClientJoin implement server.Component for Graph
-func (g *Graph) ClientJoin(client Client) {
+// ClientJoin sends the commands the source can use to the client.
+// Call it again to update the client's commands when the source's permission changes.
+func (g *Graph) ClientJoin(client Client, src Source) {
 	client.SendPacket(pk.Marshal(
-		packetid.ClientboundCommands, g,
+		packetid.ClientboundCommands, filteredGraph{g: g, src: src},
 	))
 }
@@ -0,0 +1,103 @@
+package command
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+
+	"git.konjactw.dev/falloutBot/go-mc/chat"
+	mcnet "git.konjactw.dev/falloutBot/go-mc/net"
+)
+
+// ConsoleSource is the Source of the server console and the RCON clients,
+// which has the highest permission level, and writes the feedback as plain text lines to W.
+type ConsoleSource struct {
+	W io.Writer
+
+	lock sync.Mutex
+}
+
+func (c *ConsoleSource) PermissionLevel() int { return 4 }
+
+// SendMessage implements Receiver for ConsoleSource.
+func (c *ConsoleSource) SendMessage(msg chat.Message) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, _ = io.WriteString(c.W, msg.ClearString()+"\n")
+}
+
+// ServeConsole reads the commands from r line by line, such as os.Stdin,
+// and executes them on the Graph as the console, whose feedback and errors are written to w.
+// The leading '/' of the commands is optional.
+//
+// It returns when r reaches EOF, or the ctx is done after a line is read.
+func (g *Graph) ServeConsole(ctx context.Context, r io.Reader, w io.Writer) error {
+	src := &ConsoleSource{W: w}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		cmd := strings.TrimPrefix(strings.TrimSpace(scanner.Text()), "/")
+		if cmd == "" {
+			continue
+		}
+		if err := g.Execute(ctx, src, cmd); err != nil {
+			src.SendMessage(ErrorMessage(err))
+		}
+	}
+	return scanner.Err()
+}
+
+// ServeRCON accepts the RCON clients from l, and serves them by ServeRCONConn in new goroutines.
+// When the ctx is done, l and the connections are closed, and the ctx's error is returned.
+// Otherwise, it returns the error of accepting, such as when l is closed.
+func (g *Graph) ServeRCON(ctx context.Context, l *mcnet.RCONListener, password string) error {
+	stop := context.AfterFunc(ctx, func() { _ = l.Close() })
+	defer stop()
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		go func() {
+			stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+			defer stop()
+			defer conn.Close()
+			_ = g.ServeRCONConn(ctx, conn, password)
+		}()
+	}
+}
+
+// ServeRCONConn logs in the RCON client with the password,
+// then executes its commands on the Graph as the console until the connection is closed.
+// The feedback and errors of each command are sent back as its response,
+// which is split into multiple packets if it's too large.
+func (g *Graph) ServeRCONConn(ctx context.Context, conn mcnet.RCONServerConn, password string) error {
+	if err := conn.AcceptLogin(password); err != nil {
+		return err
+	}
+	for {
+		cmd, err := conn.AcceptCmd()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		var resp strings.Builder
+		src := &ConsoleSource{W: &resp}
+		if err := g.Execute(ctx, src, strings.TrimPrefix(cmd, "/")); err != nil {
+			src.SendMessage(ErrorMessage(err))
+		}
+		if err := conn.RespCmd(strings.TrimSuffix(resp.String(), "\n")); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"git.konjactw.dev/falloutBot/go-mc/chat"
+	mcnet "git.konjactw.dev/falloutBot/go-mc/net"
+)
+
+func TestGraph_ServeConsole(t *testing.T) {
+	g := NewGraph()
+	g.AppendLiteral(g.Literal("echo").
+		AppendArgument(g.Argument("text", StringParser(2)).
+			HandleFunc(Handle(func(c *Context) error {
+				text, _ := Arg[string](c, "text")
+				c.SendFeedback(chat.Text(text))
+				return nil
+			}))).
+		HandleFunc(nil),
+	).AppendLiteral(g.Literal("stop").
+		Requires(RequiresLevel(4)).
+		HandleFunc(Handle(func(c *Context) error {
+			c.SendFeedback(chat.Text("Stopping the server"))
+			return nil
+		})),
+	)
+
+	var out strings.Builder
+	in := strings.NewReader("echo hello\n\n/stop\nunknown\n")
+	if err := g.ServeConsole(context.TODO(), in, &out); err != nil {
+		t.Fatal(err)
+	}
+	want := "hello\nStopping the server\nUnknown or incomplete command, see below for error\nunknown<--[HERE]\n"
+	if out.String() != want {
+		t.Errorf("got %q, want %q", out.String(), want)
+	}
+}
+
+func TestGraph_ServeRCON(t *testing.T) {
+	g := NewGraph()
+	g.AppendLiteral(g.Literal("echo").
+		AppendArgument(g.Argument("text", StringParser(2)).
+			HandleFunc(Handle(func(c *Context) error {
+				text, _ := Arg[string](c, "text")
+				c.SendFeedback(chat.Text(text))
+				return nil
+			}))).
+		HandleFunc(nil),
+	).AppendLiteral(g.Literal("dump").
+		HandleFunc(Handle(func(c *Context) error {
+			for range 3 {
+				c.SendFeedback(chat.Text(strings.Repeat("x", 3000)))
+			}
+			return nil
+		})),
+	).AppendLiteral(g.Literal("stop").
+		Requires(RequiresLevel(4)).
+		HandleFunc(Handle(func(c *Context) error {
+			c.SendFeedback(chat.Text("Stopping the server"))
+			return nil
+		})),
+	)
+
+	l, err := mcnet.ListenRCON("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- g.ServeRCON(ctx, l, "password") }()
+
+	if _, err := mcnet.DialRCON(l.Addr().String(), "wrong"); err == nil {
+		t.Error("login with a wrong password should fail")
+	}
+	client, err := mcnet.DialRCON(l.Addr().String(), "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	conn := client.(*mcnet.RCONConn)
+
+	if err := conn.Cmd("stop"); err != nil {
+		t.Fatal(err)
+	}
+	if resp, err := conn.Resp(); err != nil || resp != "Stopping the server" {
+		t.Errorf("got %q, %v", resp, err)
+	}
+
+	// a response larger than a packet
+	long := strings.Repeat("x", 3000) + "\n" + strings.Repeat("x", 3000) + "\n" + strings.Repeat("x", 3000)
+	if resp, err := conn.Exec("dump"); err != nil || resp != long {
+		t.Errorf("long response: got %d bytes, %v", len(resp), err)
+	}
+	// the next response isn't mixed with the previous one
+	if resp, err := conn.Exec("echo hi"); err != nil || resp != "hi" {
+		t.Errorf("got %q, %v", resp, err)
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("ServeRCON should return the ctx's error, got %v", err)
+	}
+	if _, err := conn.Resp(); err == nil {
+		t.Error("the connection should be closed")
+	}
+}
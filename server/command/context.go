@@ -0,0 +1,126 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"git.konjactw.dev/falloutBot/go-mc/chat"
+)
+
+// Context is the context of running a command.
+// The ctx passed to the HandlerFunc and the RedirectModifier is always a *Context,
+// which can be used directly by the Handler.
+type Context struct {
+	context.Context
+	// Source is who runs the command, which can be changed by the RedirectModifier, see WithSource.
+	Source Source
+	// X, Y, Z, Yaw and Pitch are where the command is run, from the Source if it's Positioned.
+	X, Y, Z    float64
+	Yaw, Pitch float32
+	// Input is the whole command.
+	Input string
+	// Args are the values parsed, the same as the args of the HandlerFunc.
+	Args []ParsedData
+
+	// path is the nodes parsed, parallel to Args.
+	path []*Node
+}
+
+// Handler handles a command with its Context. Use Handle to convert it to a HandlerFunc.
+type Handler func(c *Context) error
+
+// Handle converts the Handler to a HandlerFunc.
+func Handle(h Handler) HandlerFunc {
+	return func(ctx context.Context, _ []ParsedData) error {
+		return h(ctx.(*Context))
+	}
+}
+
+// Positioned is implemented by the sources which have a position, such as players.
+type Positioned interface {
+	Position() (x, y, z float64)
+	Rotation() (yaw, pitch float32)
+}
+
+// Receiver is implemented by the sources which receive the feedback of the commands,
+// such as players and the console.
+type Receiver interface {
+	SendMessage(msg chat.Message)
+}
+
+type sourceKey struct{}
+
+// WithSource returns a copy of ctx, running the rest of the command as the source.
+// It's used by the RedirectModifier like "/execute as".
+func WithSource(ctx context.Context, src Source) context.Context {
+	return context.WithValue(ctx, sourceKey{}, src)
+}
+
+// sourceOf returns the source set by WithSource, or the src if there isn't one.
+func sourceOf(ctx context.Context, src Source) Source {
+	if s, ok := ctx.Value(sourceKey{}).(Source); ok {
+		return s
+	}
+	return src
+}
+
+func newContext(ctx context.Context, src Source, input string, path []*Node, args []ParsedData) *Context {
+	c := &Context{
+		Context: ctx,
+		Source:  src,
+		Input:   input,
+		Args:    args,
+		path:    path,
+	}
+	if p, ok := src.(Positioned); ok {
+		c.X, c.Y, c.Z = p.Position()
+		c.Yaw, c.Pitch = p.Rotation()
+	}
+	return c
+}
+
+// Argument returns the value of the argument node with the name.
+// The ok is false if the argument isn't parsed, such as an optional argument not given.
+func (c *Context) Argument(name string) (value ParsedData, ok bool) {
+	for i := len(c.path) - 1; i >= 0; i-- {
+		if n := c.path[i]; n.kind&0x03 == ArgumentNode && n.Name == name {
+			return c.Args[i], true
+		}
+	}
+	return nil, false
+}
+
+// SendFeedback sends the message to the Source if it's a Receiver.
+func (c *Context) SendFeedback(msg chat.Message) {
+	if r, ok := c.Source.(Receiver); ok {
+		r.SendMessage(msg)
+	}
+}
+
+// SendFailure sends the message in red to the Source if it's a Receiver.
+func (c *Context) SendFailure(msg chat.Message) {
+	c.SendFeedback(msg.SetColor(chat.Red))
+}
+
+// Arg returns the value of the argument node with the name as T.
+func Arg[T any](c *Context, name string) (T, error) {
+	var zero T
+	value, ok := c.Argument(name)
+	if !ok {
+		return zero, fmt.Errorf("command: argument %q not found", name)
+	}
+	v, ok := value.(T)
+	if !ok {
+		return zero, fmt.Errorf("command: argument %q is %T, not %T", name, value, zero)
+	}
+	return v, nil
+}
+
+// ArgOr is like Arg, but returns the def if the argument isn't parsed or isn't a T.
+// It's useful for the optional arguments.
+func ArgOr[T any](c *Context, name string, def T) T {
+	if v, err := Arg[T](c, name); err == nil {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,145 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"git.konjactw.dev/falloutBot/go-mc/chat"
+)
+
+type testPlayer struct {
+	name     string
+	level    int
+	messages []chat.Message
+}
+
+func (p *testPlayer) PermissionLevel() int { return p.level }
+
+func (p *testPlayer) Position() (x, y, z float64) { return 1, 64, -3 }
+
+func (p *testPlayer) Rotation() (yaw, pitch float32) { return 90, 0 }
+
+func (p *testPlayer) SendMessage(msg chat.Message) { p.messages = append(p.messages, msg) }
+
+func TestContext(t *testing.T) {
+	g := NewGraph()
+	var got *Context
+	g.AppendLiteral(g.Literal("give").
+		AppendArgument(g.Argument("item", StringParser(0)).
+			AppendArgument(g.Argument("count", IntegerParser{Min: 1, Max: 64}).
+				HandleFunc(Handle(func(c *Context) error {
+					got = c
+					c.SendFeedback(chat.Text("Gave " + ArgOr[string](c, "item", "") + " to " + c.Source.(*testPlayer).name))
+					return nil
+				}))).
+			HandleFunc(Handle(func(c *Context) error {
+				got = c
+				return nil
+			}))).
+		Unhandle(),
+	)
+
+	p := &testPlayer{name: "Steve"}
+	if err := g.Execute(context.TODO(), p, "give diamond 5"); err != nil {
+		t.Fatal(err)
+	}
+	if got.X != 1 || got.Y != 64 || got.Z != -3 || got.Yaw != 90 || got.Input != "give diamond 5" {
+		t.Errorf("unexpected context: %+v", got)
+	}
+	if item, err := Arg[string](got, "item"); err != nil || item != "diamond" {
+		t.Errorf("item: %q, %v", item, err)
+	}
+	if count, err := Arg[int32](got, "count"); err != nil || count != 5 {
+		t.Errorf("count: %d, %v", count, err)
+	}
+	if _, err := Arg[string](got, "count"); err == nil {
+		t.Error("count shouldn't be a string")
+	}
+	if len(p.messages) != 1 || p.messages[0].ClearString() != "Gave diamond to Steve" {
+		t.Errorf("feedback: %v", p.messages)
+	}
+
+	if err := g.Execute(context.TODO(), p, "give stone"); err != nil {
+		t.Fatal(err)
+	}
+	if count := ArgOr[int32](got, "count", 1); count != 1 {
+		t.Errorf("default count: %d", count)
+	}
+}
+
+func TestWithSource(t *testing.T) {
+	g := NewGraph()
+	var ran []string
+	kill := g.Literal("kill").
+		Requires(RequiresLevel(2)).
+		HandleFunc(Handle(func(c *Context) error {
+			ran = append(ran, c.Source.(*testPlayer).name)
+			return nil
+		}))
+	execute := g.Literal("execute").HandleFunc(nil)
+	execute.AppendLiteral(g.Literal("as").
+		AppendArgument(g.Argument("target", StringParser(0)).
+			Redirect(execute, func(ctx context.Context, args []ParsedData) ([]context.Context, error) {
+				target, _ := Arg[string](ctx.(*Context), "target")
+				return []context.Context{WithSource(ctx, &testPlayer{name: target, level: len(target) - 2})}, nil
+			})).
+		HandleFunc(nil),
+	).AppendLiteral(g.Literal("run").Redirect(g, nil))
+	g.AppendLiteral(kill).AppendLiteral(execute)
+
+	if err := g.Execute(context.TODO(), &testPlayer{name: "op", level: 4}, "execute as Alex run kill"); err != nil {
+		t.Fatal(err)
+	}
+	// "Bob" has no permission to kill
+	if err := g.Execute(context.TODO(), &testPlayer{name: "op", level: 4}, "execute as Bob run kill"); err == nil {
+		t.Error("execute as a source without permission should fail")
+	}
+	if len(ran) != 1 || ran[0] != "Alex" {
+		t.Errorf("ran as %v", ran)
+	}
+}
+
+func TestSyntaxErr(t *testing.T) {
+	g := NewGraph()
+	g.AppendLiteral(g.Literal("time").
+		AppendLiteral(g.Literal("set").
+			AppendArgument(g.Argument("time", TimeParser{}).HandleFunc(unhandledCmd)).
+			HandleFunc(nil)).
+		HandleFunc(nil),
+	)
+	for _, tc := range []struct {
+		cmd     string
+		cursor  int
+		message string
+		context string
+	}{
+		{"tim", 0, "Unknown or incomplete command, see below for error", "<--[HERE]"},
+		{"time", 4, "Unknown or incomplete command, see below for error", "time<--[HERE]"},
+		{"time add 1", 5, "Incorrect argument for command", "time <--[HERE]"},
+		{"time set abc", 9, "invalid time 'abc'", "time set <--[HERE]"},
+		{"time set 1d extra", 12, "Incorrect argument for command", "...me set 1d <--[HERE]"},
+	} {
+		err := g.Execute(context.TODO(), PermissionLevel(0), tc.cmd)
+		var syntax SyntaxErr
+		if !errors.As(err, &syntax) {
+			t.Errorf("execute %q: %v isn't a SyntaxErr", tc.cmd, err)
+			continue
+		}
+		if syntax.Cursor != tc.cursor || syntax.Context() != tc.context || syntax.Msg.ClearString() != tc.message {
+			t.Errorf("execute %q: got %d %q %q", tc.cmd, syntax.Cursor, syntax.Msg.ClearString(), syntax.Context())
+		}
+	}
+
+	err := SyntaxErr{Input: "time add 1", Cursor: 5, Msg: chat.TranslateMsg("command.unknown.argument")}
+	if want := "Incorrect argument for command at position 5: time <--[HERE]"; err.Error() != want {
+		t.Errorf("got %q, want %q", err.Error(), want)
+	}
+	msg := ErrorMessage(err)
+	if want := "Incorrect argument for command\ntime add 1<--[HERE]"; msg.ClearString() != want {
+		t.Errorf("got %q, want %q", msg.ClearString(), want)
+	}
+	if line := msg.Extra[2]; line.ClickEvent == nil || line.ClickEvent.Value != "/time add 1" || !line.Extra[1].UnderLined {
+		t.Errorf("unexpected context line: %+v", line)
+	}
+}
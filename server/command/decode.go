@@ -0,0 +1,253 @@
+package command
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"unicode"
+
+	"git.konjactw.dev/falloutBot/go-mc/chat"
+	"git.konjactw.dev/falloutBot/go-mc/data/registryid"
+	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
+)
+
+// RawParser is an argument type decoded from the Commands packet, which isn't implemented by this package.
+// Its properties are kept as is, so the Graph can be encoded again.
+type RawParser struct {
+	ID         pk.VarInt
+	Properties []byte
+}
+
+// Name returns the name of the argument type, such as "minecraft:nbt_path".
+func (p RawParser) Name() string {
+	return registryid.CommandArgumentType[p.ID]
+}
+
+func (p RawParser) WriteTo(w io.Writer) (int64, error) {
+	n, err := p.ID.WriteTo(w)
+	if err != nil {
+		return n, err
+	}
+	nn, err := w.Write(p.Properties)
+	return n + int64(nn), err
+}
+
+// Parse reads a token until a whitespace outside the brackets, braces and quotes as a string.
+// It's only an approximation of the argument type, which is good enough for most of them.
+func (p RawParser) Parse(cmd string) (left string, value ParsedData, err error) {
+	token, left, err := readBalanced(cmd)
+	if err != nil {
+		return cmd, nil, err
+	}
+	if token == "" {
+		return cmd, nil, ParseErr{Pos: 0, Err: "expected " + p.Name()}
+	}
+	return left, token, nil
+}
+
+// parsers are the argument types without properties implemented by this package.
+var parsers = map[string]Parser{
+	"brigadier:bool":              BoolParser{},
+	"minecraft:game_profile":      GameProfileParser{},
+	"minecraft:block_pos":         BlockPosParser{},
+	"minecraft:column_pos":        ColumnPosParser{},
+	"minecraft:vec3":              Vec3Parser{},
+	"minecraft:block_state":       BlockStateParser{},
+	"minecraft:item_stack":        ItemStackParser{},
+	"minecraft:color":             ColorParser{},
+	"minecraft:component":         ComponentParser{},
+	"minecraft:message":           MessageParser{},
+	"minecraft:objective":         ObjectiveParser{},
+	"minecraft:rotation":          RotationParser{},
+	"minecraft:swizzle":           SwizzleParser{},
+	"minecraft:resource_location": ResourceLocationParser{},
+	"minecraft:dimension":         DimensionParser{},
+	"minecraft:gamemode":          GameModeParser{},
+	"minecraft:uuid":              UUIDParser{},
+}
+
+// parserDecoder decodes the parser of an argument node in the Commands packet.
+type parserDecoder struct{ p *Parser }
+
+func (d parserDecoder) ReadFrom(r io.Reader) (n int64, err error) {
+	var id pk.VarInt
+	if n, err = id.ReadFrom(r); err != nil {
+		return
+	}
+	if id < 0 || int(id) >= len(registryid.CommandArgumentType) {
+		// The length of its properties is unknown, so the rest of the packet can't be decoded.
+		return n, fmt.Errorf("command: unknown argument type %d", id)
+	}
+	name := registryid.CommandArgumentType[id]
+	if p, ok := parsers[name]; ok {
+		*d.p = p
+		return n, nil
+	}
+
+	var nn int64
+	switch name {
+	case "brigadier:float":
+		p := NewFloatParser()
+		nn, err = readRange(r, (*pk.Float)(&p.Min), (*pk.Float)(&p.Max))
+		*d.p = p
+	case "brigadier:double":
+		p := NewDoubleParser()
+		nn, err = readRange(r, (*pk.Double)(&p.Min), (*pk.Double)(&p.Max))
+		*d.p = p
+	case "brigadier:integer":
+		p := NewIntegerParser()
+		nn, err = readRange(r, (*pk.Int)(&p.Min), (*pk.Int)(&p.Max))
+		*d.p = p
+	case "brigadier:long":
+		p := NewLongParser()
+		nn, err = readRange(r, (*pk.Long)(&p.Min), (*pk.Long)(&p.Max))
+		*d.p = p
+	case "brigadier:string":
+		var format pk.VarInt
+		nn, err = format.ReadFrom(r)
+		*d.p = StringParser(format)
+	case "minecraft:entity":
+		var flags pk.Byte
+		nn, err = flags.ReadFrom(r)
+		*d.p = EntityParser{Single: flags&0x01 != 0, PlayersOnly: flags&0x02 != 0}
+	case "minecraft:time":
+		var p TimeParser
+		nn, err = (*pk.Int)(&p.Min).ReadFrom(r)
+		*d.p = p
+	case "minecraft:resource":
+		var p ResourceParser
+		nn, err = (*pk.Identifier)(&p.Registry).ReadFrom(r)
+		*d.p = p
+	case "minecraft:resource_key":
+		var p ResourceKeyParser
+		nn, err = (*pk.Identifier)(&p.Registry).ReadFrom(r)
+		*d.p = p
+	default:
+		// keep the raw properties of the argument types not implemented
+		var props bytes.Buffer
+		tee := io.TeeReader(r, &props)
+		switch name {
+		case "minecraft:score_holder":
+			var flags pk.Byte
+			nn, err = flags.ReadFrom(tee)
+		case "minecraft:resource_or_tag", "minecraft:resource_or_tag_key", "minecraft:resource_selector":
+			var registry pk.Identifier
+			nn, err = registry.ReadFrom(tee)
+		}
+		*d.p = RawParser{ID: id, Properties: props.Bytes()}
+	}
+	return n + nn, err
+}
+
+// readRange reads the properties of the number argument types, see writeRange.
+// The bounds not sent are left unchanged.
+func readRange(r io.Reader, minVal, maxVal pk.FieldDecoder) (int64, error) {
+	var flags pk.Byte
+	return pk.Tuple{
+		&flags,
+		pk.Opt{Has: func() bool { return flags&0x01 != 0 }, Field: minVal},
+		pk.Opt{Has: func() bool { return flags&0x02 != 0 }, Field: maxVal},
+	}.ReadFrom(r)
+}
+
+// Commands returns the names of the commands the source can use.
+// The source can be nil for the Graph decoded by the client, which is already filtered by the server.
+func (g *Graph) Commands(src Source) (names []string) {
+	for _, i := range g.nodes[0].Children {
+		if n := g.nodes[i]; n.kind&0x03 == LiteralNode && n.canUse(src) {
+			names = append(names, n.Name)
+		}
+	}
+	return
+}
+
+// ParsedArgument is an argument of a command parsed by Graph.Parse.
+type ParsedArgument struct {
+	Name   string
+	Parser Parser
+	Value  ParsedData
+	// Raw is the input of the argument.
+	Raw string
+}
+
+// Parse parses the cmd as the source without running it, and returns its arguments in order.
+// The redirects are followed, but their modifiers aren't called.
+//
+// Unlike Execute, Parse tries all the argument nodes of the same parent,
+// so it validates the commands like "/tp <targets> <destination>" decoded from the vanilla servers.
+// It returns a SyntaxErr if the cmd isn't a complete command.
+func (g *Graph) Parse(src Source, cmd string) ([]ParsedArgument, error) {
+	return g.parse(src, cmd, g.nodes[0], cmd, nil)
+}
+
+// parse parses the rest of the cmd from the node, and backtracks if the rest can't be parsed from a child.
+func (g *Graph) parse(src Source, cmd string, node *Node, rest string, parsed []ParsedArgument) ([]ParsedArgument, error) {
+	left, value, err := node.parse(rest)
+	if err != nil {
+		return nil, syntaxErr(cmd, len(cmd)-len(rest), err)
+	}
+	if node.kind&0x03 == ArgumentNode {
+		parsed = append(slices.Clip(parsed), ParsedArgument{
+			Name:   node.Name,
+			Parser: node.Parser,
+			Value:  value,
+			Raw:    rest[:len(rest)-len(left)],
+		})
+	}
+	left = strings.TrimLeftFunc(left, unicode.IsSpace)
+	cursor := len(cmd) - len(left)
+	if len(strings.TrimSpace(left)) == 0 {
+		if node.Run == nil {
+			return nil, SyntaxErr{Input: cmd, Cursor: cursor, Msg: chat.TranslateMsg("command.unknown.command")}
+		}
+		return parsed, nil
+	}
+
+	from := node
+	if node.kind&hasRedirect != 0 {
+		if from = g.nodes[node.redirect]; !from.canUse(src) {
+			return nil, unknownErr(cmd, cursor, from)
+		}
+	}
+	// report the error at the furthest cursor
+	furthest := unknownErr(cmd, cursor, from)
+	for _, child := range g.candidates(src, from, left) {
+		result, err := g.parse(src, cmd, child, left, parsed)
+		if err == nil {
+			return result, nil
+		}
+		var syntax, prev SyntaxErr
+		if errors.As(err, &syntax) && errors.As(furthest, &prev) && syntax.Cursor >= prev.Cursor {
+			furthest = err
+		}
+	}
+	return nil, furthest
+}
+
+// candidates returns the children of the node the source can use, which may parse the left.
+// The literals take precedence over the arguments.
+func (g *Graph) candidates(src Source, node *Node, left string) (children []*Node) {
+	word, _ := readWord(left)
+	for _, i := range node.Children {
+		child := g.nodes[i]
+		if !child.canUse(src) {
+			continue
+		}
+		if child.kind&0x03 == LiteralNode && child.Name == word {
+			return []*Node{child}
+		}
+		if child.kind&0x03 == ArgumentNode {
+			children = append(children, child)
+		}
+	}
+	return
+}
+
+// Validate reports whether the cmd is a complete command the source can use, see Parse.
+func (g *Graph) Validate(src Source, cmd string) error {
+	_, err := g.Parse(src, cmd)
+	return err
+}
@@ -0,0 +1,237 @@
+package command
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"reflect"
+	"slices"
+	"testing"
+
+	"git.konjactw.dev/falloutBot/go-mc/chat/sign"
+	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
+)
+
+func TestGraph_ReadFrom(t *testing.T) {
+	server := NewGraph()
+	say := server.Literal("say").
+		AppendArgument(server.Argument("message", MessageParser{}).HandleFunc(unhandledCmd)).
+		HandleFunc(nil)
+	server.AppendLiteral(say).
+		AppendLiteral(server.Literal("me").Redirect(say, nil)).
+		AppendLiteral(server.Literal("scoreboard").
+			AppendArgument(server.Argument("holder", RawParser{ID: argumentType("minecraft:score_holder"), Properties: []byte{1}}).
+				AppendArgument(server.Argument("score", IntegerParser{Min: 0, Max: math.MaxInt32}).HandleFunc(unhandledCmd)).
+				HandleFunc(nil)).
+			HandleFunc(nil)).
+		AppendLiteral(server.Literal("summon").
+			AppendArgument(server.Argument("entity", ResourceParser{Registry: "minecraft:entity_type"}).
+				SuggestsType(SummonableEntities).
+				HandleFunc(unhandledCmd)).
+			HandleFunc(nil))
+
+	var encoded bytes.Buffer
+	if _, err := server.WriteTo(&encoded); err != nil {
+		t.Fatal(err)
+	}
+	var g Graph
+	if _, err := g.ReadFrom(bytes.NewReader(encoded.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+	var reencoded bytes.Buffer
+	if _, err := g.WriteTo(&reencoded); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(encoded.Bytes(), reencoded.Bytes()) {
+		t.Errorf("re-encoded graph differs:\n% x\n% x", encoded.Bytes(), reencoded.Bytes())
+	}
+
+	if want := []string{"say", "me", "scoreboard", "summon"}; !slices.Equal(g.Commands(nil), want) {
+		t.Errorf("commands: got %v, want %v", g.Commands(nil), want)
+	}
+	for _, n := range g.nodes {
+		switch n.Name {
+		case "holder":
+			if p, ok := n.Parser.(RawParser); !ok || p.Name() != "minecraft:score_holder" || !bytes.Equal(p.Properties, []byte{1}) {
+				t.Errorf("holder: %#v", n.Parser)
+			}
+		case "entity":
+			if n.SuggestionsType != SummonableEntities || n.Parser != (ResourceParser{Registry: "minecraft:entity_type"}) {
+				t.Errorf("entity: %#v %q", n.Parser, n.SuggestionsType)
+			}
+		case "score":
+			if n.Parser != (IntegerParser{Min: 0, Max: math.MaxInt32}) {
+				t.Errorf("score: %#v", n.Parser)
+			}
+		}
+	}
+}
+
+func TestGraph_Parse(t *testing.T) {
+	server := NewGraph()
+	targets := server.Argument("targets", EntityParser{}).
+		AppendArgument(server.Argument("destination", EntityParser{Single: true}).HandleFunc(unhandledCmd)).
+		HandleFunc(nil).
+		AppendArgument(server.Argument("location", Vec3Parser{}).HandleFunc(unhandledCmd))
+	teleport := server.Literal("teleport").
+		AppendArgument(server.Argument("destination", EntityParser{Single: true}).HandleFunc(unhandledCmd)).
+		HandleFunc(nil).
+		AppendArgument(targets)
+	server.AppendLiteral(teleport).
+		AppendLiteral(server.Literal("tp").Redirect(teleport, nil)).
+		AppendLiteral(server.Literal("say").
+			AppendArgument(server.Argument("message", MessageParser{}).HandleFunc(unhandledCmd)).
+			HandleFunc(nil)).
+		AppendLiteral(server.Literal("scoreboard").
+			AppendArgument(server.Argument("holder", RawParser{ID: argumentType("minecraft:score_holder"), Properties: []byte{1}}).
+				AppendArgument(server.Argument("score", IntegerParser{Min: 0, Max: math.MaxInt32}).HandleFunc(unhandledCmd)).
+				HandleFunc(nil)).
+			HandleFunc(nil))
+
+	var encoded bytes.Buffer
+	if _, err := server.WriteTo(&encoded); err != nil {
+		t.Fatal(err)
+	}
+	var g Graph
+	if err := (pk.Packet{Data: encoded.Bytes()}).Scan(&g); err != nil {
+		t.Fatal(err)
+	}
+
+	// the commands are parsed by the decoded parsers
+	for _, tc := range []struct {
+		cmd  string
+		want []string
+	}{
+		{"teleport Steve", []string{"destination=Steve"}},
+		{"tp @a Steve", []string{"targets=@a", "destination=Steve"}},
+		{"tp @a ~ ~1 ~", []string{"targets=@a", "location=~ ~1 ~"}},
+		{"say hello world", []string{"message=hello world"}},
+		{"scoreboard @s[tag=a b] 5", []string{"holder=@s[tag=a b]", "score=5"}},
+	} {
+		args, err := g.Parse(nil, tc.cmd)
+		if err != nil {
+			t.Errorf("parse %q: %v", tc.cmd, err)
+			continue
+		}
+		var got []string
+		for _, a := range args {
+			got = append(got, a.Name+"="+a.Raw)
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("parse %q: got %v, want %v", tc.cmd, got, tc.want)
+		}
+	}
+
+	for _, tc := range []struct {
+		cmd    string
+		cursor int
+	}{
+		{"kill", 0},
+		{"tp", 2},
+		{"tp @a 1 2", 9},
+		{"scoreboard @s -1", 14},
+	} {
+		var syntax SyntaxErr
+		if err := g.Validate(nil, tc.cmd); !errors.As(err, &syntax) || syntax.Cursor != tc.cursor {
+			t.Errorf("validate %q: %v", tc.cmd, err)
+		}
+	}
+}
+
+func TestGraph_ReadFrom_root(t *testing.T) {
+	// the root node is the second node
+	data := []byte{
+		2, // 2 nodes
+		LiteralNode | isExecutable, 0, 4, 'h', 'e', 'l', 'p',
+		RootNode, 1, 0,
+		1, // root index
+	}
+	var g Graph
+	if _, err := g.ReadFrom(bytes.NewReader(data)); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Validate(nil, "help"); err != nil {
+		t.Error(err)
+	}
+
+	var invalid Graph
+	if _, err := invalid.ReadFrom(bytes.NewReader([]byte{1, RootNode, 1, 5, 0})); err == nil {
+		t.Error("children out of range should fail")
+	}
+}
+
+func TestGraph_ReadFrom_invalidType(t *testing.T) {
+	data := []byte{
+		2, // 2 nodes
+		RootNode, 1, 1,
+		0x03, 0, // node type 3
+		0, // root index
+	}
+	var g Graph
+	if _, err := g.ReadFrom(bytes.NewReader(data)); err == nil {
+		t.Error("node type 3 should fail")
+	}
+}
+
+func TestGraph_ReadFrom_rootChild(t *testing.T) {
+	data := []byte{
+		2, // 2 nodes
+		RootNode, 1, 1,
+		LiteralNode | isExecutable, 1, 0, 4, 'l', 'o', 'o', 'p', // links back to the root
+		0, // root index
+	}
+	var g Graph
+	if _, err := g.ReadFrom(bytes.NewReader(data)); err == nil {
+		t.Error("the root node as a child should fail")
+	}
+}
+
+func TestGraph_ReadFrom_resourceSelector(t *testing.T) {
+	// the registry of the resource selector must be consumed before reading the next node
+	g := NewGraph()
+	selector := RawParser{ID: argumentType("minecraft:resource_selector"), Properties: []byte{4, 't', 'e', 's', 't'}}
+	g.AppendLiteral(g.Literal("test").
+		AppendArgument(g.Argument("tests", selector).HandleFunc(unhandledCmd)).
+		HandleFunc(nil),
+	).AppendLiteral(g.Literal("say").HandleFunc(unhandledCmd))
+	var encoded bytes.Buffer
+	if _, err := g.WriteTo(&encoded); err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded Graph
+	if _, err := decoded.ReadFrom(bytes.NewReader(encoded.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"test", "say"}; !slices.Equal(decoded.Commands(nil), want) {
+		t.Errorf("commands: got %v, want %v", decoded.Commands(nil), want)
+	}
+	for _, n := range decoded.nodes {
+		if n.Name == "tests" && !reflect.DeepEqual(n.Parser, selector) {
+			t.Errorf("tests: %#v", n.Parser)
+		}
+	}
+}
+
+func TestGraph_SignableArguments(t *testing.T) {
+	g := NewGraph()
+	g.AppendLiteral(g.Literal("tp").
+		AppendArgument(g.Argument("targets", EntityParser{}).
+			AppendArgument(g.Argument("destination", EntityParser{Single: true}).HandleFunc(unhandledCmd)).
+			HandleFunc(nil)).
+		HandleFunc(nil),
+	).AppendLiteral(g.Literal("say").
+		AppendArgument(g.Argument("message", MessageParser{}).HandleFunc(unhandledCmd)).
+		HandleFunc(nil),
+	)
+	args, err := g.SignableArguments(nil, "say hello  world")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []sign.Argument{{Name: "message", Value: "hello  world"}}; !reflect.DeepEqual(args, want) {
+		t.Errorf("got %v, want %v", args, want)
+	}
+	if args, err := g.SignableArguments(nil, "tp @a Steve"); err != nil || len(args) != 0 {
+		t.Errorf("got %v, %v", args, err)
+	}
+}
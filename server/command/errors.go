@@ -0,0 +1,88 @@
+package command
+
+import (
+	"errors"
+	"strconv"
+
+	"git.konjactw.dev/falloutBot/go-mc/chat"
+)
+
+// SyntaxErr is the error of parsing the Input at the Cursor, like the CommandSyntaxException of Brigadier.
+type SyntaxErr struct {
+	Input string
+	// Cursor is the byte offset of the error in the Input.
+	Cursor int
+	Msg    chat.Message
+}
+
+func (e SyntaxErr) Error() string {
+	return e.Msg.ClearString() + " at position " + strconv.Itoa(e.Cursor) + ": " + e.Context()
+}
+
+// Context returns at most 10 characters of the Input before the Cursor, followed by "<--[HERE]".
+func (e SyntaxErr) Context() string {
+	before, _ := e.split()
+	return before + "<--[HERE]"
+}
+
+// split returns the Input before the Cursor, shortened to 10 characters, and the rest.
+func (e SyntaxErr) split() (before, after string) {
+	cursor := min(max(e.Cursor, 0), len(e.Input))
+	runes := []rune(e.Input[:cursor])
+	if len(runes) > 10 {
+		return "..." + string(runes[len(runes)-10:]), e.Input[cursor:]
+	}
+	return string(runes), e.Input[cursor:]
+}
+
+// Message renders the error as the vanilla server does:
+// the red error message, and a gray line of the Context,
+// in which the rest of the Input is red underlined and clicking it suggests the command.
+func (e SyntaxErr) Message() chat.Message {
+	before, after := e.split()
+	line := chat.Message{
+		Color:      chat.Gray,
+		ClickEvent: chat.SuggestCommand("/" + e.Input),
+		Extra:      []chat.Message{chat.Text(before)},
+	}
+	if after != "" {
+		line.Extra = append(line.Extra, chat.Message{Text: after, Color: chat.Red, UnderLined: true})
+	}
+	here := chat.TranslateMsg("command.context.here")
+	here.Color, here.Italic = chat.Red, true
+	line.Extra = append(line.Extra, here)
+	return chat.Text("").Append(e.Msg.SetColor(chat.Red), chat.Text("\n"), line)
+}
+
+// CommandErr is the error of a command failing without the position, like "/help" of an unknown command.
+type CommandErr struct {
+	Msg chat.Message
+}
+
+func (e CommandErr) Error() string {
+	return e.Msg.ClearString()
+}
+
+// ErrorMessage renders the error of Graph.Execute to be sent to the source.
+// A SyntaxErr is rendered by its Message, a CommandErr is its Msg in red, and other errors are in red text.
+func ErrorMessage(err error) chat.Message {
+	var syntax SyntaxErr
+	if errors.As(err, &syntax) {
+		return syntax.Message()
+	}
+	var command CommandErr
+	if errors.As(err, &command) {
+		return command.Msg.SetColor(chat.Red)
+	}
+	return chat.Text(err.Error()).SetColor(chat.Red)
+}
+
+// syntaxErr converts the error of parsing the input at the cursor to a SyntaxErr.
+// The position of a ParseErr is relative to the cursor.
+func syntaxErr(input string, cursor int, err error) error {
+	var parseErr ParseErr
+	if errors.As(err, &parseErr) {
+		return SyntaxErr{Input: input, Cursor: cursor + parseErr.Pos, Msg: chat.Text(parseErr.Err)}
+	}
+	return SyntaxErr{Input: input, Cursor: cursor, Msg: chat.Text(err.Error())}
+}
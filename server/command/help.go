@@ -0,0 +1,220 @@
+package command
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"git.konjactw.dev/falloutBot/go-mc/chat"
+)
+
+// HelpPageSize is the number of the usages in a page of Graph.Help.
+const HelpPageSize = 8
+
+// Usage is the usage of a command, see Graph.SmartUsage.
+type Usage struct {
+	// Command is the literal part of the usage, which is suggested to the player clicking the usage.
+	Command string
+	// Text is the usage of the command, such as "give <targets> <item> [<count>]".
+	Text string
+	// Description is the Description of the first node of the usage.
+	Description string
+}
+
+// SmartUsage returns the usages of the commands after the cmd the source can use, like the getSmartUsage of Brigadier.
+// The cmd is the command typed so far, "" for all the commands and "team" for the subcommands of "/team".
+//
+// Each child of the cmd's last node has a usage, in which the arguments are in angle brackets,
+// the optional parts are in square brackets, and the alternatives are separated by '|' in parentheses.
+// For example, "give <targets> <item> [<count>]" and "time (add|query|set)".
+func (g *Graph) SmartUsage(src Source, cmd string) ([]Usage, error) {
+	cmd = strings.TrimSpace(cmd)
+	node, err := g.find(src, cmd, g.nodes[0], cmd)
+	if err != nil {
+		return nil, err
+	}
+	optional := node.Run != nil
+	if node.kind&hasRedirect != 0 {
+		node = g.nodes[node.redirect]
+	}
+	var usages []Usage
+	for _, i := range node.Children {
+		child := g.nodes[i]
+		text, ok := g.smartUsage(src, child, optional, false, map[*Node]bool{node: true})
+		if !ok {
+			continue
+		}
+		usage := Usage{Command: cmd, Text: text, Description: child.Description}
+		if child.kind&0x03 == LiteralNode {
+			usage.Command = strings.TrimSpace(cmd + " " + child.Name)
+		}
+		if cmd != "" {
+			usage.Text = cmd + " " + text
+		}
+		usages = append(usages, usage)
+	}
+	return usages, nil
+}
+
+// find returns the last node of the rest of the cmd, which doesn't need to be a complete command, see Graph.parse.
+func (g *Graph) find(src Source, cmd string, node *Node, rest string) (*Node, error) {
+	left, _, err := node.parse(rest)
+	if err != nil {
+		return nil, syntaxErr(cmd, len(cmd)-len(rest), err)
+	}
+	left = strings.TrimLeftFunc(left, unicode.IsSpace)
+	if len(strings.TrimSpace(left)) == 0 {
+		return node, nil
+	}
+	cursor := len(cmd) - len(left)
+	from := node
+	if node.kind&hasRedirect != 0 {
+		if from = g.nodes[node.redirect]; !from.canUse(src) {
+			return nil, unknownErr(cmd, cursor, from)
+		}
+	}
+	for _, child := range g.candidates(src, from, left) {
+		if found, err := g.find(src, cmd, child, left); err == nil {
+			return found, nil
+		}
+	}
+	return nil, unknownErr(cmd, cursor, from)
+}
+
+// smartUsage returns the usage of the node and its children.
+// The children of the node are listed as alternatives if deep is false, otherwise the usage is only the node itself.
+// The path is the nodes whose usages contain this one, and a child already in it is rendered like a redirect,
+// so a cycle of the nodes doesn't recurse forever.
+func (g *Graph) smartUsage(src Source, node *Node, optional, deep bool, path map[*Node]bool) (string, bool) {
+	if !node.canUse(src) {
+		return "", false
+	}
+	self := node.usageText()
+	if optional {
+		self = "[" + self + "]"
+	}
+	if deep {
+		return self, true
+	}
+	if node.kind&hasRedirect != 0 {
+		if node.redirect == 0 {
+			return self + " ...", true
+		}
+		return self + " -> " + g.nodes[node.redirect].usageText(), true
+	}
+
+	// The children are optional if the node is executable.
+	childOptional := node.Run != nil
+	open, closing := "(", ")"
+	if childOptional {
+		open, closing = "[", "]"
+	}
+	var children []*Node
+	for _, i := range node.Children {
+		if child := g.nodes[i]; child.canUse(src) {
+			children = append(children, child)
+		}
+	}
+	if len(children) == 1 {
+		child := children[0]
+		if path[child] {
+			return self + " -> " + child.usageText(), true
+		}
+		path[node] = true
+		usage, _ := g.smartUsage(src, child, childOptional, childOptional, path)
+		return self + " " + usage, true
+	}
+	var alternatives []string
+	for _, child := range children {
+		if text := child.usageText(); !slices.Contains(alternatives, text) {
+			alternatives = append(alternatives, text)
+		}
+	}
+	switch len(alternatives) {
+	case 0:
+		return self, true
+	case 1:
+		if childOptional {
+			return self + " [" + alternatives[0] + "]", true
+		}
+		return self + " " + alternatives[0], true
+	default:
+		return self + " " + open + strings.Join(alternatives, "|") + closing, true
+	}
+}
+
+// usageText is the usage of the node itself, the name of a literal or "<name>" of an argument.
+func (n *Node) usageText() string {
+	if n.kind&0x03 == ArgumentNode {
+		return "<" + n.Name + ">"
+	}
+	return n.Name
+}
+
+// Help renders the page of the SmartUsage of the cmd for the source, which starts from 1.
+// Clicking a usage suggests its command in the chat box, and hovering shows its description.
+//
+// It returns a CommandErr if the source can't use the cmd, or the page is out of range.
+func (g *Graph) Help(src Source, cmd string, page int) (chat.Message, error) {
+	cmd = strings.TrimSpace(cmd)
+	usages, err := g.SmartUsage(src, cmd)
+	if err != nil {
+		return chat.Message{}, CommandErr{Msg: chat.TranslateMsg("commands.help.failed")}
+	}
+	if len(usages) == 0 {
+		if cmd == "" {
+			return chat.Message{}, CommandErr{Msg: chat.TranslateMsg("commands.help.failed")}
+		}
+		// an executable command without arguments
+		usages = []Usage{{Command: cmd, Text: cmd}}
+	}
+	pages := (len(usages) + HelpPageSize - 1) / HelpPageSize
+	if page < 1 || page > pages {
+		return chat.Message{}, CommandErr{Msg: chat.Text(fmt.Sprintf("Page %d is out of range [1, %d]", page, pages))}
+	}
+
+	title := "Help"
+	if cmd != "" {
+		title += ": /" + cmd
+	}
+	msg := chat.Text(fmt.Sprintf("--- %s (%d/%d) ---", title, page, pages)).SetColor(chat.Gold)
+	for _, usage := range usages[(page-1)*HelpPageSize : min(page*HelpPageSize, len(usages))] {
+		line := chat.Message{
+			Text:       "/" + usage.Text,
+			ClickEvent: chat.SuggestCommand("/" + usage.Command + " "),
+		}
+		if usage.Description != "" {
+			line.HoverEvent = chat.ShowText(chat.Text(usage.Description))
+			line.Extra = []chat.Message{chat.Text(" - " + usage.Description).SetColor(chat.Gray)}
+		}
+		msg = msg.Append(chat.Text("\n"), line)
+	}
+	return msg, nil
+}
+
+// HelpCommand returns the "help" literal rendering the Help of the Graph for the source,
+// which should be appended to the Graph.
+// Its argument is a page number or a command, such as "/help 2" and "/help team".
+func (g *Graph) HelpCommand() *Literal {
+	return g.Literal("help").
+		Describes("Shows the usages of the commands").
+		AppendArgument(g.Argument("command", StringParser(2)).
+			Describes("A page number or a command").
+			HandleFunc(Handle(g.help))).
+		HandleFunc(Handle(g.help))
+}
+
+func (g *Graph) help(c *Context) error {
+	cmd, page := ArgOr(c, "command", ""), 1
+	if n, err := strconv.Atoi(cmd); err == nil {
+		cmd, page = "", n
+	}
+	msg, err := g.Help(c.Source, strings.TrimPrefix(cmd, "/"), page)
+	if err != nil {
+		return err
+	}
+	c.SendFeedback(msg)
+	return nil
+}
@@ -0,0 +1,120 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestGraph_SmartUsage(t *testing.T) {
+	g := NewGraph()
+	targets := g.Argument("targets", EntityParser{}).
+		AppendArgument(g.Argument("destination", EntityParser{Single: true}).HandleFunc(unhandledCmd)).
+		HandleFunc(nil).
+		AppendArgument(g.Argument("location", Vec3Parser{}).HandleFunc(unhandledCmd))
+	teleport := g.Literal("teleport").
+		AppendArgument(g.Argument("destination", EntityParser{Single: true}).HandleFunc(unhandledCmd)).
+		HandleFunc(nil).
+		AppendArgument(targets)
+	g.AppendLiteral(teleport).
+		AppendLiteral(g.Literal("tp").Redirect(teleport, nil))
+	Register[giveCommand](g, "give")
+	Register[teamCommand](g, "team add")
+	Register[teamCommand](g, "team remove")
+	g.AppendLiteral(g.Literal("time").
+		AppendLiteral(g.Literal("set").AppendArgument(g.Argument("time", TimeParser{}).HandleFunc(unhandledCmd)).HandleFunc(nil)).
+		AppendLiteral(g.Literal("query").Unhandle()).
+		Unhandle())
+
+	usages := func(src Source, cmd string) (texts []string) {
+		u, err := g.SmartUsage(src, cmd)
+		if err != nil {
+			t.Fatalf("usage of %q: %v", cmd, err)
+		}
+		for _, usage := range u {
+			texts = append(texts, usage.Text)
+		}
+		return
+	}
+	want := []string{
+		"teleport (<destination>|<targets>)",
+		"tp -> teleport",
+		"give <targets> <item> [<count>]",
+		"team (add|remove)",
+		"time [set|query]",
+	}
+	if got := usages(PermissionLevel(2), ""); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	// give requires level 2
+	if got := usages(PermissionLevel(0), ""); slices.Contains(got, want[2]) {
+		t.Errorf("give shouldn't be listed: %q", got)
+	}
+	if got, want := usages(nil, "team"), []string{"team add <team>", "team remove <team>"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := usages(nil, "tp @a"), []string{"tp @a <destination>", "tp @a <location>"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if _, err := g.SmartUsage(PermissionLevel(0), "give"); err == nil {
+		t.Error("give shouldn't be found for level 0")
+	}
+}
+
+func TestGraph_SmartUsage_cycle(t *testing.T) {
+	g := NewGraph()
+	// neither of them is executable, so their single children are always expanded
+	a := g.Literal("a").HandleFunc(nil)
+	b := g.Literal("b").HandleFunc(nil)
+	a.AppendLiteral(b)
+	b.AppendLiteral(a)
+	g.AppendLiteral(g.Literal("loop").AppendLiteral(a).HandleFunc(nil))
+
+	usages, err := g.SmartUsage(nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(usages) != 1 || usages[0].Text != "loop a b -> a" {
+		t.Errorf("got %+v", usages)
+	}
+}
+
+func TestGraph_Help(t *testing.T) {
+	g := NewGraph()
+	for i := range HelpPageSize + 2 {
+		g.AppendLiteral(g.Literal("cmd" + strconv.Itoa(i)).Describes("command " + strconv.Itoa(i)).Unhandle())
+	}
+	g.AppendLiteral(g.HelpCommand())
+
+	p := &testPlayer{level: 4}
+	if err := g.Execute(context.TODO(), p, "help 2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Execute(context.TODO(), p, "help cmd3"); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.messages) != 2 {
+		t.Fatalf("messages: %v", p.messages)
+	}
+	want := "--- Help (2/2) ---\n/cmd8 - command 8\n/cmd9 - command 9\n/help [<command>] - Shows the usages of the commands"
+	if got := p.messages[0].ClearString(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if last := p.messages[0].Extra[len(p.messages[0].Extra)-1]; last.ClickEvent == nil || last.ClickEvent.Value != "/help " {
+		t.Errorf("click event: %+v", last.ClickEvent)
+	}
+	if got, want := p.messages[1].ClearString(), "--- Help: /cmd3 (1/1) ---\n/cmd3"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	var command CommandErr
+	if err := g.Execute(context.TODO(), p, "help unknown"); !errors.As(err, &command) || err.Error() != "Unknown command or insufficient permissions" {
+		t.Errorf("help unknown: %v", err)
+	}
+	if err := g.Execute(context.TODO(), p, "help 3"); !errors.As(err, &command) {
+		t.Errorf("help 3: %v", err)
+	}
+}
@@ -1,22 +1,40 @@
 package command
 
 import (
+	"cmp"
+	"errors"
+	"fmt"
 	"io"
+	"math"
+	"slices"
 	"strconv"
 	"strings"
 
+	"git.konjactw.dev/falloutBot/go-mc/data/registryid"
 	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
 )
 
+// Parser parses an argument of the command.
+// The parsers are also sent to the clients in the Commands packet by WriteTo,
+// which writes the ID of the argument type in registryid.CommandArgumentType, and its properties.
 type Parser interface {
 	Parse(cmd string) (left string, value ParsedData, err error)
 }
 
+// argumentType returns the ID of the argument type in the registry.
+func argumentType(name string) pk.VarInt {
+	id := slices.Index(registryid.CommandArgumentType, name)
+	if id == -1 {
+		panic("command: unknown argument type " + name)
+	}
+	return pk.VarInt(id)
+}
+
 type StringParser int32
 
 func (s StringParser) WriteTo(w io.Writer) (int64, error) {
 	return pk.Tuple{
-		pk.Identifier("brigadier:string"),
+		argumentType("brigadier:string"),
 		pk.VarInt(s),
 	}.WriteTo(w)
 }
@@ -41,7 +59,7 @@ func (s StringParser) Parse(cmd string) (left string, value ParsedData, err erro
 				} else if v == '\\' {
 					isEscaping = true
 				} else if v == '"' {
-					return cmd[:i], sb.String(), nil
+					return cmd[i+2:], sb.String(), nil
 				} else {
 					sb.WriteRune(v)
 				}
@@ -63,6 +81,180 @@ func (s StringParser) Parse(cmd string) (left string, value ParsedData, err erro
 	}
 }
 
+// BoolParser parses "true" or "false" as a bool.
+type BoolParser struct{}
+
+func (BoolParser) WriteTo(w io.Writer) (int64, error) {
+	return argumentType("brigadier:bool").WriteTo(w)
+}
+
+func (BoolParser) Parse(cmd string) (left string, value ParsedData, err error) {
+	word, left := readWord(cmd)
+	switch word {
+	case "true":
+		return left, true, nil
+	case "false":
+		return left, false, nil
+	default:
+		return cmd, nil, ParseErr{Pos: 0, Err: "invalid boolean, expected true or false but found '" + word + "'"}
+	}
+}
+
+func (BoolParser) Suggest(remaining string) []string {
+	return filterPrefix([]string{"true", "false"}, remaining)
+}
+
+// IntegerParser parses an int32 in the range [Min, Max].
+// Use math.MinInt32 and math.MaxInt32 for no bounds, see NewIntegerParser.
+// Note that the zero value only accepts 0.
+type IntegerParser struct {
+	Min, Max int32
+}
+
+// NewIntegerParser returns an IntegerParser without bounds.
+func NewIntegerParser() IntegerParser {
+	return IntegerParser{Min: math.MinInt32, Max: math.MaxInt32}
+}
+
+func (p IntegerParser) WriteTo(w io.Writer) (int64, error) {
+	return writeRange(w, "brigadier:integer", p.Min, p.Max, math.MinInt32, math.MaxInt32, func(v int32) pk.FieldEncoder { return pk.Int(v) })
+}
+
+func (p IntegerParser) Parse(cmd string) (left string, value ParsedData, err error) {
+	return parseNumber(cmd, "integer", p.Min, p.Max, func(s string) (int32, error) {
+		v, err := strconv.ParseInt(s, 10, 32)
+		return int32(v), err
+	})
+}
+
+// LongParser parses an int64 in the range [Min, Max].
+// Use math.MinInt64 and math.MaxInt64 for no bounds, see NewLongParser.
+// Note that the zero value only accepts 0.
+type LongParser struct {
+	Min, Max int64
+}
+
+// NewLongParser returns a LongParser without bounds.
+func NewLongParser() LongParser {
+	return LongParser{Min: math.MinInt64, Max: math.MaxInt64}
+}
+
+func (p LongParser) WriteTo(w io.Writer) (int64, error) {
+	return writeRange(w, "brigadier:long", p.Min, p.Max, math.MinInt64, math.MaxInt64, func(v int64) pk.FieldEncoder { return pk.Long(v) })
+}
+
+func (p LongParser) Parse(cmd string) (left string, value ParsedData, err error) {
+	return parseNumber(cmd, "long", p.Min, p.Max, func(s string) (int64, error) {
+		return strconv.ParseInt(s, 10, 64)
+	})
+}
+
+// FloatParser parses a float32 in the range [Min, Max].
+// Use -math.MaxFloat32 and math.MaxFloat32 for no bounds, see NewFloatParser.
+// Note that the zero value only accepts 0.
+type FloatParser struct {
+	Min, Max float32
+}
+
+// NewFloatParser returns a FloatParser without bounds.
+func NewFloatParser() FloatParser {
+	return FloatParser{Min: -math.MaxFloat32, Max: math.MaxFloat32}
+}
+
+func (p FloatParser) WriteTo(w io.Writer) (int64, error) {
+	return writeRange(w, "brigadier:float", p.Min, p.Max, -math.MaxFloat32, math.MaxFloat32, func(v float32) pk.FieldEncoder { return pk.Float(v) })
+}
+
+func (p FloatParser) Parse(cmd string) (left string, value ParsedData, err error) {
+	return parseNumber(cmd, "float", p.Min, p.Max, func(s string) (float32, error) {
+		v, err := parseFloat(s, 32)
+		return float32(v), err
+	})
+}
+
+// DoubleParser parses a float64 in the range [Min, Max].
+// Use -math.MaxFloat64 and math.MaxFloat64 for no bounds, see NewDoubleParser.
+// Note that the zero value only accepts 0.
+type DoubleParser struct {
+	Min, Max float64
+}
+
+// NewDoubleParser returns a DoubleParser without bounds.
+func NewDoubleParser() DoubleParser {
+	return DoubleParser{Min: -math.MaxFloat64, Max: math.MaxFloat64}
+}
+
+func (p DoubleParser) WriteTo(w io.Writer) (int64, error) {
+	return writeRange(w, "brigadier:double", p.Min, p.Max, -math.MaxFloat64, math.MaxFloat64, func(v float64) pk.FieldEncoder { return pk.Double(v) })
+}
+
+func (p DoubleParser) Parse(cmd string) (left string, value ParsedData, err error) {
+	return parseNumber(cmd, "double", p.Min, p.Max, func(s string) (float64, error) {
+		return parseFloat(s, 64)
+	})
+}
+
+// parseFloat is strconv.ParseFloat rejecting NaN and infinities,
+// whose input is already limited to the number characters by parseNumber.
+func parseFloat(s string, bitSize int) (float64, error) {
+	v, err := strconv.ParseFloat(s, bitSize)
+	if err == nil && (math.IsNaN(v) || math.IsInf(v, 0)) {
+		return 0, errors.New("not a finite number")
+	}
+	return v, err
+}
+
+// writeRange writes the properties of the number argument types,
+// a flag byte and the bounds which aren't the default.
+func writeRange[T cmp.Ordered](w io.Writer, name string, minVal, maxVal, lowest, highest T, encode func(T) pk.FieldEncoder) (int64, error) {
+	var flags byte
+	fields := pk.Tuple{argumentType(name), nil}
+	if minVal != lowest {
+		flags |= 0x01
+		fields = append(fields, encode(minVal))
+	}
+	if maxVal != highest {
+		flags |= 0x02
+		fields = append(fields, encode(maxVal))
+	}
+	fields[1] = pk.Byte(flags)
+	return fields.WriteTo(w)
+}
+
+func parseNumber[T cmp.Ordered](cmd, typ string, minVal, maxVal T, parse func(string) (T, error)) (left string, value ParsedData, err error) {
+	word, left := readWord(cmd)
+	if word == "" {
+		return cmd, nil, ParseErr{Pos: 0, Err: "expected " + typ}
+	}
+	// Only the characters Brigadier reads as a number are allowed,
+	// which excludes the NaN, Inf, hex and underscores accepted by strconv.
+	if strings.Trim(word, "0123456789.-") != "" {
+		return cmd, nil, ParseErr{Pos: 0, Err: "invalid " + typ + " '" + word + "'"}
+	}
+	v, err := parse(word)
+	if err != nil {
+		return cmd, nil, ParseErr{Pos: 0, Err: "invalid " + typ + " '" + word + "'"}
+	}
+	if v < minVal {
+		return cmd, nil, ParseErr{Pos: 0, Err: fmt.Sprintf("%s must not be less than %v, found %v", typ, minVal, v)}
+	}
+	if v > maxVal {
+		return cmd, nil, ParseErr{Pos: 0, Err: fmt.Sprintf("%s must not be more than %v, found %v", typ, maxVal, v)}
+	}
+	return left, v, nil
+}
+
+// readWord splits the cmd at the first whitespace.
+func readWord(cmd string) (word, left string) {
+	i := strings.IndexAny(cmd, "\t\n\v\f\r ")
+	if i == -1 {
+		return cmd, ""
+	}
+	return cmd[:i], cmd[i:]
+}
+
+// ParseErr is the error of a Parser, at the Pos of the input of the argument.
+// It's converted to a SyntaxErr by Graph.Execute.
 type ParseErr struct {
 	Pos int
 	Err string
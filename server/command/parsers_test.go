@@ -0,0 +1,149 @@
+package command
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"git.konjactw.dev/falloutBot/go-mc/chat"
+	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
+)
+
+func TestParsers_Parse(t *testing.T) {
+	for _, tc := range []struct {
+		parser Parser
+		cmd    string
+		left   string
+		value  ParsedData
+	}{
+		{StringParser(0), "word left", " left", "word"},
+		{StringParser(1), `"quoted \"phrase\"" left`, " left", `quoted "phrase"`},
+		{BoolParser{}, "true", "", true},
+		{IntegerParser{Min: 0, Max: 10}, "7 left", " left", int32(7)},
+		{NewLongParser(), "-9000000000", "", int64(-9000000000)},
+		{NewFloatParser(), "1.5", "", float32(1.5)},
+		{NewDoubleParser(), "-.5", "", -0.5},
+		{DoubleParser{Min: 0, Max: 1}, "0.25", "", 0.25},
+		{EntityParser{}, "@e[type=cow,name=\"a b\",nbt={a:[1]}] left", " left", EntitySelector{
+			Selector:  'e',
+			Arguments: []SelectorArgument{{"type", "cow"}, {"name", `"a b"`}, {"nbt", "{a:[1]}"}},
+		}},
+		{EntityParser{Single: true, PlayersOnly: true}, "Notch", "", EntitySelector{Name: "Notch"}},
+		{EntityParser{Single: true}, "@e[limit=1]", "", EntitySelector{Selector: 'e', Arguments: []SelectorArgument{{"limit", "1"}}}},
+		{EntityParser{Single: true}, "@r", "", EntitySelector{Selector: 'r'}},
+		{GameProfileParser{}, "853c80ef-3c37-49fd-aa49-938b674adae6", "", EntitySelector{UUID: uuid.MustParse("853c80ef-3c37-49fd-aa49-938b674adae6")}},
+		{BlockPosParser{}, "1 ~ ~-2 left", " left", BlockPos{Coord{Value: 1}, Coord{Relative: true}, Coord{Value: -2, Relative: true}}},
+		{Vec3Parser{}, "^ ^1 ^0.5", "", Vec3{Coord{Local: true}, Coord{Value: 1, Local: true}, Coord{Value: 0.5, Local: true}}},
+		{ColumnPosParser{}, "3 -4", "", ColumnPos{Coord{Value: 3}, Coord{Value: -4}}},
+		{RotationParser{}, "~90 45.5", "", Rotation{Coord{Value: 90, Relative: true}, Coord{Value: 45.5}}},
+		{ResourceLocationParser{}, "stone left", " left", ResourceLocation("minecraft:stone")},
+		{ResourceParser{Registry: "entity_type"}, "mod:thing", "", Resource{Registry: "minecraft:entity_type", ID: "mod:thing"}},
+		{ResourceKeyParser{Registry: "minecraft:worldgen/biome"}, "plains", "", Resource{Registry: "minecraft:worldgen/biome", ID: "minecraft:plains"}},
+		{BlockStateParser{}, "chest[facing=north,waterlogged=false]{Items:[]} left", " left", BlockState{
+			Name:       "minecraft:chest",
+			Properties: map[string]string{"facing": "north", "waterlogged": "false"},
+			NBT:        "{Items:[]}",
+		}},
+		{ItemStackParser{}, "diamond_sword[damage=5]", "", ItemStack{Name: "minecraft:diamond_sword", Components: "damage=5"}},
+		{MessageParser{}, "hello @a", "", "hello @a"},
+		{TimeParser{}, "1.5d left", " left", int32(36000)},
+		{TimeParser{}, "3s", "", int32(60)},
+		{UUIDParser{}, "853c80ef-3c37-49fd-aa49-938b674adae6", "", uuid.MustParse("853c80ef-3c37-49fd-aa49-938b674adae6")},
+		{GameModeParser{}, "creative", "", Creative},
+		{DimensionParser{}, "the_nether", "", ResourceLocation("minecraft:the_nether")},
+		{ColorParser{}, "dark_red", "", "dark_red"},
+		{ObjectiveParser{}, "kills left", " left", "kills"},
+		{SwizzleParser{}, "xz", "", Swizzle{X: true, Z: true}},
+	} {
+		left, value, err := tc.parser.Parse(tc.cmd)
+		if err != nil {
+			t.Errorf("%T: parse %q: %v", tc.parser, tc.cmd, err)
+			continue
+		}
+		if left != tc.left || !reflect.DeepEqual(value, tc.value) {
+			t.Errorf("%T: parse %q: got (%q, %#v), want (%q, %#v)", tc.parser, tc.cmd, left, value, tc.left, tc.value)
+		}
+	}
+}
+
+func TestParsers_ParseErr(t *testing.T) {
+	for _, tc := range []struct {
+		parser Parser
+		cmd    string
+	}{
+		{BoolParser{}, "yes"},
+		{IntegerParser{Min: 0, Max: 10}, "11"},
+		{IntegerParser{Min: math.MinInt32, Max: math.MaxInt32}, "1.5"},
+		{NewIntegerParser(), "1_000"},
+		{NewIntegerParser(), "+1"},
+		{NewFloatParser(), "NaN"},
+		{NewDoubleParser(), "NaN"},
+		{NewDoubleParser(), "-Inf"},
+		{NewDoubleParser(), "0x1p3"},
+		{NewDoubleParser(), "1e400"},
+		{EntityParser{Single: true}, "@a"},
+		{EntityParser{Single: true}, "@r[limit=2]"},
+		{EntityParser{Single: true}, "@p[limit=5]"},
+		{EntityParser{Single: true}, "@s[limit=0]"},
+		{EntityParser{PlayersOnly: true}, "@e"},
+		{EntityParser{}, "@x"},
+		{EntityParser{}, "@e[type=cow"},
+		{BlockPosParser{}, "1 2"},
+		{BlockPosParser{}, "1.5 2 3"},
+		{Vec3Parser{}, "^ 1 2"},
+		{RotationParser{}, "^ ^"},
+		{ResourceLocationParser{}, "a:b:c"},
+		{ResourceLocationParser{}, "minecraft:"},
+		{ResourceLocationParser{}, ":"},
+		{TimeParser{Min: 1}, "0"},
+		{UUIDParser{}, "853c80ef3c3749fdaa49938b674adae6"},
+		{GameModeParser{}, "hardcore"},
+		{SwizzleParser{}, "xx"},
+	} {
+		if _, _, err := tc.parser.Parse(tc.cmd); err == nil {
+			t.Errorf("%T: parse %q should fail", tc.parser, tc.cmd)
+		}
+	}
+}
+
+func TestComponentParser(t *testing.T) {
+	for _, cmd := range []string{`{"text":"hello","bold":true} left`, `{text:"hello",bold:1b} left`} {
+		left, value, err := ComponentParser{}.Parse(cmd)
+		if err != nil {
+			t.Fatalf("parse %q: %v", cmd, err)
+		}
+		if left != " left" {
+			t.Errorf("parse %q: left %q", cmd, left)
+		}
+		if msg := value.(chat.Message); msg.ClearString() != "hello" || !msg.Bold {
+			t.Errorf("parse %q: got %v", cmd, value)
+		}
+	}
+}
+
+func TestParsers_WriteTo(t *testing.T) {
+	for _, tc := range []struct {
+		parser pk.FieldEncoder
+		want   []byte
+	}{
+		{StringParser(2), []byte{5, 2}},
+		{BoolParser{}, []byte{0}},
+		{IntegerParser{Min: math.MinInt32, Max: math.MaxInt32}, []byte{3, 0}},
+		{IntegerParser{Min: 0, Max: math.MaxInt32}, []byte{3, 1, 0, 0, 0, 0}},
+		{IntegerParser{Min: 0, Max: 1}, []byte{3, 3, 0, 0, 0, 0, 0, 0, 0, 1}},
+		{EntityParser{Single: true, PlayersOnly: true}, []byte{6, 3}},
+		{TimeParser{Min: 1}, []byte{43, 0, 0, 0, 1}},
+		{ResourceParser{Registry: "a"}, []byte{46, 1, 'a'}},
+	} {
+		var buf bytes.Buffer
+		if _, err := tc.parser.WriteTo(&buf); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf.Bytes(), tc.want) {
+			t.Errorf("%T: got % x, want % x", tc.parser, buf.Bytes(), tc.want)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package command
+
+import (
+	"io"
+
+	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
+)
+
+// Source is who runs the commands, such as a player or the console.
+type Source interface {
+	// PermissionLevel returns the level from 0 to 4 of the source.
+	// It's usually the level in ops.json for the players, and 4 for the console.
+	PermissionLevel() int
+}
+
+// PermissionLevel is a Source only having a permission level.
+type PermissionLevel int
+
+func (p PermissionLevel) PermissionLevel() int { return int(p) }
+
+// Requirement reports whether the source can use a node.
+// The nodes the source can't use are hidden from it, and can't be executed by it.
+type Requirement func(src Source) bool
+
+// RequiresLevel returns a Requirement allowing the sources with at least the permission level.
+func RequiresLevel(level int) Requirement {
+	return func(src Source) bool {
+		return src != nil && src.PermissionLevel() >= level
+	}
+}
+
+// canUse reports whether the node's requirement allows the source.
+func (n *Node) canUse(src Source) bool {
+	return n.requires == nil || n.requires(src)
+}
+
+// filteredGraph is the Graph with only the nodes usable by the source,
+// which is sent to the client in the Commands packet.
+type filteredGraph struct {
+	g   *Graph
+	src Source
+}
+
+func (f filteredGraph) WriteTo(w io.Writer) (int64, error) {
+	// breadth-first search the usable nodes, and give them new indices
+	indices := map[int32]int32{0: 0}
+	queue := []int32{0}
+	visit := func(i int32) {
+		if _, visited := indices[i]; !visited && f.g.nodes[i].canUse(f.src) {
+			indices[i] = int32(len(indices))
+			queue = append(queue, i)
+		}
+	}
+	for j := 0; j < len(queue); j++ {
+		n := f.g.nodes[queue[j]]
+		for _, child := range n.Children {
+			visit(child)
+		}
+		if n.kind&hasRedirect != 0 {
+			visit(n.redirect)
+		}
+	}
+
+	nodes := make([]Node, len(queue))
+	for j, i := range queue {
+		n := *f.g.nodes[i]
+		n.Children = nil
+		for _, child := range f.g.nodes[i].Children {
+			if index, ok := indices[child]; ok {
+				n.Children = append(n.Children, index)
+			}
+		}
+		if n.kind&hasRedirect != 0 {
+			// drop the redirect to the node the source can't use
+			if index, ok := indices[n.redirect]; ok {
+				n.redirect = index
+			} else {
+				n.kind &^= hasRedirect
+			}
+		}
+		nodes[j] = n
+	}
+	return pk.Tuple{
+		pk.Array(nodes),
+		pk.VarInt(0),
+	}.WriteTo(w)
+}
@@ -0,0 +1,118 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
+)
+
+func TestGraph_Execute_requirement(t *testing.T) {
+	var ran string
+	run := func(name string) HandlerFunc {
+		return func(context.Context, []ParsedData) error {
+			ran = name
+			return nil
+		}
+	}
+	g := NewGraph()
+	kick := g.Literal("kick").
+		Requires(RequiresLevel(3)).
+		AppendArgument(g.Argument("target", StringParser(0)).HandleFunc(run("kick"))).
+		Unhandle()
+	g.AppendLiteral(g.Literal("list").HandleFunc(run("list"))).
+		AppendLiteral(g.Literal("stop").Requires(RequiresLevel(4)).HandleFunc(run("stop"))).
+		AppendLiteral(kick).
+		// an alias redirecting to an operator command
+		AppendLiteral(g.Literal("k").Redirect(kick, nil))
+
+	for _, tc := range []struct {
+		level int
+		cmd   string
+		ok    bool
+	}{
+		{0, "list", true},
+		{0, "stop", false},
+		{4, "stop", true},
+		{2, "kick Steve", false},
+		{3, "kick Steve", true},
+		{0, "k Steve", false},
+		{3, "k Steve", true},
+	} {
+		ran = ""
+		err := g.Execute(context.TODO(), PermissionLevel(tc.level), tc.cmd)
+		if (err == nil) != tc.ok {
+			t.Errorf("level %d: execute %q: err = %v", tc.level, tc.cmd, err)
+		}
+		if tc.ok && ran == "" {
+			t.Errorf("level %d: execute %q: not run", tc.level, tc.cmd)
+		}
+	}
+}
+
+func TestGraph_Suggest_requirement(t *testing.T) {
+	g := NewGraph()
+	g.AppendLiteral(g.Literal("list").HandleFunc(unhandledCmd)).
+		AppendLiteral(g.Literal("stop").Requires(RequiresLevel(4)).HandleFunc(unhandledCmd))
+
+	texts := func(s Suggestions) (texts []string) {
+		for _, m := range s.Matches {
+			texts = append(texts, m.Text)
+		}
+		return
+	}
+	if got := texts(g.Suggest(context.TODO(), PermissionLevel(0), "/", 1)); len(got) != 1 || got[0] != "list" {
+		t.Errorf("level 0 got %q", got)
+	}
+	if got := texts(g.Suggest(context.TODO(), PermissionLevel(4), "/", 1)); len(got) != 2 {
+		t.Errorf("level 4 got %q", got)
+	}
+}
+
+func TestGraph_ClientJoin_filtered(t *testing.T) {
+	g := NewGraph()
+	kick := g.Literal("kick").
+		Requires(RequiresLevel(3)).
+		AppendArgument(g.Argument("target", StringParser(0)).HandleFunc(unhandledCmd)).
+		Unhandle()
+	g.AppendLiteral(g.Literal("list").HandleFunc(unhandledCmd)).
+		AppendLiteral(g.Literal("stop").Requires(RequiresLevel(4)).HandleFunc(unhandledCmd)).
+		AppendLiteral(kick).
+		AppendLiteral(g.Literal("k").Redirect(kick, nil))
+
+	for _, tc := range []struct {
+		level int
+		nodes int
+	}{
+		// root, list, k without the redirect
+		{0, 3},
+		// root, list, kick, target, k
+		{3, 5},
+		{4, 6},
+	} {
+		var client packetRecorder
+		g.ClientJoin(&client, PermissionLevel(tc.level))
+		if len(client) != 1 {
+			t.Fatalf("level %d: sent %d packets", tc.level, len(client))
+		}
+		var count pk.VarInt
+		if _, err := count.ReadFrom(bytes.NewReader(client[0].Data)); err != nil {
+			t.Fatal(err)
+		}
+		if int(count) != tc.nodes {
+			t.Errorf("level %d: sent %d nodes, want %d", tc.level, count, tc.nodes)
+		}
+	}
+
+	// The "k" node redirects to "kick" for the operators.
+	var buf bytes.Buffer
+	if _, err := (filteredGraph{g: g, src: PermissionLevel(3)}).WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	// "k": literal with redirect, no children, redirect to the 3rd node, name
+	want := []byte{LiteralNode | hasRedirect, 0, 2, 1, 'k'}
+	if !bytes.Contains(buf.Bytes(), want) {
+		t.Errorf("got % x, want % x in it", buf.Bytes(), want)
+	}
+}
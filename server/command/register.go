@@ -0,0 +1,234 @@
+package command
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"git.konjactw.dev/falloutBot/go-mc/chat"
+)
+
+// Runner is a command declared by a struct, see Register.
+type Runner interface {
+	Run(c *Context) error
+}
+
+// Register adds the command declared by the struct T to the Graph, and returns its last literal node.
+//
+// The path is the literals of the command separated by spaces, such as "team add".
+// The literals already in the Graph are reused, so the commands can share their parents.
+//
+// The fields of T tagged by `cmd:"name,options"` are the arguments of the command in order,
+// which are parsed into a new T before its Run is called.
+// The name is the field name in lower case if it's empty. The options are:
+//
+//   - optional: the argument can be omitted, which must be after all the required arguments.
+//   - phrase, greedy: a string argument is a quotable phrase or the rest of the command, see StringParser.
+//   - single, players: an EntitySelector argument selects one entity or only players, see EntityParser.
+//
+// The parsers are decided by the field types.
+// The numbers are bounded by the tags `min:"0"` and `max:"10"`,
+// and a Resource field is parsed by ResourceParser with the registry of the tag `registry:"entity_type"`.
+// The other types, or the fields with the same types but different parsers,
+// are parsed by the Parsers method of T, returning the parsers by the argument names.
+//
+// The tag `help:"..."` is the Description of the argument node,
+// and the Description of the command is returned by the Description method of T.
+// The Requires method of T is the requirement of the command.
+//
+// Register panics if T isn't a struct or a field can't be parsed.
+func Register[T any, P interface {
+	*T
+	Runner
+}](g *Graph, path string) *Literal {
+	var declared P = new(T)
+	args := declaredArgs(reflect.TypeFor[T](), any(declared))
+
+	run := Handle(func(c *Context) error {
+		cmd := P(new(T))
+		v := reflect.ValueOf(cmd).Elem()
+		for _, arg := range args {
+			value, ok := c.Argument(arg.name)
+			if !ok {
+				continue
+			}
+			field := v.FieldByIndex(arg.index)
+			rv := reflect.ValueOf(value)
+			if !rv.Type().AssignableTo(field.Type()) {
+				if !rv.CanConvert(field.Type()) {
+					return fmt.Errorf("command: argument %q is %T, not %s", arg.name, value, field.Type())
+				}
+				rv = rv.Convert(field.Type())
+			}
+			field.Set(rv)
+		}
+		return cmd.Run(c)
+	})
+
+	words := strings.Fields(path)
+	if len(words) == 0 {
+		panic("command: register a command without name")
+	}
+	node := g.nodes[0]
+	for _, word := range words {
+		node = g.literalChild(node, word)
+	}
+	literal := node
+	if d, ok := any(declared).(interface{ Description() string }); ok {
+		literal.Description = d.Description()
+	}
+	if r, ok := any(declared).(interface{ Requires(src Source) bool }); ok {
+		literal.requires = r.Requires
+	}
+
+	for _, arg := range args {
+		if arg.optional {
+			node.Run = run
+		}
+		next := g.Argument(arg.name, arg.parser).current
+		next.Description = arg.help
+		node.Children = append(node.Children, next.index)
+		node = next
+	}
+	node.Run = run
+	return (*Literal)(literal)
+}
+
+// literalChild returns the literal child of the node by its name, which is created if not exists.
+func (g *Graph) literalChild(node *Node, name string) *Node {
+	for _, i := range node.Children {
+		if child := g.nodes[i]; child.kind&0x03 == LiteralNode && child.Name == name {
+			return child
+		}
+	}
+	child := g.Literal(name).current
+	node.Children = append(node.Children, child.index)
+	return child
+}
+
+// declaredArg is an argument declared by a field of the struct, see Register.
+type declaredArg struct {
+	index    []int
+	name     string
+	parser   Parser
+	optional bool
+	help     string
+}
+
+func declaredArgs(typ reflect.Type, declared any) (args []declaredArg) {
+	if typ.Kind() != reflect.Struct {
+		panic("command: register a non-struct type " + typ.String())
+	}
+	var parsers map[string]Parser
+	if p, ok := declared.(interface{ Parsers() map[string]Parser }); ok {
+		parsers = p.Parsers()
+	}
+	for _, field := range reflect.VisibleFields(typ) {
+		tag, ok := field.Tag.Lookup("cmd")
+		if !ok || !field.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		arg := declaredArg{
+			index: field.Index,
+			name:  name,
+			help:  field.Tag.Get("help"),
+		}
+		options := map[string]bool{}
+		for _, opt := range strings.Split(opts, ",") {
+			options[opt] = true
+		}
+		arg.optional = options["optional"]
+		if len(args) > 0 && args[len(args)-1].optional && !arg.optional {
+			panic("command: required argument " + name + " after optional arguments")
+		}
+		if arg.parser = parsers[name]; arg.parser == nil {
+			arg.parser = fieldParser(field, options)
+		}
+		args = append(args, arg)
+	}
+	return
+}
+
+// fieldParser returns the parser of the field by its type.
+func fieldParser(field reflect.StructField, options map[string]bool) Parser {
+	// The bounds are parsed by the kind of the field, so the defaults of int64 don't lose precision.
+	intBound := func(tag string, bits int, def int64) int64 {
+		s, ok := field.Tag.Lookup(tag)
+		if !ok {
+			return def
+		}
+		v, err := strconv.ParseInt(s, 10, bits)
+		if err != nil {
+			panic("command: invalid " + tag + " of field " + field.Name + ": " + err.Error())
+		}
+		return v
+	}
+	floatBound := func(tag string, bits int, def float64) float64 {
+		s, ok := field.Tag.Lookup(tag)
+		if !ok {
+			return def
+		}
+		v, err := strconv.ParseFloat(s, bits)
+		if err != nil {
+			panic("command: invalid " + tag + " of field " + field.Name + ": " + err.Error())
+		}
+		return v
+	}
+	switch field.Type {
+	case reflect.TypeFor[EntitySelector]():
+		return EntityParser{Single: options["single"], PlayersOnly: options["players"]}
+	case reflect.TypeFor[BlockPos]():
+		return BlockPosParser{}
+	case reflect.TypeFor[Vec3]():
+		return Vec3Parser{}
+	case reflect.TypeFor[ColumnPos]():
+		return ColumnPosParser{}
+	case reflect.TypeFor[Rotation]():
+		return RotationParser{}
+	case reflect.TypeFor[ResourceLocation]():
+		return ResourceLocationParser{}
+	case reflect.TypeFor[Resource]():
+		return ResourceParser{Registry: field.Tag.Get("registry")}
+	case reflect.TypeFor[BlockState]():
+		return BlockStateParser{}
+	case reflect.TypeFor[ItemStack]():
+		return ItemStackParser{}
+	case reflect.TypeFor[chat.Message]():
+		return ComponentParser{}
+	case reflect.TypeFor[uuid.UUID]():
+		return UUIDParser{}
+	case reflect.TypeFor[GameMode]():
+		return GameModeParser{}
+	case reflect.TypeFor[Swizzle]():
+		return SwizzleParser{}
+	}
+	switch field.Type.Kind() {
+	case reflect.String:
+		switch {
+		case options["greedy"]:
+			return StringParser(2)
+		case options["phrase"]:
+			return StringParser(1)
+		}
+		return StringParser(0)
+	case reflect.Bool:
+		return BoolParser{}
+	case reflect.Int32, reflect.Int:
+		return IntegerParser{Min: int32(intBound("min", 32, math.MinInt32)), Max: int32(intBound("max", 32, math.MaxInt32))}
+	case reflect.Int64:
+		return LongParser{Min: intBound("min", 64, math.MinInt64), Max: intBound("max", 64, math.MaxInt64)}
+	case reflect.Float32:
+		return FloatParser{Min: float32(floatBound("min", 32, -math.MaxFloat32)), Max: float32(floatBound("max", 32, math.MaxFloat32))}
+	case reflect.Float64:
+		return DoubleParser{Min: floatBound("min", 64, -math.MaxFloat64), Max: floatBound("max", 64, math.MaxFloat64)}
+	}
+	panic("command: no parser for field " + field.Name + " of type " + field.Type.String())
+}
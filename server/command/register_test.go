@@ -0,0 +1,124 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"git.konjactw.dev/falloutBot/go-mc/chat"
+)
+
+type giveCommand struct {
+	Targets EntitySelector `cmd:",players" help:"The players to give"`
+	Item    string         `cmd:"item" help:"The item to give"`
+	Count   int            `cmd:",optional" min:"1" max:"64"`
+}
+
+var lastGive *giveCommand
+
+func (g *giveCommand) Description() string { return "Gives an item to players" }
+
+func (g *giveCommand) Requires(src Source) bool { return src != nil && src.PermissionLevel() >= 2 }
+
+func (g *giveCommand) Run(c *Context) error {
+	if g.Count == 0 {
+		g.Count = 1
+	}
+	lastGive = g
+	c.SendFeedback(chat.Text("Gave " + g.Item))
+	return nil
+}
+
+type seedCommand struct {
+	Seed int64 `cmd:"value"`
+}
+
+var lastSeed int64
+
+func (s *seedCommand) Run(*Context) error {
+	lastSeed = s.Seed
+	return nil
+}
+
+type teamCommand struct {
+	Name string `cmd:"team"`
+}
+
+func (t *teamCommand) Run(c *Context) error { return errors.New("team " + t.Name) }
+
+func TestRegister(t *testing.T) {
+	g := NewGraph()
+	give := Register[giveCommand](g, "give")
+	Register[teamCommand](g, "team add")
+	Register[teamCommand](g, "team remove")
+	Register[seedCommand](g, "seed")
+
+	if give.Description != "Gives an item to players" {
+		t.Errorf("description: %q", give.Description)
+	}
+	if want := []string{"give", "team", "seed"}; len(g.nodes[0].Children) != len(want) {
+		t.Errorf("root children: %v", g.nodes[0].Children)
+	}
+	for _, n := range g.nodes {
+		switch n.Name {
+		case "targets":
+			if n.Parser != (EntityParser{PlayersOnly: true}) || n.Description != "The players to give" || n.Run != nil {
+				t.Errorf("targets: %#v", n)
+			}
+		case "count":
+			if n.Parser != (IntegerParser{Min: 1, Max: 64}) || n.Run == nil {
+				t.Errorf("count: %#v", n)
+			}
+		case "value":
+			if n.Parser != (LongParser{Min: math.MinInt64, Max: math.MaxInt64}) {
+				t.Errorf("seed: %#v", n.Parser)
+			}
+		case "item":
+			if n.Parser != StringParser(0) || n.Run == nil {
+				t.Errorf("item: %#v", n)
+			}
+		}
+	}
+
+	p := &testPlayer{name: "Steve", level: 2}
+	if err := g.Execute(context.TODO(), p, "give @a diamond 5"); err != nil {
+		t.Fatal(err)
+	}
+	if lastGive.Item != "diamond" || lastGive.Count != 5 || lastGive.Targets.Selector != 'a' {
+		t.Errorf("got %+v", lastGive)
+	}
+	if err := g.Execute(context.TODO(), p, "give @a stone"); err != nil || lastGive.Count != 1 {
+		t.Errorf("optional count: %v, %+v", err, lastGive)
+	}
+	if len(p.messages) != 2 {
+		t.Errorf("feedback: %v", p.messages)
+	}
+	if err := g.Execute(context.TODO(), &testPlayer{}, "give @a stone"); err == nil {
+		t.Error("give shouldn't be allowed for level 0")
+	}
+	if err := g.Execute(context.TODO(), p, "seed -5"); err != nil || lastSeed != -5 {
+		t.Errorf("seed: %v, %d", err, lastSeed)
+	}
+	if err := g.Execute(context.TODO(), p, "team remove red"); err == nil || err.Error() != "team red" {
+		t.Errorf("team remove: %v", err)
+	}
+}
+
+func TestRegister_invalid(t *testing.T) {
+	type optionalFirst struct {
+		runner
+		A int `cmd:",optional"`
+		B int `cmd:""`
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("required argument after optional ones should panic")
+		}
+	}()
+	Register[optionalFirst](NewGraph(), "bad")
+}
+
+type runner struct{}
+
+func (runner) Run(*Context) error { return nil }
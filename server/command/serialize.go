@@ -1,7 +1,10 @@
 package command
 
 import (
+	"errors"
+	"fmt"
 	"io"
+	"slices"
 	"unsafe"
 
 	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
@@ -22,129 +25,140 @@ func (g *Graph) WriteTo(w io.Writer) (int64, error) {
 
 func (n Node) WriteTo(w io.Writer) (int64, error) {
 	var flag byte
-	flag |= n.kind & 0x03
+	flag |= n.kind & (0x03 | hasRedirect)
 	if n.Run != nil {
 		flag |= isExecutable
 	}
+	if n.kind&0x03 == ArgumentNode && n.SuggestionsType != "" {
+		flag |= hasSuggestionsType
+	}
 	return pk.Tuple{
 		pk.Byte(flag),
 		pk.Array((*[]pk.VarInt)(unsafe.Pointer(&n.Children))),
 		pk.Opt{
 			Has:   func() bool { return n.kind&hasRedirect != 0 },
-			Field: nil, // TODO: send redirect node
+			Field: pk.VarInt(n.redirect),
 		},
 		pk.Opt{
-			Has:   func() bool { return n.kind == ArgumentNode || n.kind == LiteralNode },
+			Has:   func() bool { return n.kind&0x03 == ArgumentNode || n.kind&0x03 == LiteralNode },
 			Field: pk.String(n.Name),
 		},
 		pk.Opt{
-			Has:   func() bool { return n.kind == ArgumentNode },
+			Has:   func() bool { return n.kind&0x03 == ArgumentNode },
 			Field: n.Parser, // Parser identifier and Properties
 		},
 		pk.Opt{
 			Has:   func() bool { return flag&hasSuggestionsType != 0 },
-			Field: nil, // TODO: send Suggestions type
+			Field: pk.Identifier(n.SuggestionsType),
 		},
 	}.WriteTo(w)
 }
 
-func (g *Graph) ReadFrom(r io.Reader) (int64, error) {
-	var totalBytes int64
-
-	// 讀取 nodes 數組
-	nodesReader := pk.Array(&g.nodes)
-	n, err := nodesReader.ReadFrom(r)
-	if err != nil {
-		return totalBytes, err
+// ReadFrom decodes the Commands packet into the Graph, which is used by the clients.
+// The executable nodes are run by the server, so their Run only returns an error.
+func (g *Graph) ReadFrom(r io.Reader) (n int64, err error) {
+	var count, root pk.VarInt
+	if n, err = count.ReadFrom(r); err != nil {
+		return
 	}
-	totalBytes += n
-
-	// 讀取版本號 (VarInt(0))
-	var version pk.VarInt
-	n, err = version.ReadFrom(r)
-	if err != nil {
-		return totalBytes, err
+	if count < 1 {
+		return n, errors.New("command: no nodes in the graph")
 	}
-	totalBytes += n
-
-	return totalBytes, nil
-}
-
-func (n *Node) ReadFrom(r io.Reader) (int64, error) {
-	var totalBytes int64
-
-	// 讀取 flag
-	var flag pk.Byte
-	bytes, err := flag.ReadFrom(r)
-	if err != nil {
-		return totalBytes, err
+	var nodes []*Node
+	for i := int32(0); i < int32(count); i++ {
+		node := &Node{g: g, index: i}
+		nn, err := node.ReadFrom(r)
+		n += nn
+		if err != nil {
+			return n, err
+		}
+		nodes = append(nodes, node)
 	}
-	totalBytes += bytes
-
-	// 從 flag 中提取 kind
-	n.kind = byte(flag) & 0x03
-
-	// 讀取 Children 數組
-	childrenSlice := (*[]pk.VarInt)(unsafe.Pointer(&n.Children))
-	childrenReader := pk.Array(childrenSlice)
-	bytes, err = childrenReader.ReadFrom(r)
+	nn, err := root.ReadFrom(r)
+	n += nn
 	if err != nil {
-		return totalBytes, err
+		return n, err
 	}
-	totalBytes += bytes
 
-	// 讀取 redirect 選項
-	redirectOpt := pk.Opt{
-		Has:   func() bool { return n.kind&hasRedirect != 0 },
-		Field: nil, // TODO: 實現 redirect node 讀取
+	invalid := func(i int32) bool { return i < 0 || i >= int32(count) }
+	if invalid(int32(root)) {
+		return n, fmt.Errorf("command: invalid root node %d", root)
 	}
-	bytes, err = redirectOpt.ReadFrom(r)
-	if err != nil {
-		return totalBytes, err
-	}
-	totalBytes += bytes
-
-	// 讀取名稱選項 (ArgumentNode 或 LiteralNode)
-	if n.kind == ArgumentNode || n.kind == LiteralNode {
-		var nameStr pk.String
-		nameOpt := pk.Opt{
-			Has:   func() bool { return n.kind == ArgumentNode || n.kind == LiteralNode },
-			Field: &nameStr,
-		}
-		bytes, err = nameOpt.ReadFrom(r)
-		if err != nil {
-			return totalBytes, err
+	// A root node can't be a child, but it can be the target of redirects.
+	invalidChild := func(i int32) bool { return invalid(i) || nodes[i].kind&0x03 == RootNode }
+	for _, node := range nodes {
+		if slices.ContainsFunc(node.Children, invalidChild) || node.kind&hasRedirect != 0 && invalid(node.redirect) {
+			return n, fmt.Errorf("command: node %d links to invalid nodes", node.index)
 		}
-		totalBytes += bytes
-		n.Name = string(nameStr)
 	}
-
-	// 讀取 Parser 選項 (僅 ArgumentNode)
-	if n.kind == ArgumentNode {
-		parserOpt := pk.Opt{
-			Has:   func() bool { return n.kind == ArgumentNode },
-			Field: &n.Parser,
+	// The root node is always the first node in the Graph.
+	if root != 0 {
+		swap := func(i int32) int32 {
+			switch i {
+			case 0:
+				return int32(root)
+			case int32(root):
+				return 0
+			}
+			return i
 		}
-		bytes, err = parserOpt.ReadFrom(r)
-		if err != nil {
-			return totalBytes, err
+		for _, node := range nodes {
+			for j, child := range node.Children {
+				node.Children[j] = swap(child)
+			}
+			node.redirect = swap(node.redirect)
 		}
-		totalBytes += bytes
+		nodes[0], nodes[root] = nodes[root], nodes[0]
+		nodes[0].index, nodes[root].index = 0, int32(root)
 	}
+	g.nodes = nodes
+	return n, nil
+}
 
-	// 讀取 Suggestions 類型選項
-	suggestionsOpt := pk.Opt{
-		Has:   func() bool { return byte(flag)&hasSuggestionsType != 0 },
-		Field: nil, // TODO: 實現 Suggestions 類型讀取
+func (n *Node) ReadFrom(r io.Reader) (int64, error) {
+	var (
+		flag     pk.Byte
+		name     pk.String
+		suggests pk.Identifier
+	)
+	total, err := pk.Tuple{
+		&flag,
+		pk.Array((*[]pk.VarInt)(unsafe.Pointer(&n.Children))),
+	}.ReadFrom(r)
+	if err != nil {
+		return total, err
+	}
+	n.kind = byte(flag) & (0x03 | hasRedirect)
+	kind := n.kind & 0x03
+	if kind != RootNode && kind != LiteralNode && kind != ArgumentNode {
+		return total, fmt.Errorf("command: invalid node type %d", kind)
 	}
-	bytes, err = suggestionsOpt.ReadFrom(r)
+	nn, err := pk.Tuple{
+		pk.Opt{
+			Has:   func() bool { return n.kind&hasRedirect != 0 },
+			Field: (*pk.VarInt)(&n.redirect),
+		},
+		pk.Opt{
+			Has:   func() bool { return kind == ArgumentNode || kind == LiteralNode },
+			Field: &name,
+		},
+		pk.Opt{
+			Has:   func() bool { return kind == ArgumentNode },
+			Field: parserDecoder{&n.Parser},
+		},
+		pk.Opt{
+			Has:   func() bool { return byte(flag)&hasSuggestionsType != 0 },
+			Field: &suggests,
+		},
+	}.ReadFrom(r)
+	total += nn
 	if err != nil {
-		return totalBytes, err
+		return total, err
 	}
-	totalBytes += bytes
-
+	n.Name = string(name)
+	n.SuggestionsType = string(suggests)
 	if byte(flag)&isExecutable != 0 {
+		n.Run = unhandledCmd
 	}
-
-	return totalBytes, nil
+	return total, nil
 }
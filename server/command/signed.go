@@ -0,0 +1,22 @@
+package command
+
+import "git.konjactw.dev/falloutBot/go-mc/chat/sign"
+
+// SignableArguments returns the arguments of the cmd which are signed by the clients,
+// which are the arguments parsed by MessageParser, such as the message of "/say".
+//
+// The clients sign them by sign.Signer.SignArguments, and send the command in the ServerboundChatCommandSigned packet.
+// The servers verify them by sign.Session.VerifyArguments.
+func (g *Graph) SignableArguments(src Source, cmd string) ([]sign.Argument, error) {
+	parsed, err := g.Parse(src, cmd)
+	if err != nil {
+		return nil, err
+	}
+	var args []sign.Argument
+	for _, arg := range parsed {
+		if _, ok := arg.Parser.(MessageParser); ok {
+			args = append(args, sign.Argument{Name: arg.Name, Value: arg.Raw})
+		}
+	}
+	return args, nil
+}
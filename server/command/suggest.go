@@ -0,0 +1,179 @@
+package command
+
+import (
+	"context"
+	"strings"
+	"unicode/utf16"
+
+	"git.konjactw.dev/falloutBot/go-mc/chat"
+	"git.konjactw.dev/falloutBot/go-mc/data/packetid"
+	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
+)
+
+// The suggestions types of the argument nodes.
+// The client asks the server for the suggestions of the AskServer arguments,
+// and computes the others itself.
+const (
+	AskServer          = "minecraft:ask_server"
+	AllRecipes         = "minecraft:all_recipes"
+	AvailableSounds    = "minecraft:available_sounds"
+	SummonableEntities = "minecraft:summonable_entities"
+)
+
+// Suggestion is a completion of the command, with an optional tooltip.
+type Suggestion struct {
+	Text    string
+	Tooltip *chat.Message
+}
+
+// Suggestions is the result of Graph.Suggest.
+// Each match replaces the input in the range [Start, Start+Length).
+type Suggestions struct {
+	Start, Length int
+	Matches       []Suggestion
+}
+
+// SuggestFunc returns the suggestions of an argument.
+// The args are the values parsed before the argument from the root, the same as the args of HandlerFunc,
+// and the remaining is the incomplete input of the argument.
+type SuggestFunc func(ctx context.Context, args []ParsedData, remaining string) []Suggestion
+
+// Suggester is implemented by the parsers which know their possible values,
+// used by the argument nodes without a SuggestFunc.
+type Suggester interface {
+	Suggest(remaining string) []string
+}
+
+// SuggestStrings returns a SuggestFunc suggesting the values which start with the remaining input.
+func SuggestStrings(values ...string) SuggestFunc {
+	return func(_ context.Context, _ []ParsedData, remaining string) []Suggestion {
+		var matches []Suggestion
+		for _, v := range filterPrefix(values, remaining) {
+			matches = append(matches, Suggestion{Text: v})
+		}
+		return matches
+	}
+}
+
+// filterPrefix returns the values starting with the prefix, ignoring the case.
+func filterPrefix(values []string, prefix string) []string {
+	var matches []string
+	prefix = strings.ToLower(prefix)
+	for _, v := range values {
+		if strings.HasPrefix(strings.ToLower(v), prefix) {
+			matches = append(matches, v)
+		}
+	}
+	return matches
+}
+
+// Suggest returns the completions of the input before the cursor, from the nodes the source can use.
+// A leading '/' of the input is skipped, and the returned range is the byte offsets in the input.
+// The cursor is clamped into the input.
+func (g *Graph) Suggest(ctx context.Context, src Source, input string, cursor int) Suggestions {
+	input = input[:min(max(cursor, 0), len(input))]
+	pos := 0
+	if strings.HasPrefix(input, "/") {
+		pos = 1
+	}
+	node := g.nodes[0]
+	args := values(g.prefix(node))
+walk:
+	for {
+		rest := input[pos:]
+		for _, i := range node.Children {
+			child := g.nodes[i]
+			if !child.canUse(src) {
+				continue
+			}
+			left, value, ok := child.tryParse(rest)
+			trimmed := strings.TrimLeft(left, " ")
+			if !ok || len(trimmed) == len(left) {
+				// the child doesn't complete before a space
+				continue
+			}
+			pos = len(input) - len(trimmed)
+			args = append(args, value)
+			node = child
+			if node.kind&hasRedirect != 0 {
+				if node = g.nodes[node.redirect]; !node.canUse(src) {
+					return Suggestions{Start: pos, Length: len(input) - pos}
+				}
+				args = values(g.prefix(node))
+			}
+			continue walk
+		}
+		return g.complete(ctx, src, node, args, pos, rest)
+	}
+}
+
+// tryParse parses the cmd without panicking, used by Suggest.
+func (n *Node) tryParse(cmd string) (left string, value ParsedData, ok bool) {
+	if n.kind&0x03 == LiteralNode {
+		word, left := readWord(cmd)
+		return left, LiteralData(n.Name), word == n.Name
+	}
+	left, value, err := n.parse(cmd)
+	return left, value, err == nil
+}
+
+// complete collects the suggestions of the node's children for the remaining input at pos.
+func (g *Graph) complete(ctx context.Context, src Source, node *Node, args []ParsedData, pos int, remaining string) Suggestions {
+	s := Suggestions{Start: pos, Length: len(remaining)}
+	for _, i := range node.Children {
+		child := g.nodes[i]
+		switch {
+		case !child.canUse(src):
+		case child.kind&0x03 == LiteralNode:
+			if len(filterPrefix([]string{child.Name}, remaining)) > 0 {
+				s.Matches = append(s.Matches, Suggestion{Text: child.Name})
+			}
+		case child.suggest != nil:
+			s.Matches = append(s.Matches, child.suggest(ctx, args, remaining)...)
+		default:
+			if p, ok := child.Parser.(Suggester); ok {
+				for _, v := range p.Suggest(remaining) {
+					s.Matches = append(s.Matches, Suggestion{Text: v})
+				}
+			}
+		}
+	}
+	return s
+}
+
+// HandleCommandSuggestion handles the ServerboundCommandSuggestion packet from the client of the source,
+// and sends the suggestions back by the ClientboundCommandSuggestions packet.
+func (g *Graph) HandleCommandSuggestion(ctx context.Context, client Client, src Source, p pk.Packet) error {
+	var (
+		id   pk.VarInt
+		text pk.String
+	)
+	if err := p.Scan(&id, &text); err != nil {
+		return err
+	}
+	s := g.Suggest(ctx, src, string(text), len(text))
+	matches := make(pk.Tuple, len(s.Matches))
+	for i, m := range s.Matches {
+		tooltip := pk.Option[chat.Message, *chat.Message]{Has: m.Tooltip != nil}
+		if m.Tooltip != nil {
+			tooltip.Val = *m.Tooltip
+		}
+		matches[i] = pk.Tuple{pk.String(m.Text), tooltip}
+	}
+	// The client counts the range in UTF-16 code units.
+	start := utf16Len(string(text)[:s.Start])
+	length := utf16Len(string(text)[s.Start : s.Start+s.Length])
+	client.SendPacket(pk.Marshal(
+		packetid.ClientboundCommandSuggestions,
+		id,
+		pk.VarInt(start),
+		pk.VarInt(length),
+		pk.VarInt(len(matches)),
+		matches,
+	))
+	return nil
+}
+
+func utf16Len(s string) int {
+	return len(utf16.Encode([]rune(s)))
+}
@@ -0,0 +1,177 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"reflect"
+	"testing"
+
+	"git.konjactw.dev/falloutBot/go-mc/chat"
+	"git.konjactw.dev/falloutBot/go-mc/data/packetid"
+	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
+)
+
+func TestGraph_Suggest(t *testing.T) {
+	g := NewGraph()
+	tooltip := chat.Text("the player")
+	g.AppendLiteral(g.Literal("gamemode").
+		AppendArgument(g.Argument("mode", GameModeParser{}).
+			AppendArgument(g.Argument("target", StringParser(0)).
+				Suggests(func(ctx context.Context, args []ParsedData, remaining string) []Suggestion {
+					if args[2] != Creative {
+						return nil
+					}
+					return []Suggestion{{Text: "Steve", Tooltip: &tooltip}}
+				}).
+				HandleFunc(unhandledCmd)).
+			HandleFunc(unhandledCmd)).
+		Unhandle(),
+	).AppendLiteral(g.Literal("give").
+		AppendArgument(g.Argument("item", StringParser(0)).
+			Suggests(SuggestStrings("diamond", "dirt", "stone")).
+			HandleFunc(unhandledCmd)).
+		Unhandle(),
+	).AppendLiteral(g.Literal("summon").
+		AppendArgument(g.Argument("entity", ResourceLocationParser{}).
+			SuggestsType(SummonableEntities).
+			HandleFunc(unhandledCmd)).
+		Unhandle(),
+	)
+
+	texts := func(s Suggestions) (texts []string) {
+		for _, m := range s.Matches {
+			texts = append(texts, m.Text)
+		}
+		return
+	}
+	for _, tc := range []struct {
+		input         string
+		start, length int
+		want          []string
+	}{
+		{"/g", 1, 1, []string{"gamemode", "give"}},
+		{"", 0, 0, []string{"gamemode", "give", "summon"}},
+		{"/gamemode ", 10, 0, []string{"survival", "creative", "adventure", "spectator"}},
+		{"/gamemode s", 10, 1, []string{"survival", "spectator"}},
+		{"/gamemode creative ", 19, 0, []string{"Steve"}},
+		{"/gamemode survival ", 19, 0, nil},
+		{"/give d", 6, 1, []string{"diamond", "dirt"}},
+		{"/give Di", 6, 2, []string{"diamond", "dirt"}},
+		{"/summon ", 8, 0, nil},
+		{"/unknown ", 1, 8, nil},
+	} {
+		s := g.Suggest(context.TODO(), PermissionLevel(0), tc.input, len(tc.input))
+		if s.Start != tc.start || s.Length != tc.length || !reflect.DeepEqual(texts(s), tc.want) {
+			t.Errorf("suggest %q: got [%d, +%d) %q, want [%d, +%d) %q", tc.input, s.Start, s.Length, texts(s), tc.start, tc.length, tc.want)
+		}
+	}
+}
+
+func TestGraph_Suggest_args(t *testing.T) {
+	g := NewGraph()
+	var got [][]ParsedData
+	g.AppendLiteral(g.Literal("say").
+		AppendArgument(g.Argument("message", StringParser(0)).
+			Suggests(func(ctx context.Context, args []ParsedData, remaining string) []Suggestion {
+				got = append(got, args)
+				return nil
+			}).
+			HandleFunc(unhandledCmd)).
+		Unhandle(),
+	).AppendLiteral(g.Literal("run").Redirect(g, nil))
+
+	g.Suggest(context.TODO(), PermissionLevel(0), "/say ", 5)
+	g.Suggest(context.TODO(), PermissionLevel(0), "/run run say ", 13)
+	want := []ParsedData{nil, LiteralData("say")}
+	if len(got) != 2 || !reflect.DeepEqual(got[0], want) || !reflect.DeepEqual(got[1], want) {
+		t.Errorf("args should start from the root with or without redirects, got %v", got)
+	}
+}
+
+func TestGraph_Suggest_cursor(t *testing.T) {
+	g := NewGraph()
+	g.AppendLiteral(g.Literal("say").HandleFunc(unhandledCmd))
+	for _, tc := range []struct {
+		cursor, start, length int
+	}{
+		{-1, 0, 0},
+		{2, 1, 1},
+		{100, 1, 3},
+	} {
+		s := g.Suggest(context.TODO(), PermissionLevel(0), "/say", tc.cursor)
+		if s.Start != tc.start || s.Length != tc.length {
+			t.Errorf("cursor %d: got [%d, +%d), want [%d, +%d)", tc.cursor, s.Start, s.Length, tc.start, tc.length)
+		}
+	}
+}
+
+type packetRecorder []pk.Packet
+
+func (r *packetRecorder) SendPacket(p pk.Packet) { *r = append(*r, p) }
+
+type suggestionMatch struct {
+	Text    pk.String
+	Tooltip pk.Option[chat.Message, *chat.Message]
+}
+
+func (m *suggestionMatch) ReadFrom(r io.Reader) (int64, error) {
+	return pk.Tuple{&m.Text, &m.Tooltip}.ReadFrom(r)
+}
+
+func TestGraph_HandleCommandSuggestion(t *testing.T) {
+	g := NewGraph()
+	tooltip := chat.Text("the player")
+	g.AppendLiteral(g.Literal("msg").
+		AppendArgument(g.Argument("target", StringParser(0)).
+			Suggests(func(ctx context.Context, args []ParsedData, remaining string) []Suggestion {
+				return []Suggestion{{Text: "Steve", Tooltip: &tooltip}}
+			}).
+			HandleFunc(unhandledCmd)).
+		Unhandle(),
+	)
+	var client packetRecorder
+	err := g.HandleCommandSuggestion(context.TODO(), &client, PermissionLevel(0), pk.Marshal(
+		packetid.ServerboundCommandSuggestion,
+		pk.VarInt(7),
+		pk.String("/msg St"),
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(client) != 1 || client[0].ID != int32(packetid.ClientboundCommandSuggestions) {
+		t.Fatalf("unexpected packets: %v", client)
+	}
+	var (
+		id, start, length pk.VarInt
+		matches           []suggestionMatch
+	)
+	if err := client[0].Scan(&id, &start, &length, pk.Array(&matches)); err != nil {
+		t.Fatal(err)
+	}
+	if id != 7 || start != 5 || length != 2 || len(matches) != 1 {
+		t.Fatalf("got id=%d range=[%d, +%d) %d matches", id, start, length, len(matches))
+	}
+	if matches[0].Text != "Steve" || !matches[0].Tooltip.Has || matches[0].Tooltip.Val.ClearString() != "the player" {
+		t.Errorf("unexpected match: %+v", matches[0])
+	}
+}
+
+func TestNode_WriteTo_suggestionsType(t *testing.T) {
+	g := NewGraph()
+	entity := g.Argument("entity", ResourceLocationParser{}).
+		SuggestsType(SummonableEntities).
+		HandleFunc(unhandledCmd)
+	var buf bytes.Buffer
+	if _, err := (*Node)(entity).WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	// flags, no children, name, resource_location, suggestions type
+	want := append([]byte{ArgumentNode | isExecutable | hasSuggestionsType, 0, 6}, "entity"...)
+	want = append(want, byte(argumentType("minecraft:resource_location")))
+	want = append(want, byte(len(SummonableEntities)))
+	want = append(want, SummonableEntities...)
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("got % x, want % x", buf.Bytes(), want)
+	}
+}
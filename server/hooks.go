@@ -0,0 +1,157 @@
+package server
+
+import (
+	"log/slog"
+	stdnet "net"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"git.konjactw.dev/falloutBot/go-mc/chat"
+	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
+)
+
+// ConnEventType is the kind of ConnEvent.
+type ConnEventType int
+
+const (
+	// ConnEventAccepted is fired when the Server starts handling a connection.
+	ConnEventAccepted ConnEventType = iota
+	// ConnEventHandshake is fired after the handshake packet is parsed.
+	ConnEventHandshake
+	// ConnEventStateChanged is fired when the connection enters another state.
+	ConnEventStateChanged
+	// ConnEventLoginSuccess is fired when the player passes the LoginHandler.
+	ConnEventLoginSuccess
+	// ConnEventLoginFailure is fired when the LoginHandler returns an error.
+	ConnEventLoginFailure
+	// ConnEventConfigFailure is fired when the ConfigHandler returns an error.
+	ConnEventConfigFailure
+	// ConnEventClosed is fired after the connection is closed.
+	ConnEventClosed
+)
+
+func (c ConnEventType) String() string {
+	switch c {
+	case ConnEventAccepted:
+		return "accepted"
+	case ConnEventHandshake:
+		return "handshake"
+	case ConnEventStateChanged:
+		return "state changed"
+	case ConnEventLoginSuccess:
+		return "login success"
+	case ConnEventLoginFailure:
+		return "login failure"
+	case ConnEventConfigFailure:
+		return "config failure"
+	case ConnEventClosed:
+		return "closed"
+	default:
+		return "ConnEventType(" + strconv.Itoa(int(c)) + ")"
+	}
+}
+
+// ConnEvent describes something happened to a connection.
+// Only the fields related to the Type are set.
+type ConnEvent struct {
+	Type ConnEventType
+	Addr stdnet.Addr
+	// State is the current state of the connection.
+	// For ConnEventClosed, it's the last state before closing.
+	State ConnState
+	// PrevState is the state before ConnEventStateChanged.
+	PrevState ConnState
+
+	// Protocol and Intention are the fields of the handshake packet.
+	// Protocol is also set for the events after ConnEventHandshake.
+	Protocol  int32
+	Intention int32
+
+	// Name and ID are the player's profile, set since ConnEventLoginSuccess.
+	Name string
+	ID   uuid.UUID
+
+	// Err is the error of ConnEventLoginFailure and ConnEventConfigFailure.
+	Err error
+	// Reason is the message sent to the client, if the failure is a LoginFailErr or ConfigFailErr.
+	Reason *chat.Message
+}
+
+// ConnHook receives the events of all connections handled by the Server.
+// HandleConnEvent is called synchronously in the connection's goroutine, so it shouldn't block.
+type ConnHook interface {
+	HandleConnEvent(e ConnEvent)
+}
+
+// PacketHook is an optional interface of ConnHook to watch every packet read or written by the Server and GamePlay.
+// The Data of the packet must not be retained or modified.
+type PacketHook interface {
+	HandlePacket(state ConnState, serverbound bool, p pk.Packet)
+}
+
+// ConnHooks combines several ConnHook. Events are passed to each of them in order.
+type ConnHooks []ConnHook
+
+// HandleConnEvent implements ConnHook for ConnHooks
+func (h ConnHooks) HandleConnEvent(e ConnEvent) {
+	for _, hook := range h {
+		hook.HandleConnEvent(e)
+	}
+}
+
+// HandlePacket implements PacketHook for ConnHooks
+func (h ConnHooks) HandlePacket(state ConnState, serverbound bool, p pk.Packet) {
+	for _, hook := range h {
+		if hook, ok := hook.(PacketHook); ok {
+			hook.HandlePacket(state, serverbound, p)
+		}
+	}
+}
+
+// packetObserver forwards the packets of a connection to the PacketHook with its current state.
+type packetObserver struct {
+	conn *trackedConn
+	hook PacketHook
+}
+
+func (p packetObserver) PacketRead(packet pk.Packet) {
+	p.hook.HandlePacket(p.conn.State(), true, packet)
+}
+
+func (p packetObserver) PacketWritten(packet pk.Packet) {
+	p.hook.HandlePacket(p.conn.State(), false, packet)
+}
+
+// connEvents fires the events of a connection to the Server's ConnHook.
+type connEvents struct {
+	hook   ConnHook
+	conn   *trackedConn
+	addr   stdnet.Addr
+	logger *slog.Logger
+
+	protocol int32
+	name     string
+	id       uuid.UUID
+}
+
+func (c *connEvents) fire(e ConnEvent) {
+	if c.hook == nil {
+		return
+	}
+	e.Addr = c.addr
+	e.State = c.conn.State()
+	if e.Protocol == 0 {
+		e.Protocol = c.protocol
+	}
+	if e.Name == "" {
+		e.Name, e.ID = c.name, c.id
+	}
+	c.hook.HandleConnEvent(e)
+}
+
+func (c *connEvents) setState(state ConnState) {
+	prev := c.conn.swapState(state)
+	c.fire(ConnEvent{Type: ConnEventStateChanged, PrevState: prev})
+	c.logger.Debug("state changed", "state", state, "prev_state", prev)
+}
@@ -0,0 +1,162 @@
+package server
+
+import (
+	stdnet "net"
+	"testing"
+
+	"git.konjactw.dev/falloutBot/go-mc/data/packetid"
+	"git.konjactw.dev/falloutBot/go-mc/net"
+	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
+)
+
+// eventRecorder is a ConnHook and PacketHook remembering everything it receives.
+type eventRecorder struct {
+	events  []ConnEvent
+	packets []packetKey
+}
+
+func (r *eventRecorder) HandleConnEvent(e ConnEvent) { r.events = append(r.events, e) }
+
+func (r *eventRecorder) HandlePacket(state ConnState, serverbound bool, p pk.Packet) {
+	r.packets = append(r.packets, packetKey{state: state, serverbound: serverbound, id: p.ID})
+}
+
+// connOnly is a ConnHook without HandlePacket.
+type connOnly struct{ events []ConnEventType }
+
+func (c *connOnly) HandleConnEvent(e ConnEvent) { c.events = append(c.events, e.Type) }
+
+// acceptTransfer lets s handle a connection sending a transfer handshake,
+// which is rejected since AcceptsTransfers is false.
+func acceptTransfer(t *testing.T, s *Server) {
+	l, err := stdnet.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		s.AcceptConn(net.WrapConn(conn))
+	}()
+
+	client, err := net.DialMC(l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	err = client.WritePacket(pk.Marshal(
+		0x00, // handshake
+		pk.VarInt(ProtocolVersion),
+		pk.String("localhost"),
+		pk.UnsignedShort(25565),
+		pk.VarInt(3),
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-done
+}
+
+func TestServer_ConnHook(t *testing.T) {
+	var recorder eventRecorder
+	acceptTransfer(t, &Server{ConnHook: &recorder})
+
+	want := []struct {
+		typ   ConnEventType
+		state ConnState
+	}{
+		{ConnEventAccepted, ConnHandshake},
+		{ConnEventHandshake, ConnHandshake},
+		{ConnEventStateChanged, ConnLogin},
+		{ConnEventLoginFailure, ConnLogin},
+		{ConnEventClosed, ConnLogin},
+	}
+	if len(recorder.events) != len(want) {
+		t.Fatalf("want %d events, get %v", len(want), recorder.events)
+	}
+	for i, e := range recorder.events {
+		if e.Type != want[i].typ || e.State != want[i].state {
+			t.Errorf("event %d: want %v in %v, get %v in %v", i, want[i].typ, want[i].state, e.Type, e.State)
+		}
+		if e.Addr == nil {
+			t.Errorf("event %v: no addr", e.Type)
+		}
+	}
+
+	if e := recorder.events[0]; e.Protocol != 0 {
+		t.Errorf("protocol before the handshake: %d", e.Protocol)
+	}
+	if e := recorder.events[1]; e.Protocol != ProtocolVersion || e.Intention != 3 {
+		t.Errorf("handshake: protocol %d, intention %d", e.Protocol, e.Intention)
+	}
+	if e := recorder.events[2]; e.PrevState != ConnHandshake || e.Protocol != ProtocolVersion {
+		t.Errorf("state changed: prev state %v, protocol %d", e.PrevState, e.Protocol)
+	}
+	e := recorder.events[3]
+	if e.Err == nil || e.Reason == nil || e.Reason.Translate != "multiplayer.disconnect.transfers_disabled" {
+		t.Errorf("login failure: err %v, reason %v", e.Err, e.Reason)
+	}
+}
+
+func TestServer_PacketHook(t *testing.T) {
+	var recorder eventRecorder
+	acceptTransfer(t, &Server{ConnHook: &recorder})
+
+	want := []packetKey{
+		{state: ConnHandshake, serverbound: true, id: 0x00},
+		{state: ConnLogin, serverbound: false, id: int32(packetid.ClientboundLoginLoginDisconnect)},
+	}
+	if len(recorder.packets) != len(want) {
+		t.Fatalf("want packets %v, get %v", want, recorder.packets)
+	}
+	for i := range want {
+		if recorder.packets[i] != want[i] {
+			t.Errorf("packet %d: want %v, get %v", i, want[i], recorder.packets[i])
+		}
+	}
+}
+
+func TestConnHooks(t *testing.T) {
+	var first, last eventRecorder
+	var middle connOnly
+	hooks := ConnHooks{&first, &middle, &last}
+
+	hooks.HandleConnEvent(ConnEvent{Type: ConnEventAccepted})
+	hooks.HandleConnEvent(ConnEvent{Type: ConnEventClosed})
+	hooks.HandlePacket(ConnPlay, true, pk.Packet{ID: 0x10})
+
+	for _, r := range []*eventRecorder{&first, &last} {
+		if len(r.events) != 2 || r.events[0].Type != ConnEventAccepted || r.events[1].Type != ConnEventClosed {
+			t.Errorf("events: %v", r.events)
+		}
+		if len(r.packets) != 1 || r.packets[0] != (packetKey{state: ConnPlay, serverbound: true, id: 0x10}) {
+			t.Errorf("packets: %v", r.packets)
+		}
+	}
+	if len(middle.events) != 2 {
+		t.Errorf("events without PacketHook: %v", middle.events)
+	}
+}
+
+// orderHook appends its name to a shared slice for each event.
+type orderHook struct {
+	name  string
+	order *[]string
+}
+
+func (o orderHook) HandleConnEvent(ConnEvent) { *o.order = append(*o.order, o.name) }
+
+func TestConnHooks_order(t *testing.T) {
+	var order []string
+	hooks := ConnHooks{orderHook{"a", &order}, orderHook{"b", &order}, orderHook{"c", &order}}
+	hooks.HandleConnEvent(ConnEvent{Type: ConnEventAccepted})
+	if len(order) != 3 || order[0] != "a" || order[1] != "b" || order[2] != "c" {
+		t.Errorf("want hooks called in order, get %v", order)
+	}
+}
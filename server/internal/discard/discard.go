@@ -0,0 +1,17 @@
+// Package discard provides the logger used when no logger is set.
+package discard
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Logger drops all records.
+var Logger = slog.New(handler{})
+
+type handler struct{}
+
+func (handler) Enabled(context.Context, slog.Level) bool  { return false }
+func (handler) Handle(context.Context, slog.Record) error { return nil }
+func (h handler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h handler) WithGroup(string) slog.Handler           { return h }
@@ -0,0 +1,150 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// jsonListReloadInterval represents how often the files are checked for modification.
+const jsonListReloadInterval = time.Second * 5
+
+// jsonList is a list of entries stored in a JSON file,
+// such as whitelist.json, ops.json, banned-players.json and banned-ips.json.
+type jsonList[E any] struct {
+	path string
+	// key returns the identity of the entry, entries with the same key are replaced.
+	key func(e *E) string
+
+	entries []E
+	modTime time.Time
+	lock    sync.RWMutex
+}
+
+func newJSONList[E any](path string, key func(e *E) string) (*jsonList[E], error) {
+	l := &jsonList[E]{path: path, key: key}
+	return l, l.Reload()
+}
+
+// Reload reads the file again if it's modified since the last load.
+// A nonexistent file is treated as an empty list.
+func (l *jsonList[E]) Reload() error {
+	info, err := os.Stat(l.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		l.lock.Lock()
+		l.entries, l.modTime = nil, time.Time{}
+		l.lock.Unlock()
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if info.ModTime().Equal(l.modTime) {
+		return nil
+	}
+	data, err := os.ReadFile(l.path)
+	if err != nil {
+		return err
+	}
+	var entries []E
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	l.entries, l.modTime = entries, info.ModTime()
+	return nil
+}
+
+// Run implement Component for the list, which reloads the file when it's modified.
+// Errors are ignored, and the list is kept unchanged.
+func (l *jsonList[E]) Run(ctx context.Context) {
+	ticker := time.NewTicker(jsonListReloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = l.Reload()
+		}
+	}
+}
+
+// save writes the entries to the file. The lock must be held.
+func (l *jsonList[E]) save() error {
+	data, err := json.MarshalIndent(l.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	// Write to a temporary file first, so the list is never half written.
+	tmp, err := os.CreateTemp(filepath.Dir(l.path), filepath.Base(l.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), l.path); err != nil {
+		return err
+	}
+	if info, err := os.Stat(l.path); err == nil {
+		l.modTime = info.ModTime()
+	}
+	return nil
+}
+
+func (l *jsonList[E]) get(key string) (e E, ok bool) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	if i := l.index(key); i >= 0 {
+		return l.entries[i], true
+	}
+	return
+}
+
+func (l *jsonList[E]) index(key string) int {
+	return slices.IndexFunc(l.entries, func(e E) bool { return l.key(&e) == key })
+}
+
+// put adds or replaces the entry, and saves the file.
+func (l *jsonList[E]) put(e E) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if i := l.index(l.key(&e)); i >= 0 {
+		l.entries[i] = e
+	} else {
+		l.entries = append(l.entries, e)
+	}
+	return l.save()
+}
+
+// remove deletes the entry, and saves the file if it's found.
+func (l *jsonList[E]) remove(key string) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	i := l.index(key)
+	if i < 0 {
+		return nil
+	}
+	l.entries = slices.Delete(l.entries, i, i+1)
+	return l.save()
+}
+
+// Entries returns a copy of all entries.
+func (l *jsonList[E]) Entries() []E {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	return slices.Clone(l.entries)
+}
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestJSONList_save(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "whitelist.json")
+	whitelist, err := NewWhitelist(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); err == nil {
+		t.Error("the file shouldn't be created before the list is modified")
+	}
+
+	steve, alex := uuid.New(), uuid.New()
+	if err := whitelist.Add("Steve", steve); err != nil {
+		t.Fatal(err)
+	}
+	if err := whitelist.Add("Alex", alex); err != nil {
+		t.Fatal(err)
+	}
+	// the entry with the same key is replaced
+	if err := whitelist.Add("Steve2", steve); err != nil {
+		t.Fatal(err)
+	}
+	if err := whitelist.Remove(alex); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := NewWhitelist(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := loaded.Entries()
+	if len(entries) != 1 || entries[0].UUID != steve || entries[0].Name != "Steve2" {
+		t.Errorf("saved entries: %+v", entries)
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Errorf("temporary files are left: %v", files)
+	}
+}
+
+func TestJSONList_Reload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "whitelist.json")
+	whitelist, err := NewWhitelist(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := whitelist.Add("Steve", uuid.New()); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	modify := func(content string, modTime time.Time) error {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+		return whitelist.Reload()
+	}
+
+	// the file isn't read again if the modification time is unchanged
+	if err := modify(`[{"uuid":"069a79f4-44e9-4726-a5be-fca90e38aaf5","name":"Notch"}]`, info.ModTime()); err != nil {
+		t.Fatal(err)
+	}
+	if entries := whitelist.Entries(); len(entries) != 1 || entries[0].Name != "Steve" {
+		t.Errorf("reloaded without modification: %+v", entries)
+	}
+
+	if err := modify(`[{"uuid":"069a79f4-44e9-4726-a5be-fca90e38aaf5","name":"Notch"}]`, info.ModTime().Add(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if entries := whitelist.Entries(); len(entries) != 1 || entries[0].Name != "Notch" {
+		t.Errorf("not reloaded after modification: %+v", entries)
+	}
+
+	// the list is kept if the file is broken
+	if err := modify(`[{"uuid":`, info.ModTime().Add(time.Second*2)); err == nil {
+		t.Error("broken file should return an error")
+	}
+	if entries := whitelist.Entries(); len(entries) != 1 || entries[0].Name != "Notch" {
+		t.Errorf("broken file: %+v", entries)
+	}
+
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := whitelist.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if entries := whitelist.Entries(); len(entries) != 0 {
+		t.Errorf("removed file: %+v", entries)
+	}
+}
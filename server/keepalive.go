@@ -4,6 +4,7 @@ import (
 	"container/list"
 	"context"
 	"errors"
+	"log/slog"
 	"time"
 
 	"git.konjactw.dev/falloutBot/go-mc/chat"
@@ -15,12 +16,18 @@ const keepAliveInterval = time.Second * 15
 // keepAliveWaitInterval represents how long does the player expired
 const keepAliveWaitInterval = time.Second * 30
 
+// KeepAliveClient is a player handled by KeepAlive.
+// It's logged by the player's name and UUID if it has the methods Name() string and UUID() uuid.UUID.
 type KeepAliveClient interface {
 	SendKeepAlive(id int64)
 	SendDisconnect(reason chat.Message)
 }
 
 type KeepAlive struct {
+	// Logger receives the records of sent keep alive and timed out players.
+	// This is an optional field and can be set to nil.
+	Logger *slog.Logger
+
 	join chan KeepAliveClient
 	quit chan KeepAliveClient
 	tick chan KeepAliveClient
@@ -104,6 +111,7 @@ func (k *KeepAlive) pingPlayer(now time.Time) {
 		c := k.pingList.Remove(elem).(keepAliveItem).player
 		// Send Clientbound KeepAlive packet.
 		c.SendKeepAlive(k.keepAliveID)
+		loggerOr(k.Logger).Debug("keep alive sent", append(clientAttrs(c), "id", k.keepAliveID)...)
 		k.keepAliveID++
 		// Clientbound KeepAlive packet is sent, move the player to waiting list.
 		k.listIndex[c] = k.waitList.PushBack(
@@ -128,6 +136,7 @@ func (k *KeepAlive) tickPlayer(c KeepAliveClient) {
 	// update delay of player
 	now := time.Now()
 	delay := now.Sub(k.waitList.Remove(elem).(keepAliveItem).t)
+	loggerOr(k.Logger).Debug("keep alive received", append(clientAttrs(c), "delay", delay)...)
 	for _, f := range k.updatePlayerDelay {
 		f(c, delay)
 	}
@@ -141,6 +150,7 @@ func (k *KeepAlive) kickPlayer() {
 	if elem := k.waitList.Front(); elem != nil {
 		c := k.waitList.Remove(elem).(keepAliveItem).player
 		k.waitList.Remove(elem)
+		loggerOr(k.Logger).Info("player timed out", clientAttrs(c)...)
 		c.SendDisconnect(chat.TranslateMsg("disconnect.timeout"))
 	}
 	keepAliveSetTimer(k.waitList, k.waitTimer, keepAliveWaitInterval)
@@ -0,0 +1,38 @@
+package server
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	"git.konjactw.dev/falloutBot/go-mc/server/internal/discard"
+)
+
+// loggerOr returns l, or a logger dropping all records if l is nil.
+func loggerOr(l *slog.Logger) *slog.Logger {
+	if l == nil {
+		return discard.Logger
+	}
+	return l
+}
+
+// packetIDAttr formats the packet ID like the other error messages do.
+func packetIDAttr(id int32) slog.Attr {
+	return slog.String("packet_id", fmt.Sprintf("0x%02X", id))
+}
+
+// identifiedClient is the optional interface of the clients passed to the components,
+// which are logged by the name and the UUID of the player instead of the whole value.
+type identifiedClient interface {
+	Name() string
+	UUID() uuid.UUID
+}
+
+// clientAttrs returns the attributes identifying the client in the logs.
+func clientAttrs(c any) []any {
+	if p, ok := c.(identifiedClient); ok {
+		return []any{"name", p.Name(), "uuid", p.UUID()}
+	}
+	return []any{"client", fmt.Sprintf("%T", c)}
+}
@@ -1,9 +1,13 @@
 package server
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/rsa"
+	"errors"
 	"fmt"
+	"log/slog"
+	stdnet "net"
 	"sync"
 	"sync/atomic"
 
@@ -31,6 +35,38 @@ type LoginChecker interface {
 	CheckPlayer(name string, id uuid.UUID, protocol int32) (ok bool, reason chat.Message)
 }
 
+// LoginAddrChecker is an optional interface of LoginChecker to check the remote address of a player,
+// which is checked before LoginChecker.CheckPlayer.
+type LoginAddrChecker interface {
+	CheckAddr(addr stdnet.Addr) (ok bool, reason chat.Message)
+}
+
+// LoginCheckers combines several LoginChecker. A player is allowed only if all of them allow it,
+// and the reason of the first one rejecting the player is returned.
+type LoginCheckers []LoginChecker
+
+// CheckPlayer implements LoginChecker for LoginCheckers
+func (l LoginCheckers) CheckPlayer(name string, id uuid.UUID, protocol int32) (ok bool, reason chat.Message) {
+	for _, c := range l {
+		if ok, reason = c.CheckPlayer(name, id, protocol); !ok {
+			return
+		}
+	}
+	return true, chat.Message{}
+}
+
+// CheckAddr implements LoginAddrChecker for LoginCheckers
+func (l LoginCheckers) CheckAddr(addr stdnet.Addr) (ok bool, reason chat.Message) {
+	for _, c := range l {
+		if c, isAddrChecker := c.(LoginAddrChecker); isAddrChecker {
+			if ok, reason = c.CheckAddr(addr); !ok {
+				return
+			}
+		}
+	}
+	return true, chat.Message{}
+}
+
 // Make sure MojangLoginHandler implement LoginHandler
 var _ LoginHandler = (*MojangLoginHandler)(nil)
 
@@ -46,6 +82,14 @@ type MojangLoginHandler struct {
 	// EnforceSecureProfile enforce to check the player's profile public key
 	EnforceSecureProfile bool
 
+	// SessionService verifies the players in online mode.
+	// This is an optional field, auth.MojangSessionServer is used if it's nil.
+	SessionService auth.SessionService
+
+	// PreventProxyConnections sends the player's IP to the session service,
+	// so players can't join through a proxy with different address from the one they authenticated.
+	PreventProxyConnections bool
+
 	// Threshold set the smallest size of raw network payload to compress.
 	// Set to 0 to compress all packets. Set to -1 to disable compression.
 	Threshold int
@@ -55,6 +99,10 @@ type MojangLoginHandler struct {
 	// This is an optional field and can be set to nil.
 	LoginChecker
 
+	// Logger receives the records of the login progress, which are also passed to auth.Encrypt.
+	// This is an optional field and can be set to nil.
+	Logger *slog.Logger
+
 	// PrivateKey is the key used by encrypt the connection.
 	privateKey     atomic.Pointer[rsa.PrivateKey]
 	lockPrivateKey sync.Mutex
@@ -90,6 +138,7 @@ func (d *MojangLoginHandler) getPrivateKey() (key *rsa.PrivateKey, err error) {
 
 // AcceptLogin implement LoginHandler for MojangLoginHandler
 func (d *MojangLoginHandler) AcceptLogin(conn *net.Conn, protocol int32) (name string, id uuid.UUID, profilePubKey *user.PublicKey, properties []user.Property, err error) {
+	logger := loggerOr(d.Logger).With("addr", conn.Socket.RemoteAddr(), "protocol", protocol)
 	// login start
 	var p pk.Packet
 	err = conn.ReadPacket(&p)
@@ -97,6 +146,7 @@ func (d *MojangLoginHandler) AcceptLogin(conn *net.Conn, protocol int32) (name s
 		return
 	}
 	if packetid.ServerboundPacketID(p.ID) != packetid.ServerboundLoginHello {
+		logger.Debug("unexpected packet in login", packetIDAttr(p.ID))
 		err = wrongPacketErr{expect: int32(packetid.ServerboundLoginHello), get: p.ID}
 		return
 	}
@@ -108,18 +158,30 @@ func (d *MojangLoginHandler) AcceptLogin(conn *net.Conn, protocol int32) (name s
 	if err != nil {
 		return
 	}
+	logger.Debug("login start", "name", name, "uuid", id, "online_mode", d.OnlineMode)
 
 	// auth
 	if d.OnlineMode {
 		var serverKey *rsa.PrivateKey
 		serverKey, err = d.getPrivateKey()
 		if err != nil {
+			logger.Error("generate server key error", "error", err)
 			return
 		}
+		opts := auth.Options{SessionService: d.SessionService, Logger: logger}
+		if d.PreventProxyConnections {
+			opts.IP = addrIP(conn.Socket.RemoteAddr())
+		}
 		var resp *auth.Resp
 		// Auth, Encrypt
-		resp, err = auth.Encrypt(conn, name, serverKey)
-		if err != nil {
+		resp, err = auth.Encrypt(context.Background(), conn, name, serverKey, opts)
+		if errors.Is(err, auth.ErrNotJoined) {
+			err = LoginFailErr{reason: chat.TranslateMsg("multiplayer.disconnect.unverified_username")}
+			return
+		} else if errors.Is(err, auth.ErrAuthServersDown) {
+			err = LoginFailErr{reason: chat.TranslateMsg("multiplayer.disconnect.authservers_down")}
+			return
+		} else if err != nil {
 			return
 		}
 		name = resp.Name
@@ -129,6 +191,7 @@ func (d *MojangLoginHandler) AcceptLogin(conn *net.Conn, protocol int32) (name s
 		// offline-mode UUID
 		id = offline.NameToUUID(name)
 	}
+	logger = logger.With("name", name, "uuid", id)
 
 	// set compression
 	if d.Threshold >= 0 {
@@ -140,12 +203,20 @@ func (d *MojangLoginHandler) AcceptLogin(conn *net.Conn, protocol int32) (name s
 			return
 		}
 		conn.SetThreshold(d.Threshold)
+		logger.Debug("compression enabled", "threshold", d.Threshold)
 	}
 
 	// check if player can join (whitelist, blacklist, server full or something else)
+	if addrChecker, ok := d.LoginChecker.(LoginAddrChecker); ok {
+		if ok, result := addrChecker.CheckAddr(conn.Socket.RemoteAddr()); !ok {
+			err = LoginFailErr{reason: result}
+			return
+		}
+	}
 	if d.LoginChecker != nil {
 		if ok, result := d.CheckPlayer(name, id, protocol); !ok {
 			// player is not allowed to join the server
+			logger.Debug("player rejected by login checker", "reason", result.ClearString())
 			err = LoginFailErr{reason: result}
 			return
 		}
@@ -164,6 +235,7 @@ func (d *MojangLoginHandler) AcceptLogin(conn *net.Conn, protocol int32) (name s
 	// receive login ack
 	err = conn.ReadPacket(&p)
 	if err == nil && packetid.ServerboundPacketID(p.ID) != packetid.ServerboundLoginLoginAcknowledged {
+		logger.Debug("unexpected packet in login", packetIDAttr(p.ID))
 		err = wrongPacketErr{expect: int32(packetid.ServerboundLoginLoginAcknowledged), get: p.ID}
 	}
 	return
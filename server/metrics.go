@@ -0,0 +1,188 @@
+package server
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sync"
+
+	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
+)
+
+// Metrics is a ConnHook and PacketHook counting the connections and packets of a Server.
+// The counters can be exported in the Prometheus text format.
+//
+//	metrics := server.NewMetrics()
+//	s.ConnHook = metrics
+//	go metrics.ListenAndServe("localhost:9225")
+type Metrics struct {
+	accepted      uint64
+	active        map[ConnState]int64
+	loginSuccess  uint64
+	loginFailure  uint64
+	configFailure uint64
+	packets       map[packetKey]*packetStats
+	lock          sync.Mutex
+}
+
+// packetKey identifies a kind of packet, the same ID means different packets in different states.
+type packetKey struct {
+	state       ConnState
+	serverbound bool
+	id          int32
+}
+
+type packetStats struct {
+	count, bytes uint64
+}
+
+func NewMetrics() *Metrics {
+	return &Metrics{
+		active:  make(map[ConnState]int64),
+		packets: make(map[packetKey]*packetStats),
+	}
+}
+
+// HandleConnEvent implements ConnHook for Metrics
+func (m *Metrics) HandleConnEvent(e ConnEvent) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	switch e.Type {
+	case ConnEventAccepted:
+		m.accepted++
+		m.active[e.State]++
+	case ConnEventStateChanged:
+		m.active[e.PrevState]--
+		m.active[e.State]++
+	case ConnEventLoginSuccess:
+		m.loginSuccess++
+	case ConnEventLoginFailure:
+		m.loginFailure++
+	case ConnEventConfigFailure:
+		m.configFailure++
+	case ConnEventClosed:
+		m.active[e.State]--
+	}
+}
+
+// HandlePacket implements PacketHook for Metrics
+func (m *Metrics) HandlePacket(state ConnState, serverbound bool, p pk.Packet) {
+	size := uint64(pk.VarInt(p.ID).Len() + len(p.Data))
+	key := packetKey{state: state, serverbound: serverbound, id: p.ID}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	stats, ok := m.packets[key]
+	if !ok {
+		stats = new(packetStats)
+		m.packets[key] = stats
+	}
+	stats.count++
+	stats.bytes += size
+}
+
+// WriteTo writes the metrics in the Prometheus text exposition format.
+func (m *Metrics) WriteTo(w io.Writer) (n int64, err error) {
+	m.lock.Lock()
+	accepted, loginSuccess, loginFailure, configFailure := m.accepted, m.loginSuccess, m.loginFailure, m.configFailure
+	active := make([]ConnState, 0, len(m.active))
+	activeCount := make(map[ConnState]int64, len(m.active))
+	for state, count := range m.active {
+		active = append(active, state)
+		activeCount[state] = count
+	}
+	keys := make([]packetKey, 0, len(m.packets))
+	packets := make(map[packetKey]packetStats, len(m.packets))
+	for key, stats := range m.packets {
+		keys = append(keys, key)
+		packets[key] = *stats
+	}
+	m.lock.Unlock()
+
+	slices.Sort(active)
+	slices.SortFunc(keys, func(a, b packetKey) int {
+		if a.serverbound != b.serverbound {
+			if a.serverbound {
+				return 1
+			}
+			return -1
+		}
+		return cmp.Or(cmp.Compare(a.state, b.state), cmp.Compare(a.id, b.id))
+	})
+
+	cw := countWriter{w: bufio.NewWriter(w)}
+	metric := func(name, typ, help string) {
+		fmt.Fprintf(&cw, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+	}
+
+	metric("minecraft_connections_accepted_total", "counter", "Connections accepted by the server.")
+	fmt.Fprintf(&cw, "minecraft_connections_accepted_total %d\n", accepted)
+
+	metric("minecraft_connections", "gauge", "Connections currently open, by protocol state.")
+	for _, state := range active {
+		fmt.Fprintf(&cw, "minecraft_connections{state=%q} %d\n", state, activeCount[state])
+	}
+
+	metric("minecraft_logins_total", "counter", "Login attempts, by result.")
+	fmt.Fprintf(&cw, "minecraft_logins_total{result=\"success\"} %d\n", loginSuccess)
+	fmt.Fprintf(&cw, "minecraft_logins_total{result=\"failure\"} %d\n", loginFailure)
+
+	metric("minecraft_config_failures_total", "counter", "Connections failed in the configuration state.")
+	fmt.Fprintf(&cw, "minecraft_config_failures_total %d\n", configFailure)
+
+	metric("minecraft_packets_total", "counter", "Packets read and written, by direction, state and packet ID.")
+	for _, key := range keys {
+		fmt.Fprintf(&cw, "minecraft_packets_total{%s} %d\n", key.labels(), packets[key].count)
+	}
+	metric("minecraft_packet_bytes_total", "counter", "Uncompressed size of the packets read and written, by direction, state and packet ID.")
+	for _, key := range keys {
+		fmt.Fprintf(&cw, "minecraft_packet_bytes_total{%s} %d\n", key.labels(), packets[key].bytes)
+	}
+
+	if cw.err == nil {
+		cw.err = cw.w.Flush()
+	}
+	return cw.n, cw.err
+}
+
+func (k packetKey) labels() string {
+	direction := "clientbound"
+	if k.serverbound {
+		direction = "serverbound"
+	}
+	return fmt.Sprintf("direction=%q,state=%q,id=\"0x%02X\"", direction, k.state, k.id)
+}
+
+// ServeHTTP implements http.Handler for Metrics, which responds the metrics in the Prometheus text format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = m.WriteTo(w)
+}
+
+// ListenAndServe serves the metrics on the path "/metrics" of addr.
+// The addr is usually a local address, like "localhost:9225".
+func (m *Metrics) ListenAndServe(addr string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m)
+	return http.ListenAndServe(addr, mux)
+}
+
+// countWriter counts the bytes written, and keeps the first error.
+type countWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (c *countWriter) Write(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	c.err = err
+	return n, err
+}
@@ -0,0 +1,123 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
+)
+
+func TestMetrics_HandleConnEvent(t *testing.T) {
+	m := NewMetrics()
+	// two connections logging in, one of which then leaves
+	for range 2 {
+		m.HandleConnEvent(ConnEvent{Type: ConnEventAccepted, State: ConnHandshake})
+		m.HandleConnEvent(ConnEvent{Type: ConnEventStateChanged, PrevState: ConnHandshake, State: ConnLogin})
+		m.HandleConnEvent(ConnEvent{Type: ConnEventLoginSuccess, State: ConnLogin})
+		m.HandleConnEvent(ConnEvent{Type: ConnEventStateChanged, PrevState: ConnLogin, State: ConnConfig})
+	}
+	m.HandleConnEvent(ConnEvent{Type: ConnEventStateChanged, PrevState: ConnConfig, State: ConnPlay})
+	m.HandleConnEvent(ConnEvent{Type: ConnEventClosed, State: ConnPlay})
+	// a status ping still open
+	m.HandleConnEvent(ConnEvent{Type: ConnEventAccepted, State: ConnHandshake})
+	m.HandleConnEvent(ConnEvent{Type: ConnEventStateChanged, PrevState: ConnHandshake, State: ConnStatus})
+	// a rejected login
+	m.HandleConnEvent(ConnEvent{Type: ConnEventAccepted, State: ConnHandshake})
+	m.HandleConnEvent(ConnEvent{Type: ConnEventStateChanged, PrevState: ConnHandshake, State: ConnLogin})
+	m.HandleConnEvent(ConnEvent{Type: ConnEventLoginFailure, State: ConnLogin})
+	m.HandleConnEvent(ConnEvent{Type: ConnEventClosed, State: ConnLogin})
+
+	if m.accepted != 4 || m.loginSuccess != 2 || m.loginFailure != 1 || m.configFailure != 0 {
+		t.Errorf("counters: accepted %d, success %d, failure %d, config failure %d",
+			m.accepted, m.loginSuccess, m.loginFailure, m.configFailure)
+	}
+	want := map[ConnState]int64{
+		ConnHandshake: 0,
+		ConnStatus:    1,
+		ConnLogin:     0,
+		ConnConfig:    1,
+		ConnPlay:      0,
+	}
+	for state, count := range want {
+		if m.active[state] != count {
+			t.Errorf("connections in %v: want %d, get %d", state, count, m.active[state])
+		}
+	}
+}
+
+func TestMetrics_HandlePacket(t *testing.T) {
+	m := NewMetrics()
+	m.HandlePacket(ConnLogin, true, pk.Packet{ID: 0x00, Data: []byte("Steve")})
+	m.HandlePacket(ConnLogin, true, pk.Packet{ID: 0x00, Data: []byte("Alex")})
+	// the same ID in other states or directions is another packet
+	m.HandlePacket(ConnLogin, false, pk.Packet{ID: 0x00, Data: []byte{1}})
+	m.HandlePacket(ConnPlay, true, pk.Packet{ID: 0x00})
+	// IDs longer than one byte
+	m.HandlePacket(ConnPlay, false, pk.Packet{ID: 0x80})
+
+	want := map[packetKey]packetStats{
+		{state: ConnLogin, serverbound: true, id: 0x00}:  {count: 2, bytes: 1 + 5 + 1 + 4},
+		{state: ConnLogin, serverbound: false, id: 0x00}: {count: 1, bytes: 2},
+		{state: ConnPlay, serverbound: true, id: 0x00}:   {count: 1, bytes: 1},
+		{state: ConnPlay, serverbound: false, id: 0x80}:  {count: 1, bytes: 2},
+	}
+	if len(m.packets) != len(want) {
+		t.Errorf("want %d kinds of packets, get %d", len(want), len(m.packets))
+	}
+	for key, stats := range want {
+		if got := m.packets[key]; got == nil || *got != stats {
+			t.Errorf("%s: want %+v, get %+v", key.labels(), stats, got)
+		}
+	}
+}
+
+func TestMetrics_WriteTo(t *testing.T) {
+	m := NewMetrics()
+	m.HandleConnEvent(ConnEvent{Type: ConnEventAccepted, State: ConnHandshake})
+	m.HandleConnEvent(ConnEvent{Type: ConnEventStateChanged, PrevState: ConnHandshake, State: ConnLogin})
+	m.HandleConnEvent(ConnEvent{Type: ConnEventLoginSuccess, State: ConnLogin})
+	m.HandleConnEvent(ConnEvent{Type: ConnEventStateChanged, PrevState: ConnLogin, State: ConnConfig})
+	m.HandleConnEvent(ConnEvent{Type: ConnEventConfigFailure, State: ConnConfig})
+	m.HandlePacket(ConnPlay, true, pk.Packet{ID: 0x1A, Data: []byte{1, 2}})
+	m.HandlePacket(ConnLogin, true, pk.Packet{ID: 0x00, Data: []byte{1}})
+	m.HandlePacket(ConnLogin, false, pk.Packet{ID: 0x02})
+
+	var sb strings.Builder
+	n, err := m.WriteTo(&sb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(sb.Len()) {
+		t.Errorf("WriteTo returns %d, but %d bytes are written", n, sb.Len())
+	}
+
+	want := `# HELP minecraft_connections_accepted_total Connections accepted by the server.
+# TYPE minecraft_connections_accepted_total counter
+minecraft_connections_accepted_total 1
+# HELP minecraft_connections Connections currently open, by protocol state.
+# TYPE minecraft_connections gauge
+minecraft_connections{state="handshake"} 0
+minecraft_connections{state="login"} 0
+minecraft_connections{state="configuration"} 1
+# HELP minecraft_logins_total Login attempts, by result.
+# TYPE minecraft_logins_total counter
+minecraft_logins_total{result="success"} 1
+minecraft_logins_total{result="failure"} 0
+# HELP minecraft_config_failures_total Connections failed in the configuration state.
+# TYPE minecraft_config_failures_total counter
+minecraft_config_failures_total 1
+# HELP minecraft_packets_total Packets read and written, by direction, state and packet ID.
+# TYPE minecraft_packets_total counter
+minecraft_packets_total{direction="clientbound",state="login",id="0x02"} 1
+minecraft_packets_total{direction="serverbound",state="login",id="0x00"} 1
+minecraft_packets_total{direction="serverbound",state="play",id="0x1A"} 1
+# HELP minecraft_packet_bytes_total Uncompressed size of the packets read and written, by direction, state and packet ID.
+# TYPE minecraft_packet_bytes_total counter
+minecraft_packet_bytes_total{direction="clientbound",state="login",id="0x02"} 1
+minecraft_packet_bytes_total{direction="serverbound",state="login",id="0x00"} 2
+minecraft_packet_bytes_total{direction="serverbound",state="play",id="0x1A"} 3
+`
+	if got := sb.String(); got != want {
+		t.Errorf("want:\n%s\nget:\n%s", want, got)
+	}
+}
@@ -3,6 +3,7 @@ package server
 import (
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"image"
 	"image/png"
 	"strings"
@@ -17,6 +18,7 @@ import (
 
 // ListPingHandler collect server running status info
 // which is used to handle client ping and list progress.
+// The status requests are not answered if the ListPingHandler of the Server is nil.
 type ListPingHandler interface {
 	// Name of the server.
 	// Vanilla server uses its version name, like "1.19.3".
@@ -61,6 +63,10 @@ func (s *Server) acceptListPing(conn *net.Conn, clientProtocol int32) {
 
 		switch packetid.ClientboundPacketID(p.ID) {
 		case packetid.ClientboundStatusStatusResponse: // List
+			if s.ListPingHandler == nil {
+				// the status is disabled
+				return
+			}
 			var resp []byte
 			resp, err = s.listResp(clientProtocol)
 			if err != nil {
@@ -115,20 +121,10 @@ type PingInfo struct {
 func NewPingInfo(name string, protocol int, motd chat.Message, icon image.Image) (p *PingInfo) {
 	var favIcon string
 	if icon != nil {
-		if !icon.Bounds().Size().Eq(image.Point{X: 64, Y: 64}) {
-			panic("icon size is not 64x64")
-		}
-		// Encode icon into string "data:image/png;base64,......" format
-		var sb strings.Builder
-		sb.WriteString("data:image/png;base64,")
-		w := base64.NewEncoder(base64.StdEncoding, &sb)
-		if err := png.Encode(w, icon); err != nil {
+		var err error
+		if favIcon, err = EncodeFavIcon(icon); err != nil {
 			panic(err)
 		}
-		if err := w.Close(); err != nil {
-			panic(err)
-		}
-		favIcon = sb.String()
 	}
 	p = &PingInfo{
 		name:        name,
@@ -139,6 +135,25 @@ func NewPingInfo(name string, protocol int, motd chat.Message, icon image.Image)
 	return
 }
 
+// EncodeFavIcon encodes the icon into the format of ListPingHandler.FavIcon.
+// The size of the icon must be 64x64.
+func EncodeFavIcon(icon image.Image) (string, error) {
+	if !icon.Bounds().Size().Eq(image.Point{X: 64, Y: 64}) {
+		return "", errors.New("icon size is not 64x64")
+	}
+	// Encode icon into string "data:image/png;base64,......" format
+	var sb strings.Builder
+	sb.WriteString("data:image/png;base64,")
+	w := base64.NewEncoder(base64.StdEncoding, &sb)
+	if err := png.Encode(w, icon); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
 func (p *PingInfo) Name() string {
 	return p.name
 }
@@ -17,8 +17,7 @@ type PlayerListClient interface {
 type PlayerList struct {
 	maxPlayer int
 	players   map[PlayerListClient]PlayerSample
-	// Only the field players is protected by this Mutex.
-	// Because others field never change after created.
+	// The fields players and maxPlayer are protected by this Mutex.
 	playersLock sync.Mutex
 }
 
@@ -59,9 +58,19 @@ func (p *PlayerList) CheckPlayer(string, uuid.UUID, int32) (ok bool, reason chat
 }
 
 func (p *PlayerList) MaxPlayer() int {
+	p.playersLock.Lock()
+	defer p.playersLock.Unlock()
 	return p.maxPlayer
 }
 
+// SetMaxPlayer changes the max number of players.
+// Players already online are not kicked if there are more than the new limit.
+func (p *PlayerList) SetMaxPlayer(maxPlayers int) {
+	p.playersLock.Lock()
+	defer p.playersLock.Unlock()
+	p.maxPlayer = maxPlayers
+}
+
 func (p *PlayerList) OnlinePlayer() int {
 	p.playersLock.Lock()
 	defer p.playersLock.Unlock()
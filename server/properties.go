@@ -0,0 +1,372 @@
+package server
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Properties is the content of server.properties.
+//
+// Only the keys used by this package are declared as fields,
+// all other keys are kept in Others, so they are not lost when the file is written back.
+type Properties struct {
+	MOTD       string `properties:"motd"`
+	MaxPlayers int    `properties:"max-players"`
+	ServerIP   string `properties:"server-ip"`
+	ServerPort int    `properties:"server-port"`
+
+	OnlineMode           bool `properties:"online-mode"`
+	EnforceSecureProfile bool `properties:"enforce-secure-profile"`
+	// NetworkCompressionThreshold is the smallest size of packets to compress, -1 disables the compression.
+	NetworkCompressionThreshold int  `properties:"network-compression-threshold"`
+	EnableStatus                bool `properties:"enable-status"`
+	HideOnlinePlayers           bool `properties:"hide-online-players"`
+	PreventProxyConnections     bool `properties:"prevent-proxy-connections"`
+	AcceptsTransfers            bool `properties:"accepts-transfers"`
+	LogIPs                      bool `properties:"log-ips"`
+
+	WhiteList        bool `properties:"white-list"`
+	EnforceWhitelist bool `properties:"enforce-whitelist"`
+
+	EnableRCON              bool   `properties:"enable-rcon"`
+	RCONPort                int    `properties:"rcon.port"`
+	RCONPassword            string `properties:"rcon.password"`
+	BroadcastRCONToOps      bool   `properties:"broadcast-rcon-to-ops"`
+	EnableQuery             bool   `properties:"enable-query"`
+	QueryPort               int    `properties:"query.port"`
+	OpPermissionLevel       int    `properties:"op-permission-level"`
+	FunctionPermissionLevel int    `properties:"function-permission-level"`
+	PlayerIdleTimeout       int    `properties:"player-idle-timeout"`
+	RateLimit               int    `properties:"rate-limit"`
+	EnforceSecureChat       bool   `properties:"enforce-secure-chat"`
+	BroadcastConsoleToOps   bool   `properties:"broadcast-console-to-ops"`
+
+	LevelName          string `properties:"level-name"`
+	LevelSeed          string `properties:"level-seed"`
+	GameMode           string `properties:"gamemode"`
+	Difficulty         string `properties:"difficulty"`
+	Hardcore           bool   `properties:"hardcore"`
+	PVP                bool   `properties:"pvp"`
+	ViewDistance       int    `properties:"view-distance"`
+	SimulationDistance int    `properties:"simulation-distance"`
+	SpawnProtection    int    `properties:"spawn-protection"`
+
+	// Others is the keys not declared above.
+	Others map[string]string `properties:"-"`
+}
+
+// DefaultProperties returns the properties used by the vanilla server when the keys are absent.
+func DefaultProperties() Properties {
+	return Properties{
+		MOTD:       "A Minecraft Server",
+		MaxPlayers: 20,
+		ServerPort: 25565,
+
+		OnlineMode:                  true,
+		EnforceSecureProfile:        true,
+		NetworkCompressionThreshold: 256,
+		EnableStatus:                true,
+		LogIPs:                      true,
+
+		RCONPort:                25575,
+		BroadcastRCONToOps:      true,
+		QueryPort:               25565,
+		OpPermissionLevel:       4,
+		FunctionPermissionLevel: 2,
+		EnforceSecureChat:       true,
+		BroadcastConsoleToOps:   true,
+
+		LevelName:          "world",
+		GameMode:           "survival",
+		Difficulty:         "easy",
+		PVP:                true,
+		ViewDistance:       10,
+		SimulationDistance: 10,
+		SpawnProtection:    16,
+	}
+}
+
+// PropertyErr is returned when the value of a key can't be parsed as the type of the field.
+type PropertyErr struct {
+	Key, Value string
+	Err        error
+}
+
+func (p PropertyErr) Error() string {
+	return "invalid value " + strconv.Quote(p.Value) + " of property " + p.Key + ": " + p.Err.Error()
+}
+
+func (p PropertyErr) Unwrap() error {
+	return p.Err
+}
+
+// ReadProperties parses the properties from r, absent keys are set to the default values.
+// The format is the one of java.util.Properties, except that the file is read as UTF-8.
+func ReadProperties(r io.Reader) (p Properties, err error) {
+	p = DefaultProperties()
+	kv, err := parseProperties(r)
+	if err != nil {
+		return
+	}
+	v := reflect.ValueOf(&p).Elem()
+	fields := propertyFields()
+	for key, value := range kv {
+		i, ok := fields[key]
+		if !ok {
+			if p.Others == nil {
+				p.Others = make(map[string]string)
+			}
+			p.Others[key] = value
+			continue
+		}
+		if err = setProperty(v.Field(i), value); err != nil {
+			return p, PropertyErr{Key: key, Value: value, Err: err}
+		}
+	}
+	return
+}
+
+// LoadProperties reads the properties from the file, usually server.properties.
+// Like the vanilla server, the file is created with the default values if it doesn't exist.
+func LoadProperties(path string) (Properties, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		p := DefaultProperties()
+		return p, p.Save(path)
+	} else if err != nil {
+		return Properties{}, err
+	}
+	defer f.Close()
+	return ReadProperties(f)
+}
+
+// Save writes the properties to the file.
+func (p *Properties) Save(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := p.WriteTo(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// WriteTo writes the properties in the format of server.properties.
+// The declared keys are written first, in the order of the fields, then the others sorted by key.
+func (p *Properties) WriteTo(w io.Writer) (n int64, err error) {
+	var sb strings.Builder
+	sb.WriteString("#Minecraft server properties\n#")
+	sb.WriteString(time.Now().Format(time.UnixDate))
+	sb.WriteByte('\n')
+
+	v := reflect.ValueOf(p).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		key := t.Field(i).Tag.Get("properties")
+		if key == "-" {
+			continue
+		}
+		writeProperty(&sb, key, fmtProperty(v.Field(i)))
+	}
+	keys := make([]string, 0, len(p.Others))
+	for k := range p.Others {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		writeProperty(&sb, k, p.Others[k])
+	}
+
+	n1, err := io.WriteString(w, sb.String())
+	return int64(n1), err
+}
+
+// propertyFields maps the keys to the index of the fields of Properties.
+func propertyFields() map[string]int {
+	t := reflect.TypeOf(Properties{})
+	fields := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if key := t.Field(i).Tag.Get("properties"); key != "-" {
+			fields[key] = i
+		}
+	}
+	return fields
+}
+
+func setProperty(v reflect.Value, value string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		// Vanilla server only treats "true" as true, but reject obvious mistakes here.
+		b, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int:
+		i, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(i))
+	default:
+		panic("unsupported property type " + v.Type().String())
+	}
+	return nil
+}
+
+func fmtProperty(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int:
+		return strconv.FormatInt(v.Int(), 10)
+	default:
+		panic("unsupported property type " + v.Type().String())
+	}
+}
+
+// parseProperties reads the key-value pairs in the format of java.util.Properties.
+func parseProperties(r io.Reader) (map[string]string, error) {
+	kv := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	var logical strings.Builder
+	continued := false
+	for scanner.Scan() {
+		line := scanner.Text()
+		if continued {
+			// The leading whitespaces of continuation lines are ignored.
+			line = strings.TrimLeft(line, " \t\f")
+		} else {
+			line = strings.TrimLeft(line, " \t\f")
+			if line == "" || line[0] == '#' || line[0] == '!' {
+				continue
+			}
+			logical.Reset()
+		}
+		// A line ends with an odd number of backslashes continues on the next line.
+		trailing := len(line) - len(strings.TrimRight(line, "\\"))
+		continued = trailing%2 == 1
+		if continued {
+			line = line[:len(line)-1]
+		}
+		logical.WriteString(line)
+		if !continued {
+			key, value := splitProperty(logical.String())
+			kv[key] = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if continued {
+		key, value := splitProperty(logical.String())
+		kv[key] = value
+	}
+	return kv, nil
+}
+
+// splitProperty splits the logical line to the key and the value, and unescapes both.
+func splitProperty(line string) (key, value string) {
+	end := len(line)
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		if c == '\\' {
+			i++
+			continue
+		}
+		if c == '=' || c == ':' || c == ' ' || c == '\t' || c == '\f' {
+			end = i
+			break
+		}
+	}
+	key, rest := line[:end], line[end:]
+	rest = strings.TrimLeft(rest, " \t\f")
+	if rest != "" && (rest[0] == '=' || rest[0] == ':') {
+		rest = strings.TrimLeft(rest[1:], " \t\f")
+	}
+	return unescapeProperty(key), unescapeProperty(rest)
+}
+
+func unescapeProperty(s string) string {
+	if !strings.ContainsRune(s, '\\') {
+		return s
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '\\' || i+1 == len(s) {
+			sb.WriteByte(c)
+			continue
+		}
+		i++
+		switch c = s[i]; c {
+		case 't':
+			sb.WriteByte('\t')
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 'f':
+			sb.WriteByte('\f')
+		case 'u':
+			if i+4 < len(s) {
+				if r, err := strconv.ParseUint(s[i+1:i+5], 16, 16); err == nil {
+					sb.WriteRune(rune(r))
+					i += 4
+					break
+				}
+			}
+			sb.WriteByte(c)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+func writeProperty(sb *strings.Builder, key, value string) {
+	escapeProperty(sb, key, true)
+	sb.WriteByte('=')
+	escapeProperty(sb, value, false)
+	sb.WriteByte('\n')
+}
+
+func escapeProperty(sb *strings.Builder, s string, isKey bool) {
+	for i, r := range s {
+		switch r {
+		case '\\', '=', ':', '#', '!':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case ' ':
+			// Spaces only need to be escaped in keys and at the start of values.
+			if isKey || i == 0 {
+				sb.WriteByte('\\')
+			}
+			sb.WriteByte(' ')
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\f':
+			sb.WriteString(`\f`)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+}
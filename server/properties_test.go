@@ -0,0 +1,74 @@
+package server
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestParseProperties(t *testing.T) {
+	for _, tc := range []struct {
+		name, input string
+		want        map[string]string
+	}{
+		{"separators", "a=1\nb:2\nc 3\nd = 4\n  e\t:\t5", map[string]string{"a": "1", "b": "2", "c": "3", "d": "4", "e": "5"}},
+		{"empty value", "a=\nb\n", map[string]string{"a": "", "b": ""}},
+		{"comments", "# a=1\n! b=2\n   # c=3\nd=4 # not a comment", map[string]string{"d": "4 # not a comment"}},
+		{"continuation", "motd=Hello \\\n    World\\\n!", map[string]string{"motd": "Hello World!"}},
+		{"continuation at EOF", "motd=Hello\\", map[string]string{"motd": "Hello"}},
+		{"escaped backslash", "path=C:\\\\\nnext=1", map[string]string{"path": "C:\\", "next": "1"}},
+		{"escaped separators", "a\\=b\\:c\\ d=e\\=f", map[string]string{"a=b:c d": "e=f"}},
+		{"escapes", "a=\\t\\n\\r\\f\\x", map[string]string{"a": "\t\n\r\fx"}},
+		{"unicode", "motd=\\u00a7aGreen \\u4e2d", map[string]string{"motd": "§aGreen 中"}},
+		{"invalid unicode", "a=\\u12", map[string]string{"a": "u12"}},
+	} {
+		got, err := parseProperties(strings.NewReader(tc.input))
+		if err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: got %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestReadProperties(t *testing.T) {
+	p, err := ReadProperties(strings.NewReader("max-players=5\nenable-status=false\ncustom-key=x"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.MaxPlayers != 5 || p.EnableStatus || p.MOTD != "A Minecraft Server" || p.Others["custom-key"] != "x" {
+		t.Errorf("unexpected properties: %+v", p)
+	}
+
+	var propErr PropertyErr
+	if _, err := ReadProperties(strings.NewReader("max-players=many")); !errors.As(err, &propErr) || propErr.Key != "max-players" {
+		t.Errorf("expect PropertyErr, got %v", err)
+	}
+	if _, err := ReadProperties(strings.NewReader("pvp=yes")); !errors.As(err, &propErr) {
+		t.Errorf("expect PropertyErr, got %v", err)
+	}
+}
+
+func TestProperties_WriteTo(t *testing.T) {
+	p := DefaultProperties()
+	p.MOTD = " \\u00a7 #1: a=b\nnext line"
+	p.LevelSeed = strconv.Itoa(-42)
+	p.Others = map[string]string{"key with spaces": "!value"}
+
+	var buf bytes.Buffer
+	if _, err := p.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadProperties(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, p) {
+		t.Errorf("got %+v, want %+v", got, p)
+	}
+}
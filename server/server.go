@@ -25,16 +25,23 @@
 //
 // The implement of Gameplay is provided at [go-mc/server]. You can also write your version.
 //
+// Instead of combining the Gate modules manually,
+// NewBootstrap builds them from server.properties and the other files used by the vanilla server.
+//
 // [go-mc/server]: https://github.com/go-mc/server
 package server
 
 import (
 	"errors"
-	"log"
+	"log/slog"
+
+	"github.com/google/uuid"
 
+	"git.konjactw.dev/falloutBot/go-mc/chat"
 	"git.konjactw.dev/falloutBot/go-mc/data/packetid"
 	"git.konjactw.dev/falloutBot/go-mc/net"
 	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
+	"git.konjactw.dev/falloutBot/go-mc/yggdrasil/user"
 )
 
 const (
@@ -43,67 +50,150 @@ const (
 )
 
 type Server struct {
-	*log.Logger
+	// Logger receives the records of the connections handled by the Server.
+	// This is an optional field and can be set to nil.
+	*slog.Logger
 	ListPingHandler
 	LoginHandler
 	ConfigHandler
 	GamePlay
+
+	// AcceptsTransfers allows the clients transferred from other servers to log in.
+	AcceptsTransfers bool
+
+	// ShutdownMessage is sent to the players when Shutdown is called.
+	// "multiplayer.disconnect.server_shutdown" is sent if it's nil.
+	ShutdownMessage *chat.Message
+
+	// ConnHook receives the events of the connections, such as login failures.
+	// It can also implement PacketHook to watch the packets.
+	// This is an optional field and can be set to nil.
+	ConnHook ConnHook
+
+	tracker connTracker
 }
 
+// Listen accepts connections on the addr until Shutdown is called,
+// after which ErrServerClosed is returned.
 func (s *Server) Listen(addr string) error {
 	listener, err := net.ListenMC(addr)
 	if err != nil {
 		return err
 	}
+	if !s.tracker.addListener(listener) {
+		_ = listener.Close()
+		return ErrServerClosed
+	}
+	defer s.tracker.removeListener(listener)
+	loggerOr(s.Logger).Info("server listening", "addr", listener.Addr())
 
 	for {
 		conn, err := listener.Accept()
 		if err != nil {
+			if s.tracker.isClosed() {
+				return ErrServerClosed
+			}
 			return err
 		}
 		go s.AcceptConn(&conn)
 	}
 }
 
+// AcceptConn handles the connection until it's finished.
+// The connection is tracked for Shutdown, and closed immediately if the server is shutting down.
 func (s *Server) AcceptConn(conn *net.Conn) {
 	defer conn.Close()
+	c := s.tracker.addConn(conn)
+	if c == nil {
+		return
+	}
+	defer s.tracker.removeConn(c)
+	defer s.sendShutdown(c)
+
+	logger := loggerOr(s.Logger).With("addr", conn.Socket.RemoteAddr())
+	events := connEvents{hook: s.ConnHook, conn: c, addr: conn.Socket.RemoteAddr(), logger: logger}
+	if h, ok := s.ConnHook.(PacketHook); ok {
+		conn.Observer = packetObserver{conn: c, hook: h}
+	}
+	events.fire(ConnEvent{Type: ConnEventAccepted})
+	logger.Debug("connection accepted")
+	defer func() {
+		events.fire(ConnEvent{Type: ConnEventClosed})
+		logger.Debug("connection closed", "state", c.State())
+	}()
+
 	protocol, intention, err := s.handshake(conn)
 	if err != nil {
+		logger.Debug("handshake error", "error", err)
 		return
 	}
+	events.protocol = protocol
+	events.fire(ConnEvent{Type: ConnEventHandshake, Intention: intention})
+	logger = logger.With("protocol", protocol)
+	events.logger = logger
+	logger.Debug("handshake", "intention", intention)
 
 	switch intention {
 	case 1: // list ping
+		events.setState(ConnStatus)
 		s.acceptListPing(conn, protocol)
-	case 2: // login
-		name, id, profilePubKey, properties, err := s.AcceptLogin(conn, protocol)
+	case 2, 3: // login, transfer
+		events.setState(ConnLogin)
+		var (
+			name          string
+			id            uuid.UUID
+			profilePubKey *user.PublicKey
+			properties    []user.Property
+		)
+		if intention == 3 && !s.AcceptsTransfers {
+			err = LoginFailErr{reason: chat.TranslateMsg("multiplayer.disconnect.transfers_disabled")}
+		} else {
+			name, id, profilePubKey, properties, err = s.AcceptLogin(conn, protocol)
+		}
 		if err != nil {
 			var loginErr LoginFailErr
+			var reason *chat.Message
 			if errors.As(err, &loginErr) {
+				reason = &loginErr.reason
 				_ = conn.WritePacket(pk.Marshal(
 					packetid.ClientboundLoginLoginDisconnect,
 					loginErr.reason,
 				))
 			}
-			if s.Logger != nil {
-				s.Logger.Printf("client %v login error: %v", conn.Socket.RemoteAddr(), err)
+			events.fire(ConnEvent{Type: ConnEventLoginFailure, Err: err, Reason: reason})
+			if reason != nil {
+				logger.Info("login rejected", "reason", reason.ClearString())
+			} else {
+				logger.Warn("login error", "error", err)
 			}
 			return
 		}
-		s.AcceptConfig(conn)
+		events.name, events.id = name, id
+		events.fire(ConnEvent{Type: ConnEventLoginSuccess})
+		logger = logger.With("name", name, "uuid", id)
+		events.logger = logger
+		logger.Info("player logged in")
+
+		events.setState(ConnConfig)
+		err = s.AcceptConfig(conn)
 		if err != nil {
 			var configErr ConfigFailErr
+			var reason *chat.Message
 			if errors.As(err, &configErr) {
+				reason = &configErr.reason
 				_ = conn.WritePacket(pk.Marshal(
 					packetid.ClientboundConfigDisconnect,
 					configErr.reason,
 				))
 			}
-			if s.Logger != nil {
-				s.Logger.Printf("client %v config error: %v", conn.Socket.RemoteAddr(), err)
-			}
+			events.fire(ConnEvent{Type: ConnEventConfigFailure, Err: err, Reason: reason})
+			logger.Warn("configuration error", "error", err)
 			return
 		}
+		events.setState(ConnPlay)
 		s.AcceptPlayer(name, id, profilePubKey, properties, protocol, conn)
+		logger.Info("player left")
+	default:
+		logger.Debug("unknown intention", "intention", intention)
 	}
 }
@@ -0,0 +1,263 @@
+package server
+
+import (
+	"context"
+	"errors"
+	stdnet "net"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"git.konjactw.dev/falloutBot/go-mc/chat"
+	"git.konjactw.dev/falloutBot/go-mc/data/packetid"
+	"git.konjactw.dev/falloutBot/go-mc/net"
+	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
+)
+
+// ErrServerClosed is returned by Server.Listen after Server.Shutdown is called.
+var ErrServerClosed = errors.New("server closed")
+
+// shutdownWriteTimeout limits the time of sending the disconnect packet
+// if the context passed to Shutdown doesn't have a deadline.
+const shutdownWriteTimeout = time.Second * 5
+
+// ConnState is the protocol state of a connection.
+type ConnState int32
+
+const (
+	ConnHandshake ConnState = iota
+	ConnStatus
+	ConnLogin
+	ConnConfig
+	ConnPlay
+)
+
+func (c ConnState) String() string {
+	switch c {
+	case ConnHandshake:
+		return "handshake"
+	case ConnStatus:
+		return "status"
+	case ConnLogin:
+		return "login"
+	case ConnConfig:
+		return "configuration"
+	case ConnPlay:
+		return "play"
+	default:
+		return "ConnState(" + strconv.Itoa(int(c)) + ")"
+	}
+}
+
+// ShutdownHandler is an optional interface of GamePlay.
+//
+// If the GamePlay implements it, Server.Shutdown calls Shutdown instead of
+// writing the Disconnect packet to the players directly, which is racing with the GamePlay's writing.
+// The implementation should disconnect all players with the reason, and make AcceptPlayer return.
+type ShutdownHandler interface {
+	Shutdown(reason chat.Message)
+}
+
+// ShutdownErr is returned by Server.Shutdown if some connections are not finished before the deadline.
+// Those connections are closed forcibly.
+type ShutdownErr struct {
+	// Remaining is the remote address of the connections not finished.
+	Remaining []stdnet.Addr
+	err       error
+}
+
+func (s ShutdownErr) Error() string {
+	return "server shutdown: " + strconv.Itoa(len(s.Remaining)) + " connections not finished: " + s.err.Error()
+}
+
+func (s ShutdownErr) Unwrap() error {
+	return s.err
+}
+
+// connTracker records the listeners and the connections of a Server.
+type connTracker struct {
+	listeners map[*net.Listener]struct{}
+	conns     map[*trackedConn]struct{}
+	closed    bool
+	lock      sync.Mutex
+	// wg counts the running AcceptConn.
+	wg sync.WaitGroup
+}
+
+type trackedConn struct {
+	*net.Conn
+	state atomic.Int32
+	// shutdown is set if the reading side is closed by Server.Shutdown,
+	// and the goroutine handling the connection should send the Disconnect packet.
+	shutdown atomic.Bool
+}
+
+func (c *trackedConn) State() ConnState {
+	return ConnState(c.state.Load())
+}
+
+// swapState sets the state and returns the previous one.
+func (c *trackedConn) swapState(state ConnState) ConnState {
+	return ConnState(c.state.Swap(int32(state)))
+}
+
+// addListener returns false if the server is closed.
+func (t *connTracker) addListener(l *net.Listener) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.closed {
+		return false
+	}
+	if t.listeners == nil {
+		t.listeners = make(map[*net.Listener]struct{})
+	}
+	t.listeners[l] = struct{}{}
+	return true
+}
+
+func (t *connTracker) removeListener(l *net.Listener) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.listeners, l)
+}
+
+// addConn returns nil if the server is closed.
+func (t *connTracker) addConn(conn *net.Conn) *trackedConn {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.closed {
+		return nil
+	}
+	if t.conns == nil {
+		t.conns = make(map[*trackedConn]struct{})
+	}
+	c := &trackedConn{Conn: conn}
+	t.conns[c] = struct{}{}
+	t.wg.Add(1)
+	return c
+}
+
+func (t *connTracker) removeConn(c *trackedConn) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.conns, c)
+	t.wg.Done()
+}
+
+func (t *connTracker) isClosed() bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.closed
+}
+
+// close closes all listeners, and returns the connections at this time.
+func (t *connTracker) close() (conns []*trackedConn) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.closed = true
+	for l := range t.listeners {
+		_ = l.Close()
+	}
+	conns = make([]*trackedConn, 0, len(t.conns))
+	for c := range t.conns {
+		conns = append(conns, c)
+	}
+	return
+}
+
+func (t *connTracker) remaining() (addrs []stdnet.Addr) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for c := range t.conns {
+		addrs = append(addrs, c.Socket.RemoteAddr())
+		_ = c.Close()
+	}
+	return
+}
+
+// Shutdown stops the server gracefully.
+//
+// It closes all listeners, and the reading side of all connections in the login,
+// configuration and play state, so the goroutines handling them stop and send the ShutdownMessage.
+// Their writing isn't touched by Shutdown, which races with the handling goroutine.
+// If the GamePlay implements ShutdownHandler, the players are disconnected by it instead.
+// Connections in other states are just closed.
+// Shutdown waits for all connections to finish, including the GamePlay.
+//
+// If the context is done before all connections finished, the remaining connections are closed,
+// and a ShutdownErr wrapping the context's error is returned.
+func (s *Server) Shutdown(ctx context.Context) error {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(shutdownWriteTimeout)
+	}
+
+	logger := loggerOr(s.Logger)
+	handler, hasHandler := s.GamePlay.(ShutdownHandler)
+	conns := s.tracker.close()
+	logger.Info("server shutting down", "connections", len(conns))
+	for _, c := range conns {
+		state := c.State()
+		if state == ConnPlay && hasHandler {
+			continue
+		}
+		reader, ok := c.Socket.(interface{ CloseRead() error })
+		if state < ConnLogin || !ok {
+			_ = c.Close()
+			continue
+		}
+		c.shutdown.Store(true)
+		_ = c.Socket.SetWriteDeadline(deadline)
+		_ = reader.CloseRead()
+	}
+	if hasHandler {
+		handler.Shutdown(s.shutdownReason())
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.tracker.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		remaining := s.tracker.remaining()
+		logger.Warn("connections not finished before shutdown deadline", "remaining", remaining)
+		return ShutdownErr{Remaining: remaining, err: ctx.Err()}
+	}
+}
+
+func (s *Server) shutdownReason() chat.Message {
+	if s.ShutdownMessage != nil {
+		return *s.ShutdownMessage
+	}
+	return chat.TranslateMsg("multiplayer.disconnect.server_shutdown")
+}
+
+// sendShutdown sends the ShutdownMessage if the reading side of the connection is closed by Shutdown.
+// It's called by the goroutine handling the connection after it stops.
+func (s *Server) sendShutdown(c *trackedConn) {
+	if !c.shutdown.Load() {
+		return
+	}
+	if p, ok := disconnectPacket(c.State(), s.shutdownReason()); ok {
+		_ = c.WritePacket(p)
+	}
+}
+
+// disconnectPacket returns the packet to disconnect the client in the state.
+func disconnectPacket(state ConnState, reason chat.Message) (pk.Packet, bool) {
+	switch state {
+	case ConnLogin:
+		return pk.Marshal(packetid.ClientboundLoginLoginDisconnect, chat.JsonMessage(reason)), true
+	case ConnConfig:
+		return pk.Marshal(packetid.ClientboundConfigDisconnect, reason), true
+	case ConnPlay:
+		return pk.Marshal(packetid.ClientboundDisconnect, reason), true
+	default:
+		return pk.Packet{}, false
+	}
+}
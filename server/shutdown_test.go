@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	stdnet "net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"git.konjactw.dev/falloutBot/go-mc/chat"
+	"git.konjactw.dev/falloutBot/go-mc/data/packetid"
+	"git.konjactw.dev/falloutBot/go-mc/net"
+	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
+	"git.konjactw.dev/falloutBot/go-mc/yggdrasil/user"
+)
+
+// blockingLogin waits for the login start packet, which the client never sends.
+type blockingLogin chan struct{}
+
+func (b blockingLogin) AcceptLogin(conn *net.Conn, _ int32) (string, uuid.UUID, *user.PublicKey, []user.Property, error) {
+	close(b)
+	var p pk.Packet
+	return "", uuid.Nil, nil, nil, conn.ReadPacket(&p)
+}
+
+func TestServer_Shutdown(t *testing.T) {
+	l, err := stdnet.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	reading := make(blockingLogin)
+	s := &Server{LoginHandler: reading}
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		s.AcceptConn(net.WrapConn(conn))
+	}()
+
+	client, err := net.DialMC(l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	err = client.WritePacket(pk.Marshal(
+		0x00, // handshake
+		pk.VarInt(ProtocolVersion),
+		pk.String("localhost"),
+		pk.UnsignedShort(25565),
+		pk.VarInt(2),
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-reading
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	if err := s.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	var p pk.Packet
+	if err := client.ReadPacket(&p); err != nil {
+		t.Fatal(err)
+	}
+	if packetid.ClientboundPacketID(p.ID) != packetid.ClientboundLoginLoginDisconnect {
+		t.Fatalf("want LoginDisconnect, get %#02X", p.ID)
+	}
+	var reason chat.JsonMessage
+	if err := p.Scan(&reason); err != nil {
+		t.Fatal(err)
+	}
+	if want := chat.TranslateMsg("multiplayer.disconnect.server_shutdown"); reason.Translate != want.Translate {
+		t.Errorf("want reason %q, get %q", want.Translate, reason.Translate)
+	}
+}
@@ -0,0 +1,360 @@
+package server
+
+import (
+	"context"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"git.konjactw.dev/falloutBot/go-mc/chat"
+	"git.konjactw.dev/falloutBot/go-mc/chat/sign"
+	"git.konjactw.dev/falloutBot/go-mc/data/packetid"
+	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
+	"git.konjactw.dev/falloutBot/go-mc/yggdrasil/user"
+)
+
+// tabListFlushInterval represents how often the batched updates are sent, which is a game tick.
+const tabListFlushInterval = time.Millisecond * 50
+
+// PlayerInfoAction is the bit set of the actions in the PlayerInfoUpdate packet.
+type PlayerInfoAction byte
+
+const (
+	PlayerInfoAddPlayer PlayerInfoAction = 1 << iota
+	PlayerInfoInitializeChat
+	PlayerInfoUpdateGameMode
+	PlayerInfoUpdateListed
+	PlayerInfoUpdateLatency
+	PlayerInfoUpdateDisplayName
+	PlayerInfoUpdateListOrder
+	PlayerInfoUpdateHat
+
+	PlayerInfoAll PlayerInfoAction = 0xFF
+)
+
+// TabListEntry is a player shown in the tab list.
+type TabListEntry struct {
+	UUID       uuid.UUID
+	Name       string
+	Properties []user.Property
+	// ChatSession is nil if the player doesn't have a secure chat session.
+	ChatSession *sign.Session
+	GameMode    int32
+	Listed      bool
+	Latency     time.Duration
+	// DisplayName is nil if the name of the player is shown.
+	DisplayName *chat.Message
+	// ListOrder sorts the entries, higher first.
+	ListOrder int32
+	ShowHat   bool
+}
+
+// PlayerInfoUpdate is the content of the PlayerInfoUpdate packet.
+// Only the fields selected by Actions are encoded for each entry.
+type PlayerInfoUpdate struct {
+	Actions PlayerInfoAction
+	Entries []TabListEntry
+}
+
+func (p PlayerInfoUpdate) WriteTo(w io.Writer) (n int64, err error) {
+	n, err = pk.Tuple{pk.UnsignedByte(p.Actions), pk.VarInt(len(p.Entries))}.WriteTo(w)
+	if err != nil {
+		return
+	}
+	var n1 int64
+	for _, e := range p.Entries {
+		n1, err = e.fields(p.Actions).WriteTo(w)
+		n += n1
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (p *PlayerInfoUpdate) ReadFrom(r io.Reader) (n int64, err error) {
+	var length pk.VarInt
+	n, err = pk.Tuple{(*pk.UnsignedByte)(&p.Actions), &length}.ReadFrom(r)
+	if err != nil {
+		return
+	}
+	p.Entries = make([]TabListEntry, length)
+	var n1 int64
+	for i := range p.Entries {
+		n1, err = p.Entries[i].readFrom(r, p.Actions)
+		n += n1
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// fields returns the encoders of the UUID and the fields selected by actions.
+func (e *TabListEntry) fields(actions PlayerInfoAction) pk.Tuple {
+	fields := pk.Tuple{pk.UUID(e.UUID)}
+	if actions&PlayerInfoAddPlayer != 0 {
+		fields = append(fields, pk.String(e.Name), pk.Array(e.Properties))
+	}
+	if actions&PlayerInfoInitializeChat != 0 {
+		session := pk.OptionEncoder[sign.Session]{Has: e.ChatSession != nil}
+		if e.ChatSession != nil {
+			session.Val = *e.ChatSession
+		}
+		fields = append(fields, session)
+	}
+	if actions&PlayerInfoUpdateGameMode != 0 {
+		fields = append(fields, pk.VarInt(e.GameMode))
+	}
+	if actions&PlayerInfoUpdateListed != 0 {
+		fields = append(fields, pk.Boolean(e.Listed))
+	}
+	if actions&PlayerInfoUpdateLatency != 0 {
+		fields = append(fields, pk.VarInt(e.Latency.Milliseconds()))
+	}
+	if actions&PlayerInfoUpdateDisplayName != 0 {
+		displayName := pk.Option[chat.Message, *chat.Message]{Has: e.DisplayName != nil}
+		if e.DisplayName != nil {
+			displayName.Val = *e.DisplayName
+		}
+		fields = append(fields, displayName)
+	}
+	if actions&PlayerInfoUpdateListOrder != 0 {
+		fields = append(fields, pk.VarInt(e.ListOrder))
+	}
+	if actions&PlayerInfoUpdateHat != 0 {
+		fields = append(fields, pk.Boolean(e.ShowHat))
+	}
+	return fields
+}
+
+func (e *TabListEntry) readFrom(r io.Reader, actions PlayerInfoAction) (n int64, err error) {
+	var (
+		session                  pk.Option[sign.Session, *sign.Session]
+		displayName              pk.Option[chat.Message, *chat.Message]
+		gameMode, latency, order pk.VarInt
+		fields                   = pk.Tuple{(*pk.UUID)(&e.UUID)}
+	)
+	if actions&PlayerInfoAddPlayer != 0 {
+		fields = append(fields, (*pk.String)(&e.Name), pk.Array(&e.Properties))
+	}
+	if actions&PlayerInfoInitializeChat != 0 {
+		fields = append(fields, &session)
+	}
+	if actions&PlayerInfoUpdateGameMode != 0 {
+		fields = append(fields, &gameMode)
+	}
+	if actions&PlayerInfoUpdateListed != 0 {
+		fields = append(fields, (*pk.Boolean)(&e.Listed))
+	}
+	if actions&PlayerInfoUpdateLatency != 0 {
+		fields = append(fields, &latency)
+	}
+	if actions&PlayerInfoUpdateDisplayName != 0 {
+		fields = append(fields, &displayName)
+	}
+	if actions&PlayerInfoUpdateListOrder != 0 {
+		fields = append(fields, &order)
+	}
+	if actions&PlayerInfoUpdateHat != 0 {
+		fields = append(fields, (*pk.Boolean)(&e.ShowHat))
+	}
+	n, err = fields.ReadFrom(r)
+	if err != nil {
+		return
+	}
+	e.ChatSession = session.Pointer()
+	e.DisplayName = displayName.Pointer()
+	e.GameMode = int32(gameMode)
+	e.Latency = time.Duration(latency) * time.Millisecond
+	e.ListOrder = int32(order)
+	return
+}
+
+// TabListClient is the connection receiving the tab list.
+type TabListClient interface {
+	SendPacket(p pk.Packet)
+}
+
+// TabList maintains the tab list of all connected clients.
+//
+// Changes of the entries are batched and sent every game tick,
+// so several updates of a player in a tick are merged into one packet.
+type TabList struct {
+	join         chan tabListJoin
+	quit         chan TabListClient
+	update       chan tabListUpdate
+	headerFooter chan [2]chat.Message
+
+	clients map[TabListClient]uuid.UUID
+	entries map[uuid.UUID]*TabListEntry
+	header  chat.Message
+	footer  chat.Message
+	// pending is the actions of each entry waiting to be sent.
+	pending map[uuid.UUID]PlayerInfoAction
+	removed []uuid.UUID
+}
+
+type tabListJoin struct {
+	client TabListClient
+	entry  TabListEntry
+}
+
+type tabListUpdate struct {
+	// Either id or client is used to find the entry.
+	id      uuid.UUID
+	client  TabListClient
+	actions PlayerInfoAction
+	apply   func(e *TabListEntry)
+}
+
+func NewTabList() *TabList {
+	return &TabList{
+		join:         make(chan tabListJoin),
+		quit:         make(chan TabListClient),
+		update:       make(chan tabListUpdate),
+		headerFooter: make(chan [2]chat.Message),
+		clients:      make(map[TabListClient]uuid.UUID),
+		entries:      make(map[uuid.UUID]*TabListEntry),
+		pending:      make(map[uuid.UUID]PlayerInfoAction),
+	}
+}
+
+// ClientJoin sends the whole tab list to the client, and adds its entry to the list of everyone.
+func (t *TabList) ClientJoin(client TabListClient, entry TabListEntry) {
+	t.join <- tabListJoin{client: client, entry: entry}
+}
+
+// ClientLeft removes the entry of the client from the tab list.
+func (t *TabList) ClientLeft(client TabListClient) { t.quit <- client }
+
+// Update modifies the entry of the player by f, and sends the fields selected by actions.
+// Nothing happens if the player isn't in the tab list.
+func (t *TabList) Update(id uuid.UUID, actions PlayerInfoAction, f func(e *TabListEntry)) {
+	t.update <- tabListUpdate{id: id, actions: actions, apply: f}
+}
+
+// UpdateLatency can be registered by KeepAlive.AddPlayerDelayUpdateHandler
+// to publish the latency of players. The KeepAliveClient should be the TabListClient joined before.
+func (t *TabList) UpdateLatency(c KeepAliveClient, delay time.Duration) {
+	client, ok := c.(TabListClient)
+	if !ok {
+		return
+	}
+	t.update <- tabListUpdate{
+		client:  client,
+		actions: PlayerInfoUpdateLatency,
+		apply:   func(e *TabListEntry) { e.Latency = delay },
+	}
+}
+
+// SetHeaderFooter sets the text shown above and below the tab list.
+func (t *TabList) SetHeaderFooter(header, footer chat.Message) {
+	t.headerFooter <- [2]chat.Message{header, footer}
+}
+
+// Run implement Component for TabList
+func (t *TabList) Run(ctx context.Context) {
+	ticker := time.NewTicker(tabListFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case j := <-t.join:
+			t.addPlayer(j.client, j.entry)
+		case c := <-t.quit:
+			t.removePlayer(c)
+		case u := <-t.update:
+			t.updatePlayer(u)
+		case hf := <-t.headerFooter:
+			t.header, t.footer = hf[0], hf[1]
+			t.broadcast(t.headerFooterPacket())
+		case <-ticker.C:
+			t.flush()
+		}
+	}
+}
+
+func (t *TabList) addPlayer(c TabListClient, entry TabListEntry) {
+	all := PlayerInfoUpdate{Actions: PlayerInfoAll, Entries: make([]TabListEntry, 0, len(t.entries))}
+	for _, e := range t.entries {
+		all.Entries = append(all.Entries, *e)
+	}
+	if len(all.Entries) > 0 {
+		c.SendPacket(pk.Marshal(packetid.ClientboundPlayerInfoUpdate, all))
+	}
+	c.SendPacket(t.headerFooterPacket())
+
+	// The entry of the new player is sent to everyone including itself in the next flush,
+	// after the removal of its previous entry if it rejoins in the same tick.
+	t.clients[c] = entry.UUID
+	t.entries[entry.UUID] = &entry
+	t.pending[entry.UUID] = PlayerInfoAll
+}
+
+func (t *TabList) removePlayer(c TabListClient) {
+	id, ok := t.clients[c]
+	if !ok {
+		return
+	}
+	delete(t.clients, c)
+	delete(t.entries, id)
+	delete(t.pending, id)
+	t.removed = append(t.removed, id)
+}
+
+func (t *TabList) updatePlayer(u tabListUpdate) {
+	id := u.id
+	if u.client != nil {
+		var ok bool
+		if id, ok = t.clients[u.client]; !ok {
+			return
+		}
+	}
+	e, ok := t.entries[id]
+	if !ok {
+		return
+	}
+	u.apply(e)
+	t.pending[id] |= u.actions
+}
+
+// flush sends the batched updates, grouped by the set of actions.
+func (t *TabList) flush() {
+	if len(t.removed) > 0 {
+		t.broadcast(pk.Marshal(packetid.ClientboundPlayerInfoRemove, pk.Array(t.removed)))
+		t.removed = t.removed[:0]
+	}
+	if len(t.pending) == 0 {
+		return
+	}
+	groups := make(map[PlayerInfoAction][]TabListEntry)
+	for id, actions := range t.pending {
+		groups[actions] = append(groups[actions], *t.entries[id])
+		delete(t.pending, id)
+	}
+	actions := make([]PlayerInfoAction, 0, len(groups))
+	for a := range groups {
+		actions = append(actions, a)
+	}
+	slices.Sort(actions)
+	for _, a := range actions {
+		t.broadcast(pk.Marshal(
+			packetid.ClientboundPlayerInfoUpdate,
+			PlayerInfoUpdate{Actions: a, Entries: groups[a]},
+		))
+	}
+}
+
+func (t *TabList) broadcast(p pk.Packet) {
+	for c := range t.clients {
+		c.SendPacket(p)
+	}
+}
+
+func (t *TabList) headerFooterPacket() pk.Packet {
+	return pk.Marshal(packetid.ClientboundTabList, t.header, t.footer)
+}
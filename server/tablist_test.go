@@ -0,0 +1,101 @@
+package server
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"git.konjactw.dev/falloutBot/go-mc/chat"
+	"git.konjactw.dev/falloutBot/go-mc/data/packetid"
+	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
+	"git.konjactw.dev/falloutBot/go-mc/yggdrasil/user"
+)
+
+func TestPlayerInfoUpdate_ReadFrom(t *testing.T) {
+	name := chat.Text("Steve")
+	want := PlayerInfoUpdate{
+		Actions: PlayerInfoAll &^ PlayerInfoInitializeChat,
+		Entries: []TabListEntry{
+			{
+				UUID:        uuid.New(),
+				Name:        "Steve",
+				Properties:  []user.Property{{Name: "textures", Value: "e30="}},
+				GameMode:    1,
+				Listed:      true,
+				Latency:     120 * time.Millisecond,
+				DisplayName: &name,
+				ListOrder:   3,
+				ShowHat:     true,
+			},
+			{UUID: uuid.New(), Name: "Alex"},
+		},
+	}
+	var buf bytes.Buffer
+	if _, err := want.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var got PlayerInfoUpdate
+	if _, err := got.ReadFrom(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if got.Entries[0].DisplayName == nil || got.Entries[0].DisplayName.ClearString() != "Steve" {
+		t.Errorf("display name: %v", got.Entries[0].DisplayName)
+	}
+	got.Entries[0].DisplayName = want.Entries[0].DisplayName
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	// only the selected fields are encoded
+	buf.Reset()
+	latency := PlayerInfoUpdate{Actions: PlayerInfoUpdateLatency, Entries: want.Entries[:1]}
+	if _, err := latency.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 1+1+16+1 {
+		t.Errorf("latency update is %d bytes", buf.Len())
+	}
+}
+
+type tabListClient []pk.Packet
+
+func (c *tabListClient) SendPacket(p pk.Packet) { *c = append(*c, p) }
+
+// entries returns the UUIDs of the entries added to the client.
+func (c *tabListClient) entries(t *testing.T) (ids []uuid.UUID) {
+	for _, p := range *c {
+		if packetid.ClientboundPacketID(p.ID) != packetid.ClientboundPlayerInfoUpdate {
+			continue
+		}
+		var update PlayerInfoUpdate
+		if err := p.Scan(&update); err != nil {
+			t.Fatal(err)
+		}
+		if update.Actions&PlayerInfoAddPlayer != 0 {
+			for _, e := range update.Entries {
+				ids = append(ids, e.UUID)
+			}
+		}
+	}
+	return
+}
+
+func TestTabList_addPlayer(t *testing.T) {
+	tl := NewTabList()
+	var steve, alex tabListClient
+	steveID, alexID := uuid.New(), uuid.New()
+	tl.addPlayer(&steve, TabListEntry{UUID: steveID, Name: "Steve"})
+	tl.flush()
+	tl.addPlayer(&alex, TabListEntry{UUID: alexID, Name: "Alex"})
+	tl.flush()
+
+	if got, want := steve.entries(t), []uuid.UUID{steveID, alexID}; !reflect.DeepEqual(got, want) {
+		t.Errorf("steve got %v, want %v", got, want)
+	}
+	if got, want := alex.entries(t), []uuid.UUID{steveID, alexID}; !reflect.DeepEqual(got, want) {
+		t.Errorf("alex got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"net"
+
+	"github.com/google/uuid"
+
+	"git.konjactw.dev/falloutBot/go-mc/chat"
+)
+
+// WhitelistEntry is an entry of whitelist.json.
+type WhitelistEntry struct {
+	UUID uuid.UUID `json:"uuid"`
+	Name string    `json:"name"`
+}
+
+// Whitelist is a LoginChecker only allowing the players in whitelist.json.
+type Whitelist struct {
+	*jsonList[WhitelistEntry]
+}
+
+// NewWhitelist loads the whitelist from the file, usually whitelist.json.
+// The file is created when the list is modified if it doesn't exist.
+func NewWhitelist(path string) (*Whitelist, error) {
+	l, err := newJSONList(path, func(e *WhitelistEntry) string { return e.UUID.String() })
+	return &Whitelist{l}, err
+}
+
+// CheckPlayer implements LoginChecker for Whitelist
+func (w *Whitelist) CheckPlayer(_ string, id uuid.UUID, _ int32) (ok bool, reason chat.Message) {
+	if _, ok := w.get(id.String()); !ok {
+		return false, chat.TranslateMsg("multiplayer.disconnect.not_whitelisted")
+	}
+	return true, chat.Message{}
+}
+
+func (w *Whitelist) Add(name string, id uuid.UUID) error {
+	return w.put(WhitelistEntry{UUID: id, Name: name})
+}
+
+func (w *Whitelist) Remove(id uuid.UUID) error {
+	return w.remove(id.String())
+}
+
+// OpEntry is an entry of ops.json.
+type OpEntry struct {
+	UUID                uuid.UUID `json:"uuid"`
+	Name                string    `json:"name"`
+	Level               int       `json:"level"`
+	BypassesPlayerLimit bool      `json:"bypassesPlayerLimit"`
+}
+
+// OpList is the operators in ops.json.
+//
+// OpList isn't a LoginChecker itself, but it can make operators bypass other checkers.
+type OpList struct {
+	*jsonList[OpEntry]
+}
+
+// NewOpList loads the operators from the file, usually ops.json.
+// The file is created when the list is modified if it doesn't exist.
+func NewOpList(path string) (*OpList, error) {
+	l, err := newJSONList(path, func(e *OpEntry) string { return e.UUID.String() })
+	return &OpList{l}, err
+}
+
+// Level returns the permission level of the player, or 0 if it's not an operator.
+func (o *OpList) Level(id uuid.UUID) int {
+	e, _ := o.get(id.String())
+	return e.Level
+}
+
+func (o *OpList) Add(e OpEntry) error {
+	return o.put(e)
+}
+
+func (o *OpList) Remove(id uuid.UUID) error {
+	return o.remove(id.String())
+}
+
+// Bypass returns a LoginChecker allowing all operators, and checking other players by c.
+// This is how the vanilla server lets operators join when the whitelist is on.
+// The addresses are still checked by c if it's a LoginAddrChecker, so the operators can't bypass IP bans.
+func (o *OpList) Bypass(c LoginChecker) LoginChecker {
+	return opBypassChecker{ops: o, checker: c}
+}
+
+// BypassPlayerLimit is the same as Bypass, but only for the operators with bypassesPlayerLimit set.
+// It's usually used with PlayerList.
+func (o *OpList) BypassPlayerLimit(c LoginChecker) LoginChecker {
+	return opBypassChecker{ops: o, checker: c, playerLimit: true}
+}
+
+type opBypassChecker struct {
+	ops         *OpList
+	checker     LoginChecker
+	playerLimit bool
+}
+
+func (o opBypassChecker) CheckPlayer(name string, id uuid.UUID, protocol int32) (ok bool, reason chat.Message) {
+	if e, ok := o.ops.get(id.String()); ok && (!o.playerLimit || e.BypassesPlayerLimit) {
+		return true, chat.Message{}
+	}
+	return o.checker.CheckPlayer(name, id, protocol)
+}
+
+// CheckAddr implements LoginAddrChecker for opBypassChecker.
+// The address is checked before the player is known, so it's delegated to the checker for everyone.
+func (o opBypassChecker) CheckAddr(addr net.Addr) (ok bool, reason chat.Message) {
+	if c, isAddrChecker := o.checker.(LoginAddrChecker); isAddrChecker {
+		return c.CheckAddr(addr)
+	}
+	return true, chat.Message{}
+}
@@ -0,0 +1,54 @@
+package server
+
+import (
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestOpList_Bypass(t *testing.T) {
+	dir := t.TempDir()
+	ops, err := NewOpList(filepath.Join(dir, "ops.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	whitelist, err := NewWhitelist(filepath.Join(dir, "whitelist.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ipBans, err := NewIPBanList(filepath.Join(dir, "banned-ips.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	op := uuid.New()
+	if err := ops.Add(OpEntry{UUID: op, Name: "op", Level: 4}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ipBans.Ban(IPBan{IP: "10.0.0.1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	checker := ops.Bypass(LoginCheckers{whitelist, ipBans})
+	if ok, _ := checker.CheckPlayer("op", op, 0); !ok {
+		t.Error("operator should bypass the whitelist")
+	}
+	if ok, _ := checker.CheckPlayer("player", uuid.New(), 0); ok {
+		t.Error("player not in the whitelist shouldn't be allowed")
+	}
+
+	addrChecker, ok := checker.(LoginAddrChecker)
+	if !ok {
+		t.Fatal("Bypass should implement LoginAddrChecker")
+	}
+	if ok, _ := addrChecker.CheckAddr(&net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 25565}); ok {
+		t.Error("banned address should be rejected")
+	}
+	if ok, _ := addrChecker.CheckAddr(&net.TCPAddr{IP: net.IPv4(10, 0, 0, 2), Port: 25565}); !ok {
+		t.Error("other address should be allowed")
+	}
+	if ok, _ := ops.Bypass(whitelist).(LoginAddrChecker).CheckAddr(&net.TCPAddr{IP: net.IPv4(10, 0, 0, 1)}); !ok {
+		t.Error("address should be allowed without an address checker")
+	}
+}
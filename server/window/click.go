@@ -0,0 +1,420 @@
+package window
+
+import (
+	"slices"
+
+	"git.konjactw.dev/falloutBot/go-mc/level/item"
+)
+
+// ClickMode is the mode field of the ContainerClick packet.
+type ClickMode int32
+
+const (
+	Pickup ClickMode = iota
+	QuickMove
+	Swap
+	Clone
+	Throw
+	QuickCraft
+	PickupAll
+)
+
+// SlotOutside is the slot index of a click outside the window.
+const SlotOutside = -999
+
+// The stages and types of the quick craft, a.k.a. dragging.
+const (
+	quickCraftStart = iota
+	quickCraftAdd
+	quickCraftEnd
+)
+
+const (
+	quickCraftCharitable = iota // split evenly, left button
+	quickCraftGreedy            // one item each, right button
+	quickCraftClone             // full stack each, middle button in creative mode
+)
+
+type quickCraft struct {
+	status int8
+	typ    int8
+	slots  []int
+}
+
+func (q *quickCraft) reset() {
+	q.status = quickCraftStart
+	q.slots = q.slots[:0]
+}
+
+// click applies a click to the window.
+// It returns false if the click is invalid, which means the client is out of sync.
+func (m *Manager) click(w *Window, slot int, button int8, mode ClickMode) bool {
+	if mode == QuickCraft {
+		return m.clickQuickCraft(w, slot, button)
+	}
+	if m.quickCraft.status != quickCraftStart {
+		// Any other click cancels the dragging.
+		m.quickCraft.reset()
+		return true
+	}
+	if slot != SlotOutside && (slot < -1 || slot >= w.Len()) {
+		return false
+	}
+	switch mode {
+	case Pickup, QuickMove:
+		if button != 0 && button != 1 {
+			return false
+		}
+		switch {
+		case slot == SlotOutside:
+			if !m.Carried.IsEmpty() {
+				n := m.Carried.Count
+				if button == 1 {
+					n = 1
+				}
+				m.drop(take(&m.Carried, n))
+			}
+		case slot < 0:
+		case mode == QuickMove:
+			m.quickMove(w, slot)
+		default:
+			m.pickup(w, slot, button)
+		}
+	case Swap:
+		if slot < 0 {
+			return false
+		}
+		return m.swap(w, slot, button)
+	case Clone:
+		if m.Creative && m.Carried.IsEmpty() && slot >= 0 {
+			if s := w.Slot(slot); !s.IsEmpty() {
+				m.Carried = s.Clone()
+				m.Carried.Count = m.MaxStackSize(*s)
+			}
+		}
+	case Throw:
+		if m.Carried.IsEmpty() && slot >= 0 {
+			s := w.Slot(slot)
+			n := int32(1)
+			if button == 1 {
+				n = s.Count
+			}
+			if !s.IsEmpty() {
+				m.drop(take(s, n))
+			}
+		}
+	case PickupAll:
+		if slot >= 0 {
+			m.pickupAll(w, slot, button)
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// pickup is the left or right click on a slot.
+func (m *Manager) pickup(w *Window, i int, button int8) {
+	s, c := w.Slot(i), &m.Carried
+	switch {
+	case s.IsEmpty():
+		if !c.IsEmpty() && w.mayPlace(i, *c) {
+			n := c.Count
+			if button == 1 {
+				n = 1
+			}
+			m.place(s, c, n)
+		}
+	case c.IsEmpty():
+		n := s.Count
+		if button == 1 {
+			n = (s.Count + 1) / 2
+		}
+		*c = take(s, n)
+	case s.IsSameItem(*c):
+		if w.mayPlace(i, *c) {
+			n := c.Count
+			if button == 1 {
+				n = 1
+			}
+			m.place(s, c, n)
+		} else {
+			// e.g. taking items from the crafting result slot
+			m.place(c, s, s.Count)
+		}
+	default:
+		if w.mayPlace(i, *c) && c.Count <= m.MaxStackSize(*c) {
+			*s, *c = *c, *s
+		}
+	}
+}
+
+// swap exchanges the slot with a hotbar slot or the offhand slot.
+func (m *Manager) swap(w *Window, i int, button int8) bool {
+	var hs *item.Stack
+	switch {
+	case button >= 0 && button < 9:
+		hs = m.Inventory.Hotbar(int(button))
+	case button == 40:
+		hs = &m.Inventory[InventoryOffhand]
+	default:
+		return false
+	}
+	s := w.Slot(i)
+	if s == hs || !hs.IsEmpty() && !w.mayPlace(i, *hs) {
+		return true
+	}
+	*s, *hs = *hs, *s
+	return true
+}
+
+// quickMove is the shift click, which moves the stack to the other part of the window.
+func (m *Manager) quickMove(w *Window, i int) {
+	s := w.Slot(i)
+	if s.IsEmpty() {
+		return
+	}
+	size := len(w.container.Slots())
+	switch {
+	case w.Menu == PlayerInventory:
+		switch {
+		case i < InventoryMainStart:
+			m.moveTo(w, s, InventoryMainStart, InventoryOffhand, false)
+		case i < InventoryHotbarStart:
+			m.moveTo(w, s, InventoryHotbarStart, InventoryOffhand, false)
+		case i < InventoryOffhand:
+			m.moveTo(w, s, InventoryMainStart, InventoryHotbarStart, false)
+		default:
+			m.moveTo(w, s, InventoryMainStart, InventoryOffhand, false)
+		}
+	case !w.Menu.hasPlayerInventory():
+	case i < size:
+		m.moveTo(w, s, size, w.Len(), true)
+	default:
+		// Try to move into the container first, then between the main inventory and the hotbar.
+		hotbar := size + InventoryHotbarStart - InventoryMainStart
+		if !m.moveTo(w, s, 0, size, false) {
+			if i < hotbar {
+				m.moveTo(w, s, hotbar, w.Len(), false)
+			} else {
+				m.moveTo(w, s, size, hotbar, false)
+			}
+		}
+	}
+}
+
+// moveTo moves the stack into the slots in range [start, end).
+// Stacks of the same item are filled first, then the first empty slot.
+// It reports whether anything is moved.
+func (m *Manager) moveTo(w *Window, src *item.Stack, start, end int, reverse bool) (moved bool) {
+	maxCount := m.MaxStackSize(*src)
+	if maxCount > 1 {
+		for _, i := range slotRange(start, end, reverse) {
+			dst := w.Slot(i)
+			if src.IsEmpty() {
+				break
+			}
+			if !dst.IsEmpty() && dst.IsSameItem(*src) && dst.Count < maxCount {
+				m.place(dst, src, src.Count)
+				moved = true
+			}
+		}
+	}
+	for _, i := range slotRange(start, end, reverse) {
+		dst := w.Slot(i)
+		if src.IsEmpty() {
+			break
+		}
+		if dst.IsEmpty() && w.mayPlace(i, *src) {
+			m.place(dst, src, src.Count)
+			moved = true
+			break
+		}
+	}
+	return
+}
+
+// pickupAll is the double click, which collects the same item from the whole window to the carried stack.
+func (m *Manager) pickupAll(w *Window, i int, button int8) {
+	c := &m.Carried
+	if s := w.Slot(i); c.IsEmpty() || !s.IsEmpty() && w.mayPlace(i, *s) {
+		return
+	}
+	maxCount := m.MaxStackSize(*c)
+	// Non-full stacks are collected in the first round, full stacks in the second round.
+	for round := 0; round < 2; round++ {
+		for _, j := range slotRange(0, w.Len(), button != 0) {
+			if c.Count >= maxCount {
+				return
+			}
+			s := w.Slot(j)
+			if s.IsEmpty() || !s.IsSameItem(*c) || !w.mayPlace(j, *s) {
+				// Result slots, which items can't be placed into, are skipped.
+				continue
+			}
+			if round != 0 || s.Count != maxCount {
+				m.place(c, s, s.Count)
+			}
+		}
+	}
+}
+
+// clickQuickCraft handles the dragging.
+// The client sends a start click, an add click for each slot, and an end click.
+func (m *Manager) clickQuickCraft(w *Window, slot int, button int8) bool {
+	q := &m.quickCraft
+	last := q.status
+	q.status = button & 3
+	switch {
+	case last != q.status && (last != quickCraftAdd || q.status != quickCraftEnd):
+		q.reset()
+		return false
+	case m.Carried.IsEmpty():
+		q.reset()
+	case q.status == quickCraftStart:
+		q.typ = button >> 2 & 3
+		if q.typ == quickCraftCharitable || q.typ == quickCraftGreedy || q.typ == quickCraftClone && m.Creative {
+			q.status = quickCraftAdd
+			q.slots = q.slots[:0]
+		} else {
+			q.reset()
+			return false
+		}
+	case q.status == quickCraftAdd:
+		s := w.Slot(slot)
+		if s == nil {
+			q.reset()
+			return false
+		}
+		if m.canQuickCraft(w, slot, len(q.slots)) && !slices.Contains(q.slots, slot) {
+			q.slots = append(q.slots, slot)
+		}
+	case q.status == quickCraftEnd:
+		slots, typ := q.slots, q.typ
+		q.reset()
+		if len(slots) == 1 {
+			// Dragging over a single slot is the same as clicking it.
+			if typ == quickCraftClone {
+				return true
+			}
+			m.pickup(w, slots[0], typ)
+			return true
+		}
+		if len(slots) == 0 {
+			return true
+		}
+		c := &m.Carried
+		maxCount := m.MaxStackSize(*c)
+		var each int32
+		switch typ {
+		case quickCraftCharitable:
+			each = c.Count / int32(len(slots))
+		case quickCraftGreedy:
+			each = 1
+		case quickCraftClone:
+			each = maxCount
+		}
+		remain := c.Count
+		for _, i := range slots {
+			if !m.canQuickCraft(w, i, len(slots)-1) {
+				continue
+			}
+			s := w.Slot(i)
+			count := min(each+s.Count, maxCount)
+			remain -= count - s.Count
+			*s = c.Clone()
+			s.Count = count
+		}
+		if typ == quickCraftClone {
+			// Items are created in creative mode, the carried stack is kept.
+			remain = c.Count
+		}
+		c.Count = remain
+		if c.Count <= 0 {
+			*c = item.Stack{}
+		}
+	default:
+		q.reset()
+		return false
+	}
+	return true
+}
+
+// canQuickCraft reports whether the carried stack can be dragged to the slot,
+// with n slots already dragged.
+func (m *Manager) canQuickCraft(w *Window, i, n int) bool {
+	s, c := w.Slot(i), m.Carried
+	if !s.IsEmpty() && (!s.IsSameItem(c) || s.Count > m.MaxStackSize(c)) {
+		return false
+	}
+	return w.mayPlace(i, c) && (m.quickCraft.typ == quickCraftClone || int(c.Count) > n)
+}
+
+// place moves up to n items from src to dst, which must be empty or the same item.
+func (m *Manager) place(dst, src *item.Stack, n int32) {
+	limit := m.MaxStackSize(*src)
+	if !dst.IsEmpty() {
+		limit -= dst.Count
+	}
+	n = min(n, limit, src.Count)
+	if n <= 0 {
+		return
+	}
+	if dst.IsEmpty() {
+		*dst = take(src, n)
+	} else {
+		dst.Count += n
+		take(src, n)
+	}
+}
+
+// placeBack puts the stack into the player inventory, and drops what's left if the inventory is full.
+func (m *Manager) placeBack(s item.Stack) {
+	if s.IsEmpty() {
+		return
+	}
+	// The hotbar is preferred to the main inventory.
+	slots := append(
+		slotRange(InventoryHotbarStart, InventoryOffhand, false),
+		slotRange(InventoryMainStart, InventoryHotbarStart, false)...,
+	)
+	for _, i := range slots {
+		if dst := &m.Inventory[i]; !dst.IsEmpty() && dst.IsSameItem(s) {
+			m.place(dst, &s, s.Count)
+		}
+	}
+	for _, i := range slots {
+		if dst := &m.Inventory[i]; dst.IsEmpty() {
+			m.place(dst, &s, s.Count)
+		}
+	}
+	m.drop(s)
+}
+
+func (m *Manager) drop(s item.Stack) {
+	if m.Drop != nil && !s.IsEmpty() {
+		m.Drop(s)
+	}
+}
+
+// take splits n items from the stack.
+func take(s *item.Stack, n int32) item.Stack {
+	n = min(n, s.Count)
+	taken := s.Clone()
+	taken.Count = n
+	if s.Count -= n; s.Count <= 0 {
+		*s = item.Stack{}
+	}
+	return taken
+}
+
+func slotRange(start, end int, reverse bool) []int {
+	slots := make([]int, 0, end-start)
+	for i := start; i < end; i++ {
+		slots = append(slots, i)
+	}
+	if reverse {
+		slices.Reverse(slots)
+	}
+	return slots
+}
@@ -0,0 +1,85 @@
+package window
+
+import (
+	"git.konjactw.dev/falloutBot/go-mc/level/item"
+	"git.konjactw.dev/falloutBot/go-mc/save"
+)
+
+// The layout of the player inventory, which is also the slot index of the window 0.
+const (
+	InventoryCraftingResult = 0
+	InventoryCraftingStart  = 1  // 2x2 crafting grid
+	InventoryArmorStart     = 5  // head, chest, legs, feet
+	InventoryMainStart      = 9  // 3 rows of the main inventory
+	InventoryHotbarStart    = 36 // 9 slots of the hotbar
+	InventoryOffhand        = 45
+	InventorySize           = 46
+)
+
+// Inventory is the player inventory, stored in the layout of the window 0.
+//
+// Other windows show the main inventory and the hotbar after their own slots,
+// in the same order as they are stored here.
+type Inventory [InventorySize]item.Stack
+
+// Slots implements Container for Inventory.
+func (inv *Inventory) Slots() []item.Stack { return inv[:] }
+
+// MayPlace implements Container for Inventory.
+// Nothing can be placed into the crafting result slot.
+func (inv *Inventory) MayPlace(i int, _ item.Stack) bool {
+	return i != InventoryCraftingResult
+}
+
+// Hotbar returns the i-th slot of the hotbar.
+func (inv *Inventory) Hotbar(i int) *item.Stack {
+	return &inv[InventoryHotbarStart+i]
+}
+
+// saveSlot converts the slot index used in the player data to the layout of Inventory.
+// It returns -1 if the slot isn't a part of the inventory.
+func saveSlot(slot byte) int {
+	switch {
+	case slot < 9: // hotbar
+		return InventoryHotbarStart + int(slot)
+	case slot < 36: // main inventory
+		return int(slot)
+	case slot >= 100 && slot <= 103: // feet, legs, chest, head
+		return InventoryArmorStart + 103 - int(slot)
+	case slot == 150: // -106, offhand
+		return InventoryOffhand
+	}
+	return -1
+}
+
+// InventoryFromSave loads the player inventory from the player data.
+// Items in unknown slots are ignored.
+func InventoryFromSave(items []save.Item) (inv Inventory, err error) {
+	for i := range items {
+		slot := saveSlot(items[i].Slot)
+		if slot < 0 {
+			continue
+		}
+		if inv[slot], err = item.StackFromSave(&items[i]); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// InventoryToSave converts the player inventory to the format of the player data.
+// The crafting grid isn't saved, the same as the vanilla server.
+func InventoryToSave(inv *Inventory) (items []save.Item, err error) {
+	for slot := byte(0); slot <= 150; slot++ {
+		i := saveSlot(slot)
+		if i < 0 || inv[i].IsEmpty() {
+			continue
+		}
+		v := save.Item{Slot: slot}
+		if err = item.StackToSave(inv[i], &v); err != nil {
+			return
+		}
+		items = append(items, v)
+	}
+	return
+}
@@ -0,0 +1,305 @@
+package window
+
+import (
+	"errors"
+	"io"
+
+	"git.konjactw.dev/falloutBot/go-mc/chat"
+	"git.konjactw.dev/falloutBot/go-mc/data/packetid"
+	"git.konjactw.dev/falloutBot/go-mc/level/item"
+	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
+)
+
+// Client is the connection of the player.
+type Client interface {
+	SendPacket(p pk.Packet)
+}
+
+// Manager manages the windows of a player.
+//
+// Manager is not safe for concurrent use.
+// Its methods should be called by the goroutine handling the packets of the player.
+type Manager struct {
+	// Inventory is the player inventory.
+	// Call Sync or SetInventorySlot after modifying it directly.
+	Inventory Inventory
+	// Carried is the item held by the mouse cursor.
+	// Call SetCarried to modify it.
+	Carried item.Stack
+	// Creative enables the clone click and the clone quick craft.
+	Creative bool
+	// Hasher is used to check the stacks predicted by the client.
+	// If it's nil, only the item, count and the types of components are checked.
+	Hasher item.ComponentHasher
+	// MaxStackSize returns the max stack size of the stack.
+	// The default one reads the max_stack_size component, or returns 64 if it's absent.
+	MaxStackSize func(s item.Stack) int32
+	// Drop is called when an item is thrown out of the window by the player,
+	// or the inventory is full when the carried item is put back.
+	// The item is discarded if Drop is nil.
+	Drop func(s item.Stack)
+
+	client     Client
+	inventory  Window
+	window     *Window
+	lastID     int32
+	quickCraft quickCraft
+}
+
+// NewManager creates a Manager with an empty inventory.
+// No packet is sent before Sync is called.
+func NewManager(client Client) *Manager {
+	m := &Manager{
+		MaxStackSize: defaultMaxStackSize,
+		client:       client,
+	}
+	m.inventory = Window{
+		ID:        0,
+		Menu:      PlayerInventory,
+		container: &m.Inventory,
+		inv:       &m.Inventory,
+	}
+	m.window = &m.inventory
+	return m
+}
+
+func defaultMaxStackSize(s item.Stack) int32 {
+	if c, ok := s.Get(item.ComponentMaxStackSize).(item.MaxStackSize); ok {
+		return int32(c)
+	}
+	return 64
+}
+
+// Window returns the opened window, which is the player inventory if no other window is opened.
+func (m *Manager) Window() *Window {
+	return m.window
+}
+
+// Open opens a menu for the player. The window opened before is closed.
+func (m *Manager) Open(menu Menu, title chat.Message, c Container) (*Window, error) {
+	if menu < 0 || int(menu) >= len(menuSizes) {
+		return nil, errors.New("window: invalid menu")
+	}
+	if len(c.Slots()) != menu.Size() {
+		return nil, errors.New("window: the size of container doesn't match the menu " + menu.Name())
+	}
+	m.Close()
+
+	// The same as the vanilla server, window IDs are in range [1, 100].
+	m.lastID = m.lastID%100 + 1
+	m.window = &Window{
+		ID:        m.lastID,
+		Menu:      menu,
+		Title:     title,
+		container: c,
+		inv:       &m.Inventory,
+	}
+	m.client.SendPacket(pk.Marshal(
+		packetid.ClientboundOpenScreen,
+		pk.VarInt(m.window.ID),
+		pk.VarInt(menu),
+		title,
+	))
+	m.Sync()
+	return m.window, nil
+}
+
+// Close closes the opened window, and puts the carried item back to the inventory.
+// Nothing happens if no window is opened.
+func (m *Manager) Close() {
+	if m.window == &m.inventory {
+		return
+	}
+	m.client.SendPacket(pk.Marshal(
+		packetid.ClientboundContainerClose,
+		pk.VarInt(m.window.ID),
+	))
+	m.closed()
+}
+
+// closed is called after the window is closed by either side.
+func (m *Manager) closed() {
+	if m.window == &m.inventory {
+		// Items in the crafting grid are returned as well.
+		for i := InventoryCraftingStart; i < InventoryArmorStart; i++ {
+			m.placeBack(m.Inventory[i])
+			m.Inventory[i] = item.Stack{}
+		}
+	}
+	m.window = &m.inventory
+	m.quickCraft.reset()
+	m.placeBack(m.Carried)
+	m.Carried = item.Stack{}
+	m.Sync()
+}
+
+// Sync sends all slots of the opened window and the carried item to the client.
+func (m *Manager) Sync() {
+	w := m.window
+	m.client.SendPacket(pk.Marshal(
+		packetid.ClientboundContainerSetContent,
+		pk.VarInt(w.ID),
+		pk.VarInt(w.nextStateID()),
+		pk.Array(w.content()),
+		m.Carried,
+	))
+}
+
+// SetSlot sets the i-th slot of the opened window, and sends it to the client.
+func (m *Manager) SetSlot(i int, s item.Stack) error {
+	slot := m.window.Slot(i)
+	if slot == nil {
+		return errors.New("window: slot index out of range")
+	}
+	*slot = s
+	m.sendSlot(m.window, i, s)
+	return nil
+}
+
+// SetInventorySlot sets the i-th slot of the player inventory, and sends it to the client.
+func (m *Manager) SetInventorySlot(i int, s item.Stack) error {
+	if i < 0 || i >= InventorySize {
+		return errors.New("window: slot index out of range")
+	}
+	m.Inventory[i] = s
+	if j := m.window.inventorySlot(i); j >= 0 {
+		m.sendSlot(m.window, j, s)
+	} else {
+		// The window 0 is always accepted by the client, even if it's not opened.
+		m.sendSlot(&m.inventory, i, s)
+	}
+	return nil
+}
+
+func (m *Manager) sendSlot(w *Window, i int, s item.Stack) {
+	m.client.SendPacket(pk.Marshal(
+		packetid.ClientboundContainerSetSlot,
+		pk.VarInt(w.ID),
+		pk.VarInt(w.nextStateID()),
+		pk.Short(i),
+		s,
+	))
+}
+
+// SetCarried sets the item held by the mouse cursor, and sends it to the client.
+func (m *Manager) SetCarried(s item.Stack) {
+	m.Carried = s
+	m.client.SendPacket(pk.Marshal(packetid.ClientboundSetCursorItem, s))
+}
+
+// HandleContainerClose handles the ServerboundContainerClose packet.
+func (m *Manager) HandleContainerClose(p pk.Packet) error {
+	var windowID pk.VarInt
+	if err := p.Scan(&windowID); err != nil {
+		return err
+	}
+	if int32(windowID) == m.window.ID {
+		m.closed()
+	}
+	return nil
+}
+
+// HandleContainerClick handles the ServerboundContainerClick packet.
+//
+// The click is applied to the opened window. If the result is different from
+// what the client predicted, or the state ID is outdated, the whole window is resent.
+func (m *Manager) HandleContainerClick(p pk.Packet) error {
+	var (
+		windowID, stateID pk.VarInt
+		slot              pk.Short
+		button            pk.Byte
+		mode              pk.VarInt
+		changed           changedSlots
+		carried           item.HashedStack
+	)
+	err := p.Scan(&windowID, &stateID, &slot, &button, &mode, &changed, &carried)
+	if err != nil {
+		return err
+	}
+	w := m.window
+	if int32(windowID) != w.ID {
+		// The window was closed by the server before the click arrived.
+		return nil
+	}
+	before := w.content()
+	if !m.click(w, int(slot), int8(button), ClickMode(mode)) ||
+		int32(stateID) != w.stateID ||
+		!m.predicted(w, before, changed, carried) {
+		m.Sync()
+	}
+	return nil
+}
+
+// predicted reports whether the client knows the current content of the window.
+func (m *Manager) predicted(w *Window, before []item.Stack, changed []changedSlot, carried item.HashedStack) bool {
+	if !carried.Matches(m.Carried, m.Hasher) {
+		return false
+	}
+	predictions := make(map[int]item.HashedStack, len(changed))
+	for _, c := range changed {
+		if int(c.Slot) < 0 || int(c.Slot) >= len(before) {
+			return false
+		}
+		predictions[int(c.Slot)] = c.Stack
+	}
+	for i, old := range before {
+		s := *w.Slot(i)
+		if h, ok := predictions[i]; ok {
+			if !h.Matches(s, m.Hasher) {
+				return false
+			}
+		} else if !stackEqual(old, s) {
+			return false
+		}
+	}
+	return true
+}
+
+func stackEqual(a, b item.Stack) bool {
+	if a.IsEmpty() || b.IsEmpty() {
+		return a.IsEmpty() == b.IsEmpty()
+	}
+	return a.Count == b.Count && a.IsSameItem(b)
+}
+
+// changedSlot is a slot changed by the click, predicted by the client.
+type changedSlot struct {
+	Slot  pk.Short
+	Stack item.HashedStack
+}
+
+func (c changedSlot) WriteTo(w io.Writer) (int64, error) {
+	return pk.Tuple{c.Slot, c.Stack}.WriteTo(w)
+}
+
+func (c *changedSlot) ReadFrom(r io.Reader) (int64, error) {
+	return pk.Tuple{&c.Slot, &c.Stack}.ReadFrom(r)
+}
+
+// maxChangedSlots is the max number of the changed slots in a click, the same as the vanilla server.
+const maxChangedSlots = 128
+
+var errTooManyChangedSlots = errors.New("window: too many changed slots")
+
+// changedSlots is the changed slots in the ServerboundContainerClick packet,
+// whose length is checked before reading the slots.
+type changedSlots []changedSlot
+
+func (c *changedSlots) ReadFrom(r io.Reader) (n int64, err error) {
+	var length pk.VarInt
+	if n, err = length.ReadFrom(r); err != nil {
+		return
+	}
+	if length < 0 || length > maxChangedSlots {
+		return n, errTooManyChangedSlots
+	}
+	*c = make(changedSlots, length)
+	for i := range *c {
+		nn, err := (*c)[i].ReadFrom(r)
+		n += nn
+		if err != nil {
+			return n, err
+		}
+	}
+	return
+}
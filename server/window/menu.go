@@ -0,0 +1,99 @@
+// Package window implements the player inventory and the container menus for servers.
+//
+// Each player has a Manager, which tracks the window the player is looking at,
+// the item carried by the mouse cursor, and handles the ContainerClick packets
+// sent by the client.
+package window
+
+import "git.konjactw.dev/falloutBot/go-mc/data/registryid"
+
+// Menu is the type of window, which is the index of registryid.Menu.
+type Menu int32
+
+// PlayerInventory is the menu of the window 0, which is not in registryid.Menu.
+const PlayerInventory Menu = -1
+
+const (
+	Generic9x1 Menu = iota
+	Generic9x2
+	Generic9x3
+	Generic9x4
+	Generic9x5
+	Generic9x6
+	Generic3x3
+	Crafter3x3
+	Anvil
+	Beacon
+	BlastFurnace
+	BrewingStand
+	Crafting
+	Enchantment
+	Furnace
+	Grindstone
+	Hopper
+	Lectern
+	Loom
+	Merchant
+	ShulkerBox
+	Smithing
+	Smoker
+	CartographyTable
+	Stonecutter
+)
+
+// menuSizes is the number of slots owned by each menu, excluding the player inventory.
+var menuSizes = [...]int{
+	Generic9x1:       9,
+	Generic9x2:       18,
+	Generic9x3:       27,
+	Generic9x4:       36,
+	Generic9x5:       45,
+	Generic9x6:       54,
+	Generic3x3:       9,
+	Crafter3x3:       9,
+	Anvil:            3,
+	Beacon:           1,
+	BlastFurnace:     3,
+	BrewingStand:     5,
+	Crafting:         10,
+	Enchantment:      2,
+	Furnace:          3,
+	Grindstone:       3,
+	Hopper:           5,
+	Lectern:          1,
+	Loom:             4,
+	Merchant:         3,
+	ShulkerBox:       27,
+	Smithing:         4,
+	Smoker:           3,
+	CartographyTable: 3,
+	Stonecutter:      2,
+}
+
+// Name returns the identifier of the menu, e.g. "minecraft:generic_9x3".
+func (m Menu) Name() string {
+	if m < 0 || int(m) >= len(registryid.Menu) {
+		return ""
+	}
+	return registryid.Menu[m]
+}
+
+// Size returns the number of slots owned by the menu, excluding the player inventory.
+//
+// The crafter has an extra display-only result slot after the player inventory,
+// which isn't counted.
+func (m Menu) Size() int {
+	if m == PlayerInventory {
+		return InventorySize
+	}
+	if m < 0 || int(m) >= len(menuSizes) {
+		return 0
+	}
+	return menuSizes[m]
+}
+
+// hasPlayerInventory reports whether the main inventory and the hotbar are
+// appended to the slots of the menu.
+func (m Menu) hasPlayerInventory() bool {
+	return m != PlayerInventory && m != Lectern
+}
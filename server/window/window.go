@@ -0,0 +1,99 @@
+package window
+
+import (
+	"git.konjactw.dev/falloutBot/go-mc/chat"
+	"git.konjactw.dev/falloutBot/go-mc/level/item"
+)
+
+// Container is the storage of the slots owned by a menu, e.g. a chest or a furnace.
+//
+// A Container may be shared by the windows of several players.
+// It's not safe for concurrent use, the caller should synchronize the access
+// and call Manager.Sync for the other viewers after the content is changed.
+type Container interface {
+	// Slots returns the slots of the container.
+	// The length must be the same as the Size of the menu.
+	Slots() []item.Stack
+	// MayPlace reports whether the player can place the stack into the i-th slot.
+	MayPlace(i int, s item.Stack) bool
+}
+
+// SimpleContainer is a Container accepting any item in any slot.
+type SimpleContainer []item.Stack
+
+// NewContainer creates an empty SimpleContainer for the menu.
+func NewContainer(m Menu) SimpleContainer {
+	return make(SimpleContainer, m.Size())
+}
+
+func (c SimpleContainer) Slots() []item.Stack           { return c }
+func (c SimpleContainer) MayPlace(int, item.Stack) bool { return true }
+
+// Window is a menu opened by the player.
+// The window 0 is always the player inventory, which is opened when no other window is.
+type Window struct {
+	ID    int32
+	Menu  Menu
+	Title chat.Message
+
+	container Container
+	inv       *Inventory
+	stateID   int32
+}
+
+// Len returns the number of slots in the window, including the player inventory.
+func (w *Window) Len() int {
+	n := len(w.container.Slots())
+	if w.Menu.hasPlayerInventory() {
+		n += InventorySize - InventoryMainStart - 1
+	}
+	return n
+}
+
+// Slot returns the i-th slot of the window, or nil if i is out of range.
+func (w *Window) Slot(i int) *item.Stack {
+	slots := w.container.Slots()
+	switch {
+	case i < 0:
+		return nil
+	case i < len(slots):
+		return &slots[i]
+	case w.Menu.hasPlayerInventory() && i < w.Len():
+		return &w.inv[InventoryMainStart+i-len(slots)]
+	}
+	return nil
+}
+
+// mayPlace reports whether the stack can be placed into the i-th slot.
+func (w *Window) mayPlace(i int, s item.Stack) bool {
+	if n := len(w.container.Slots()); i < n {
+		return w.container.MayPlace(i, s)
+	}
+	return true
+}
+
+// inventorySlot converts the index of the player inventory to the index of the window.
+// It returns -1 if the slot isn't visible in the window.
+func (w *Window) inventorySlot(i int) int {
+	if w.Menu == PlayerInventory {
+		return i
+	}
+	if !w.Menu.hasPlayerInventory() || i < InventoryMainStart || i >= InventoryOffhand {
+		return -1
+	}
+	return len(w.container.Slots()) + i - InventoryMainStart
+}
+
+// content returns a copy of all slots in the window.
+func (w *Window) content() []item.Stack {
+	content := make([]item.Stack, w.Len())
+	for i := range content {
+		content[i] = *w.Slot(i)
+	}
+	return content
+}
+
+func (w *Window) nextStateID() int32 {
+	w.stateID = (w.stateID + 1) & 0x7FFF
+	return w.stateID
+}
@@ -0,0 +1,229 @@
+package window
+
+import (
+	"errors"
+	"testing"
+
+	"git.konjactw.dev/falloutBot/go-mc/chat"
+	"git.konjactw.dev/falloutBot/go-mc/data/packetid"
+	"git.konjactw.dev/falloutBot/go-mc/level/item"
+	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
+)
+
+type testClient []pk.Packet
+
+func (c *testClient) SendPacket(p pk.Packet) { *c = append(*c, p) }
+
+func (c *testClient) pop() (ids []packetid.ClientboundPacketID) {
+	for _, p := range *c {
+		ids = append(ids, packetid.ClientboundPacketID(p.ID))
+	}
+	*c = nil
+	return
+}
+
+func stone(n int32) item.Stack {
+	return item.Stack{ID: item.Stone{}.ID(), Count: n}
+}
+
+func clickPacket(w *Window, slot int, button int8, mode ClickMode, changed map[int]item.Stack, carried item.Stack) pk.Packet {
+	var slots []changedSlot
+	for i, s := range changed {
+		slots = append(slots, changedSlot{Slot: pk.Short(i), Stack: s.Hashed(nil)})
+	}
+	return pk.Marshal(
+		packetid.ServerboundContainerClick,
+		pk.VarInt(w.ID),
+		pk.VarInt(w.stateID),
+		pk.Short(slot),
+		pk.Byte(button),
+		pk.VarInt(mode),
+		pk.Array(slots),
+		carried.Hashed(nil),
+	)
+}
+
+func newTestManager(t *testing.T) (*Manager, *testClient, *Window) {
+	var c testClient
+	m := NewManager(&c)
+	w, err := m.Open(Generic9x3, chat.Text("Chest"), NewContainer(Generic9x3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := c.pop(); len(ids) != 2 ||
+		ids[0] != packetid.ClientboundOpenScreen ||
+		ids[1] != packetid.ClientboundContainerSetContent {
+		t.Fatalf("unexpected packets on open: %v", ids)
+	}
+	if w.Len() != 27+36 {
+		t.Fatalf("window length %d", w.Len())
+	}
+	return m, &c, w
+}
+
+func TestManager_Pickup(t *testing.T) {
+	m, c, w := newTestManager(t)
+	*w.Slot(0) = stone(10)
+
+	// left click takes the whole stack
+	err := m.HandleContainerClick(clickPacket(w, 0, 0, Pickup, map[int]item.Stack{0: {}}, stone(10)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !w.Slot(0).IsEmpty() || m.Carried.Count != 10 {
+		t.Errorf("left click: slot %v, carried %v", *w.Slot(0), m.Carried)
+	}
+	if ids := c.pop(); len(ids) != 0 {
+		t.Errorf("unexpected resync: %v", ids)
+	}
+
+	// right click places one item
+	err = m.HandleContainerClick(clickPacket(w, 1, 1, Pickup, map[int]item.Stack{1: stone(1)}, stone(9)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Slot(1).Count != 1 || m.Carried.Count != 9 {
+		t.Errorf("right click: slot %v, carried %v", *w.Slot(1), m.Carried)
+	}
+
+	// wrong prediction leads to a resync
+	err = m.HandleContainerClick(clickPacket(w, 1, 0, Pickup, map[int]item.Stack{1: stone(2)}, item.Stack{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Slot(1).Count != 10 || !m.Carried.IsEmpty() {
+		t.Errorf("left click: slot %v, carried %v", *w.Slot(1), m.Carried)
+	}
+	if ids := c.pop(); len(ids) != 1 || ids[0] != packetid.ClientboundContainerSetContent {
+		t.Errorf("expect resync, got %v", ids)
+	}
+}
+
+func TestManager_QuickMove(t *testing.T) {
+	m, _, w := newTestManager(t)
+	*w.Slot(5) = stone(40)
+	m.Inventory[InventoryMainStart] = stone(60)
+
+	if !m.click(w, 5, 0, QuickMove) {
+		t.Fatal("invalid click")
+	}
+	// stones are merged into the existing stack first, then the last slot of the hotbar
+	if m.Inventory[InventoryMainStart].Count != 64 || m.Inventory[InventoryOffhand-1].Count != 36 || !w.Slot(5).IsEmpty() {
+		t.Errorf("unexpected inventory after quick move: %v", m.Inventory)
+	}
+	// back into the container
+	if !m.click(w, w.inventorySlot(InventoryOffhand-1), 0, QuickMove) {
+		t.Fatal("invalid click")
+	}
+	if w.Slot(0).Count != 36 {
+		t.Errorf("unexpected container after quick move: %v", *w.Slot(0))
+	}
+}
+
+func TestManager_QuickCraft(t *testing.T) {
+	m, _, w := newTestManager(t)
+	m.Carried = stone(10)
+	*w.Slot(2) = stone(1)
+
+	clicks := []struct {
+		slot   int
+		button int8
+	}{
+		{SlotOutside, 0}, // start, split evenly
+		{0, 1},
+		{1, 1},
+		{2, 1},
+		{1, 1},           // duplicated
+		{SlotOutside, 2}, // end
+	}
+	for _, c := range clicks {
+		if !m.click(w, c.slot, c.button, QuickCraft) {
+			t.Fatalf("invalid quick craft click %v", c)
+		}
+	}
+	if w.Slot(0).Count != 3 || w.Slot(1).Count != 3 || w.Slot(2).Count != 4 || m.Carried.Count != 1 {
+		t.Errorf("unexpected result: %v %v %v, carried %v", *w.Slot(0), *w.Slot(1), *w.Slot(2), m.Carried)
+	}
+	// end without start
+	if m.click(w, SlotOutside, 2, QuickCraft) {
+		t.Error("quick craft end without start should be invalid")
+	}
+}
+
+func TestManager_PickupAll(t *testing.T) {
+	m, _, w := newTestManager(t)
+	m.Carried = stone(1)
+	*w.Slot(3) = stone(64)
+	*w.Slot(4) = stone(20)
+	m.Inventory[InventoryMainStart] = stone(30)
+
+	if !m.click(w, 0, 0, PickupAll) {
+		t.Fatal("invalid click")
+	}
+	// non-full stacks first, then the full stack
+	if m.Carried.Count != 64 || w.Slot(3).Count != 51 || !w.Slot(4).IsEmpty() || !m.Inventory[InventoryMainStart].IsEmpty() {
+		t.Errorf("unexpected result: carried %v", m.Carried)
+	}
+}
+
+func TestManager_Close(t *testing.T) {
+	m, c, w := newTestManager(t)
+	m.Carried = stone(5)
+	*m.Inventory.Hotbar(0) = stone(62)
+
+	if err := m.HandleContainerClose(pk.Marshal(packetid.ServerboundContainerClose, pk.VarInt(w.ID))); err != nil {
+		t.Fatal(err)
+	}
+	if m.Window().Menu != PlayerInventory {
+		t.Error("window should be closed")
+	}
+	if !m.Carried.IsEmpty() || m.Inventory.Hotbar(0).Count != 64 || m.Inventory.Hotbar(1).Count != 3 {
+		t.Errorf("carried item should be put back: %v", m.Inventory)
+	}
+	if ids := c.pop(); len(ids) != 1 || ids[0] != packetid.ClientboundContainerSetContent {
+		t.Errorf("expect sync, got %v", ids)
+	}
+}
+
+func TestInventoryFromSave(t *testing.T) {
+	var inv Inventory
+	*inv.Hotbar(0) = stone(1)
+	inv[InventoryMainStart] = stone(2)
+	inv[InventoryArmorStart] = stone(3)
+	inv[InventoryOffhand] = stone(4)
+	inv[InventoryCraftingStart] = stone(5)
+
+	items, err := InventoryToSave(&inv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 4 {
+		t.Fatalf("unexpected saved items: %v", items)
+	}
+	got, err := InventoryFromSave(items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inv[InventoryCraftingStart] = item.Stack{}
+	for i := range inv {
+		if !stackEqual(got[i], inv[i]) {
+			t.Errorf("slot %d not match: get %v, want %v", i, got[i], inv[i])
+		}
+	}
+}
+
+func TestManager_TooManyChangedSlots(t *testing.T) {
+	m, _, w := newTestManager(t)
+	p := pk.Marshal(
+		packetid.ServerboundContainerClick,
+		pk.VarInt(w.ID),
+		pk.VarInt(w.stateID),
+		pk.Short(0),
+		pk.Byte(0),
+		pk.VarInt(Pickup),
+		pk.VarInt(maxChangedSlots+1),
+	)
+	if err := m.HandleContainerClick(p); !errors.Is(err, errTooManyChangedSlots) {
+		t.Errorf("expect too many changed slots, got %v", err)
+	}
+}
@@ -63,6 +63,9 @@ type Profile struct {
 }
 
 // Authenticate authenticates a user using their password.
+//
+// Deprecated: Mojang accounts are retired and the endpoint no longer works,
+// use package git.konjactw.dev/falloutBot/go-mc/yggdrasil/msauth to log in Microsoft accounts.
 func Authenticate(user, password string) (*Access, error) {
 	// Payload
 	pl := authPayload{
@@ -0,0 +1,135 @@
+package msauth
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+)
+
+// ErrExpired is returned by PollToken if the user didn't sign in before the device code expires.
+var ErrExpired = errors.New("msauth: device code expired")
+
+// ErrDeclined is returned by PollToken if the user declined the sign in.
+var ErrDeclined = errors.New("msauth: authorization declined")
+
+// DeviceCode is the code the user enters on another device to sign in.
+type DeviceCode struct {
+	UserCode        string `json:"user_code"`
+	DeviceCode      string `json:"device_code"`
+	VerificationURI string `json:"verification_uri"`
+	// ExpiresIn and Interval are in seconds.
+	ExpiresIn int64 `json:"expires_in"`
+	Interval  int64 `json:"interval"`
+	// Message is the instruction for the user, including the UserCode and VerificationURI.
+	Message string `json:"message"`
+}
+
+// MSAToken is the token of a Microsoft account.
+type MSAToken struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// Valid reports whether the access token is not expired.
+func (t MSAToken) Valid() bool {
+	return t.AccessToken != "" && time.Now().Before(t.ExpiresAt)
+}
+
+type tokenResp struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+func (c *Client) scope() string {
+	if c.Scope == "" {
+		return DefaultScope
+	}
+	return c.Scope
+}
+
+// DeviceCode starts the device code flow.
+func (c *Client) DeviceCode(ctx context.Context) (*DeviceCode, error) {
+	var code DeviceCode
+	err := c.postForm(ctx, "/devicecode", url.Values{
+		"client_id": {c.ClientID},
+		"scope":     {c.scope()},
+	}, &code)
+	if err != nil {
+		return nil, err
+	}
+	return &code, nil
+}
+
+// PollToken waits for the user to sign in with the DeviceCode, and returns the token.
+func (c *Client) PollToken(ctx context.Context, code *DeviceCode) (*MSAToken, error) {
+	interval := time.Duration(code.Interval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	deadline := time.Now().Add(time.Duration(code.ExpiresIn) * time.Second)
+	form := url.Values{
+		"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+		"client_id":   {c.ClientID},
+		"device_code": {code.DeviceCode},
+	}
+	for {
+		var resp tokenResp
+		err := c.postForm(ctx, "/token", form, &resp)
+		if err == nil {
+			return &MSAToken{
+				AccessToken:  resp.AccessToken,
+				RefreshToken: resp.RefreshToken,
+				ExpiresAt:    expiry(resp.ExpiresIn),
+			}, nil
+		}
+		var oauthErr Error
+		if !errors.As(err, &oauthErr) {
+			return nil, err
+		}
+		switch oauthErr.Err {
+		case "authorization_pending":
+		case "slow_down":
+			interval += 5 * time.Second
+		case "expired_token":
+			return nil, ErrExpired
+		case "authorization_declined":
+			return nil, ErrDeclined
+		default:
+			return nil, err
+		}
+		if code.ExpiresIn > 0 && time.Now().Add(interval).After(deadline) {
+			return nil, ErrExpired
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// RefreshToken gets a new token with the refresh token.
+func (c *Client) RefreshToken(ctx context.Context, refreshToken string) (*MSAToken, error) {
+	var resp tokenResp
+	err := c.postForm(ctx, "/token", url.Values{
+		"grant_type":    {"refresh_token"},
+		"client_id":     {c.ClientID},
+		"scope":         {c.scope()},
+		"refresh_token": {refreshToken},
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	// The refresh token isn't always rotated.
+	if resp.RefreshToken == "" {
+		resp.RefreshToken = refreshToken
+	}
+	return &MSAToken{
+		AccessToken:  resp.AccessToken,
+		RefreshToken: resp.RefreshToken,
+		ExpiresAt:    expiry(resp.ExpiresIn),
+	}, nil
+}
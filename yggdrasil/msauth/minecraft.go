@@ -0,0 +1,102 @@
+package msauth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrNotOwned is returned by Authenticate if the account doesn't own Minecraft: Java Edition.
+var ErrNotOwned = errors.New("msauth: the account doesn't own the game")
+
+// ErrNoProfile is returned by Profile if the account hasn't created a Minecraft profile.
+var ErrNoProfile = errors.New("msauth: the account has no profile")
+
+// MinecraftToken is the access token of the Minecraft services,
+// used to join servers and call the other Minecraft APIs.
+type MinecraftToken struct {
+	AccessToken string    `json:"access_token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// Valid reports whether the access token is not expired.
+func (t MinecraftToken) Valid() bool {
+	return t.AccessToken != "" && time.Now().Before(t.ExpiresAt)
+}
+
+// Profile is the Minecraft profile of the account.
+type Profile struct {
+	ID    uuid.UUID `json:"id"`
+	Name  string    `json:"name"`
+	Skins []Skin    `json:"skins,omitempty"`
+	Capes []Skin    `json:"capes,omitempty"`
+}
+
+// Skin is a skin or cape of the Profile.
+type Skin struct {
+	ID      string `json:"id"`
+	State   string `json:"state"`
+	URL     string `json:"url"`
+	Variant string `json:"variant,omitempty"`
+	Alias   string `json:"alias,omitempty"`
+}
+
+// LoginWithXbox logs in the Minecraft services with the XSTS token.
+func (c *Client) LoginWithXbox(ctx context.Context, xsts *XboxToken) (*MinecraftToken, error) {
+	payload := map[string]string{
+		"identityToken": "XBL3.0 x=" + xsts.UserHash + ";" + xsts.Token,
+	}
+	var resp struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	err := c.request(ctx, http.MethodPost, c.Endpoints.MinecraftServices+"/authentication/login_with_xbox", "", payload, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &MinecraftToken{AccessToken: resp.AccessToken, ExpiresAt: expiry(resp.ExpiresIn)}, nil
+}
+
+// Entitlements returns the names of the items the account owns, such as "game_minecraft".
+func (c *Client) Entitlements(ctx context.Context, accessToken string) ([]string, error) {
+	var resp struct {
+		Items []struct {
+			Name string `json:"name"`
+		} `json:"items"`
+	}
+	err := c.request(ctx, http.MethodGet, c.Endpoints.MinecraftServices+"/entitlements/mcstore", "Bearer "+accessToken, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(resp.Items))
+	for i, item := range resp.Items {
+		names[i] = item.Name
+	}
+	return names, nil
+}
+
+// OwnsGame reports whether the account owns Minecraft: Java Edition, including by Xbox Game Pass.
+func (c *Client) OwnsGame(ctx context.Context, accessToken string) (bool, error) {
+	entitlements, err := c.Entitlements(ctx, accessToken)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(entitlements, "game_minecraft"), nil
+}
+
+// Profile fetches the account's Minecraft profile.
+func (c *Client) Profile(ctx context.Context, accessToken string) (*Profile, error) {
+	var profile Profile
+	err := c.request(ctx, http.MethodGet, c.Endpoints.MinecraftServices+"/minecraft/profile", "Bearer "+accessToken, nil, &profile)
+	if statusErr := (StatusErr{}); errors.As(err, &statusErr) && statusErr.Code == http.StatusNotFound {
+		return nil, ErrNoProfile
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
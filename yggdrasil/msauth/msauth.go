@@ -0,0 +1,257 @@
+// Package msauth implements the Microsoft account authentication of Minecraft: Java Edition.
+//
+// Since Mojang accounts were migrated, a Minecraft access token is obtained in these steps:
+//
+//  1. Sign in the Microsoft account with the OAuth 2.0 device code flow.
+//  2. Exchange the Microsoft token for an Xbox Live user token.
+//  3. Exchange the Xbox Live token for an XSTS token of the Minecraft services.
+//  4. Log in the Minecraft services with the XSTS token.
+//  5. Check the entitlements and fetch the Minecraft profile.
+//
+// Client.Login does all of them, and Client.Refresh renews an expired Session
+// without asking the user to sign in again.
+//
+//	c := msauth.NewClient("<your Azure application client id>")
+//	s, err := c.Login(ctx, func(code msauth.DeviceCode) {
+//		fmt.Println(code.Message)
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	err = s.Save("session.json")
+package msauth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Endpoints are the base URLs of the services, which can be replaced to test against a local fake.
+type Endpoints struct {
+	// Microsoft is the OAuth 2.0 endpoint serving "/devicecode" and "/token".
+	Microsoft string
+	// XboxUser serves "/user/authenticate".
+	XboxUser string
+	// XSTS serves "/xsts/authorize".
+	XSTS string
+	// MinecraftServices serves the login, entitlements and profile API.
+	MinecraftServices string
+}
+
+// DefaultEndpoints are the real services.
+var DefaultEndpoints = Endpoints{
+	Microsoft:         "https://login.microsoftonline.com/consumers/oauth2/v2.0",
+	XboxUser:          "https://user.auth.xboxlive.com",
+	XSTS:              "https://xsts.auth.xboxlive.com",
+	MinecraftServices: "https://api.minecraftservices.com",
+}
+
+// DefaultScope is the OAuth scope needed to sign in Xbox Live and get a refresh token.
+const DefaultScope = "XboxLive.signin offline_access"
+
+// Client signs in Microsoft accounts and logs in the Minecraft services.
+type Client struct {
+	// ClientID is the application (client) ID of an Azure application
+	// which is allowed to use the Minecraft services.
+	ClientID string
+	// Scope is requested in the device code flow. DefaultScope is used if it's empty.
+	Scope string
+	// Endpoints are the base URLs of the services.
+	Endpoints Endpoints
+	// HTTPClient is used to send the requests. http.DefaultClient is used if it's nil.
+	HTTPClient *http.Client
+}
+
+// NewClient returns a Client of the real services.
+func NewClient(clientID string) *Client {
+	return &Client{
+		ClientID:  clientID,
+		Endpoints: DefaultEndpoints,
+	}
+}
+
+// Login signs in with the device code flow, then logs in the Minecraft services.
+// The prompt is called with the code, which should be shown to the user.
+func (c *Client) Login(ctx context.Context, prompt func(code DeviceCode)) (*Session, error) {
+	code, err := c.DeviceCode(ctx)
+	if err != nil {
+		return nil, err
+	}
+	prompt(*code)
+	msa, err := c.PollToken(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	return c.Authenticate(ctx, *msa)
+}
+
+// Authenticate logs in the Minecraft services with a Microsoft token,
+// and returns a Session with the Minecraft token and profile.
+// ErrNotOwned is returned if the account doesn't own the game.
+func (c *Client) Authenticate(ctx context.Context, msa MSAToken) (*Session, error) {
+	xbl, err := c.XboxLive(ctx, msa.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	xsts, err := c.XSTS(ctx, xbl)
+	if err != nil {
+		return nil, err
+	}
+	mc, err := c.LoginWithXbox(ctx, xsts)
+	if err != nil {
+		return nil, err
+	}
+	owned, err := c.OwnsGame(ctx, mc.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	if !owned {
+		return nil, ErrNotOwned
+	}
+	profile, err := c.Profile(ctx, mc.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	return &Session{MSA: msa, Minecraft: *mc, Profile: *profile}, nil
+}
+
+// Refresh renews the tokens of the Session if the Minecraft token is expired.
+// The Microsoft token is refreshed first if it's expired too.
+// The Session is only modified if it's refreshed successfully.
+func (c *Client) Refresh(ctx context.Context, s *Session) error {
+	if s.Minecraft.Valid() {
+		return nil
+	}
+	msa := s.MSA
+	if !msa.Valid() {
+		if msa.RefreshToken == "" {
+			return errors.New("msauth: session expired and has no refresh token")
+		}
+		refreshed, err := c.RefreshToken(ctx, msa.RefreshToken)
+		if err != nil {
+			return err
+		}
+		msa = *refreshed
+	}
+	refreshed, err := c.Authenticate(ctx, msa)
+	if err != nil {
+		return err
+	}
+	*s = *refreshed
+	return nil
+}
+
+// expiry returns the time after expiresIn seconds, a little earlier to tolerate the latency.
+func expiry(expiresIn int64) time.Time {
+	return time.Now().Add(time.Duration(expiresIn)*time.Second - time.Minute)
+}
+
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	req.Header.Set("User-agent", "go-mc")
+	req.Header.Set("Accept", "application/json")
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return client.Do(req)
+}
+
+// postForm posts a form to the Microsoft OAuth endpoint, and decodes the response or the OAuth error.
+func (c *Client) postForm(ctx context.Context, endpoint string, form url.Values, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.Endpoints.Microsoft+endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		oauthErr := Error{StatusCode: resp.StatusCode}
+		if err := json.Unmarshal(body, &oauthErr); err != nil || oauthErr.Err == "" {
+			return StatusErr{URL: req.URL.String(), Code: resp.StatusCode}
+		}
+		return oauthErr
+	}
+	return json.Unmarshal(body, v)
+}
+
+// request sends a JSON request, and decodes the JSON response.
+func (c *Client) request(ctx context.Context, method, endpoint, authorization string, payload, v any) error {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return err
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	// The Xbox Live services require the contract version.
+	req.Header.Set("x-xbl-contract-version", "1")
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var xerr XboxErr
+		if json.Unmarshal(respBody, &xerr) == nil && xerr.XErr != 0 {
+			return xerr
+		}
+		return StatusErr{URL: endpoint, Code: resp.StatusCode, Body: string(respBody)}
+	}
+	return json.Unmarshal(respBody, v)
+}
+
+// Error is an OAuth 2.0 error responded by the Microsoft identity platform.
+type Error struct {
+	StatusCode  int    `json:"-"`
+	Err         string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+func (e Error) Error() string {
+	return "msauth: " + e.Err + ": " + e.Description
+}
+
+// StatusErr is returned if a service responds an unexpected status code.
+type StatusErr struct {
+	URL  string
+	Code int
+	Body string
+}
+
+func (s StatusErr) Error() string {
+	if s.Body != "" {
+		return fmt.Sprintf("msauth: %s responds status %d: %s", s.URL, s.Code, s.Body)
+	}
+	return fmt.Sprintf("msauth: %s responds status %d", s.URL, s.Code)
+}
@@ -0,0 +1,189 @@
+package msauth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var testID = uuid.MustParse("853c80ef-3c37-49fd-aa49-938b674adae6")
+
+// fakeServices is a local fake of all the services in the flow.
+type fakeServices struct {
+	polls      atomic.Int32
+	refreshes  atomic.Int32
+	owned      bool
+	xerr       int64
+	msaExpires int64
+}
+
+func (f *fakeServices) handler(t *testing.T) http.Handler {
+	writeJSON := func(w http.ResponseWriter, code int, v any) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(v)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /oauth/devicecode", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("client_id") != "client" || r.FormValue("scope") != DefaultScope {
+			t.Errorf("unexpected device code request: %v", r.Form)
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"user_code": "ABCD", "device_code": "device", "verification_uri": "https://microsoft.com/link",
+			"expires_in": 60, "interval": 1, "message": "enter ABCD",
+		})
+	})
+	mux.HandleFunc("POST /oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		switch r.FormValue("grant_type") {
+		case "urn:ietf:params:oauth:grant-type:device_code":
+			if f.polls.Add(1) == 1 {
+				writeJSON(w, http.StatusBadRequest, map[string]string{"error": "authorization_pending"})
+				return
+			}
+			writeJSON(w, http.StatusOK, map[string]any{"access_token": "msa", "refresh_token": "refresh", "expires_in": f.msaExpires})
+		case "refresh_token":
+			f.refreshes.Add(1)
+			if r.FormValue("refresh_token") != "refresh" {
+				writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+				return
+			}
+			writeJSON(w, http.StatusOK, map[string]any{"access_token": "msa", "expires_in": 3600})
+		}
+	})
+	mux.HandleFunc("POST /xbl/user/authenticate", func(w http.ResponseWriter, r *http.Request) {
+		var req struct{ Properties struct{ RpsTicket string } }
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.Properties.RpsTicket != "d=msa" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"Token": "xbl", "NotAfter": time.Now().Add(time.Hour),
+			"DisplayClaims": map[string]any{"xui": []map[string]string{{"uhs": "hash"}}},
+		})
+	})
+	mux.HandleFunc("POST /xsts/xsts/authorize", func(w http.ResponseWriter, r *http.Request) {
+		if f.xerr != 0 {
+			writeJSON(w, http.StatusUnauthorized, map[string]any{"XErr": f.xerr, "Message": ""})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"Token": "xsts", "NotAfter": time.Now().Add(time.Hour),
+			"DisplayClaims": map[string]any{"xui": []map[string]string{{"uhs": "hash"}}},
+		})
+	})
+	mux.HandleFunc("POST /mc/authentication/login_with_xbox", func(w http.ResponseWriter, r *http.Request) {
+		var req struct{ IdentityToken string }
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.IdentityToken != "XBL3.0 x=hash;xsts" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"access_token": "mc", "expires_in": 86400})
+	})
+	mux.HandleFunc("GET /mc/entitlements/mcstore", func(w http.ResponseWriter, r *http.Request) {
+		items := []map[string]string{}
+		if f.owned {
+			items = append(items, map[string]string{"name": "product_minecraft"}, map[string]string{"name": "game_minecraft"})
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"items": items})
+	})
+	mux.HandleFunc("GET /mc/minecraft/profile", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer mc" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"id": "853c80ef3c3749fdaa49938b674adae6", "name": "jeb_"})
+	})
+	return mux
+}
+
+func newTestClient(t *testing.T, f *fakeServices) *Client {
+	ts := httptest.NewServer(f.handler(t))
+	t.Cleanup(ts.Close)
+	return &Client{
+		ClientID: "client",
+		Endpoints: Endpoints{
+			Microsoft:         ts.URL + "/oauth",
+			XboxUser:          ts.URL + "/xbl",
+			XSTS:              ts.URL + "/xsts",
+			MinecraftServices: ts.URL + "/mc",
+		},
+	}
+}
+
+func TestClient_Login(t *testing.T) {
+	f := &fakeServices{owned: true, msaExpires: 3600}
+	c := newTestClient(t, f)
+
+	var prompted DeviceCode
+	s, err := c.Login(context.Background(), func(code DeviceCode) { prompted = code })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prompted.UserCode != "ABCD" {
+		t.Errorf("unexpected device code: %+v", prompted)
+	}
+	if f.polls.Load() != 2 {
+		t.Errorf("want 2 polls, got %d", f.polls.Load())
+	}
+	if s.Profile.ID != testID || s.Profile.Name != "jeb_" || s.Minecraft.AccessToken != "mc" || !s.Minecraft.Valid() {
+		t.Errorf("unexpected session: %+v", s)
+	}
+
+	var buf bytes.Buffer
+	if _, err := s.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := ReadSession(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.MSA.RefreshToken != "refresh" || loaded.Profile.ID != testID || !loaded.Minecraft.ExpiresAt.Equal(s.Minecraft.ExpiresAt) {
+		t.Errorf("session mismatch after serialization: %+v", loaded)
+	}
+}
+
+func TestClient_Refresh(t *testing.T) {
+	f := &fakeServices{owned: true}
+	c := newTestClient(t, f)
+	s := &Session{
+		MSA:       MSAToken{AccessToken: "expired", RefreshToken: "refresh", ExpiresAt: time.Now().Add(-time.Hour)},
+		Minecraft: MinecraftToken{AccessToken: "expired", ExpiresAt: time.Now().Add(-time.Hour)},
+	}
+	if err := c.Refresh(context.Background(), s); err != nil {
+		t.Fatal(err)
+	}
+	if f.refreshes.Load() != 1 || s.MSA.RefreshToken != "refresh" || s.Minecraft.AccessToken != "mc" {
+		t.Errorf("unexpected session after refresh: %+v", s)
+	}
+	if err := c.Refresh(context.Background(), s); err != nil {
+		t.Fatal(err)
+	}
+	if f.refreshes.Load() != 1 {
+		t.Error("valid session shouldn't be refreshed")
+	}
+}
+
+func TestClient_Authenticate_errors(t *testing.T) {
+	f := &fakeServices{}
+	c := newTestClient(t, f)
+	msa := MSAToken{AccessToken: "msa"}
+	if _, err := c.Authenticate(context.Background(), msa); !errors.Is(err, ErrNotOwned) {
+		t.Errorf("want ErrNotOwned, got %v", err)
+	}
+
+	f.xerr = XErrUnderageFamily
+	var xerr XboxErr
+	if _, err := c.Authenticate(context.Background(), msa); !errors.As(err, &xerr) || xerr.XErr != XErrUnderageFamily {
+		t.Errorf("want XboxErr, got %v", err)
+	}
+}
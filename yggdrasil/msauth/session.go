@@ -0,0 +1,58 @@
+package msauth
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+)
+
+// Session is the tokens and profile of a logged in account.
+// It can be serialized as JSON, so that the user doesn't need to sign in every time.
+// The tokens are credentials, keep them secret.
+type Session struct {
+	MSA       MSAToken       `json:"msa"`
+	Minecraft MinecraftToken `json:"minecraft"`
+	Profile   Profile        `json:"profile"`
+}
+
+// ReadSession decodes a Session written by WriteTo.
+func ReadSession(r io.Reader) (*Session, error) {
+	var s Session
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// LoadSession reads the Session saved by Save.
+func LoadSession(path string) (*Session, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadSession(f)
+}
+
+// WriteTo encodes the Session as JSON.
+func (s *Session) WriteTo(w io.Writer) (int64, error) {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(append(data, '\n'))
+	return int64(n), err
+}
+
+// Save writes the Session to the file, which is only readable by the current user.
+func (s *Session) Save(path string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := s.WriteTo(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,108 @@
+package msauth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// XboxToken is an Xbox Live user token or an XSTS token.
+type XboxToken struct {
+	Token    string
+	UserHash string
+	NotAfter time.Time
+}
+
+type xboxResp struct {
+	Token         string    `json:"Token"`
+	NotAfter      time.Time `json:"NotAfter"`
+	DisplayClaims struct {
+		Xui []struct {
+			Uhs string `json:"uhs"`
+		} `json:"xui"`
+	} `json:"DisplayClaims"`
+}
+
+func (x *xboxResp) token() (*XboxToken, error) {
+	if len(x.DisplayClaims.Xui) == 0 {
+		return nil, errors.New("msauth: xbox live token without user hash")
+	}
+	return &XboxToken{
+		Token:    x.Token,
+		UserHash: x.DisplayClaims.Xui[0].Uhs,
+		NotAfter: x.NotAfter,
+	}, nil
+}
+
+// XboxLive exchanges the Microsoft access token for an Xbox Live user token.
+func (c *Client) XboxLive(ctx context.Context, msaAccessToken string) (*XboxToken, error) {
+	payload := map[string]any{
+		"Properties": map[string]any{
+			"AuthMethod": "RPS",
+			"SiteName":   "user.auth.xboxlive.com",
+			"RpsTicket":  "d=" + msaAccessToken,
+		},
+		"RelyingParty": "http://auth.xboxlive.com",
+		"TokenType":    "JWT",
+	}
+	var resp xboxResp
+	if err := c.request(ctx, http.MethodPost, c.Endpoints.XboxUser+"/user/authenticate", "", payload, &resp); err != nil {
+		return nil, err
+	}
+	return resp.token()
+}
+
+// XSTS exchanges the Xbox Live user token for an XSTS token of the Minecraft services.
+func (c *Client) XSTS(ctx context.Context, xbl *XboxToken) (*XboxToken, error) {
+	payload := map[string]any{
+		"Properties": map[string]any{
+			"SandboxId":  "RETAIL",
+			"UserTokens": []string{xbl.Token},
+		},
+		"RelyingParty": "rp://api.minecraftservices.com/",
+		"TokenType":    "JWT",
+	}
+	var resp xboxResp
+	if err := c.request(ctx, http.MethodPost, c.Endpoints.XSTS+"/xsts/authorize", "", payload, &resp); err != nil {
+		return nil, err
+	}
+	return resp.token()
+}
+
+// XboxErr is returned if the Xbox Live services refuse the account.
+type XboxErr struct {
+	XErr     int64  `json:"XErr"`
+	Message  string `json:"Message"`
+	Redirect string `json:"Redirect"`
+}
+
+// The known XErr codes.
+const (
+	XErrNoXboxAccount  = 2148916233
+	XErrBanned         = 2148916234
+	XErrUnavailable    = 2148916235
+	XErrAdultVerify    = 2148916236
+	XErrAgeVerify      = 2148916237
+	XErrUnderageFamily = 2148916238
+)
+
+func (x XboxErr) Error() string {
+	var reason string
+	switch x.XErr {
+	case XErrNoXboxAccount:
+		reason = "the account doesn't have an Xbox account"
+	case XErrBanned:
+		reason = "the account is banned from Xbox"
+	case XErrUnavailable:
+		reason = "Xbox Live is not available in the account's country"
+	case XErrAdultVerify, XErrAgeVerify:
+		reason = "the account needs adult verification"
+	case XErrUnderageFamily:
+		reason = "the account is a child and must be added to a Family"
+	default:
+		reason = x.Message
+	}
+	return "msauth: xbox live error " + strconv.FormatInt(x.XErr, 10) + ": " + reason
+}
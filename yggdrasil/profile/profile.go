@@ -0,0 +1,314 @@
+// Package profile implements a client of Mojang's profile and skin services.
+//
+// It looks up the UUID of players by name, fetches the profiles with signed textures,
+// and downloads the skins and capes.
+// The base URLs are configurable, so it also works with other Yggdrasil compatible servers.
+package profile
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"git.konjactw.dev/falloutBot/go-mc/yggdrasil/user"
+)
+
+// ErrNotFound is returned if there is no player with the name or UUID.
+var ErrNotFound = errors.New("profile not found")
+
+// ErrInvalidSignature is returned if the textures aren't signed by the session server.
+var ErrInvalidSignature = errors.New("invalid textures signature")
+
+// bulkLimit is the max number of names per bulk lookup request.
+const bulkLimit = 10
+
+// Profile is a player's game profile.
+type Profile struct {
+	ID         uuid.UUID       `json:"id"`
+	Name       string          `json:"name"`
+	Properties []user.Property `json:"properties"`
+}
+
+// Textures decodes the "textures" property.
+// If verify is true, ErrInvalidSignature is returned if the property isn't signed by Mojang.
+func (p *Profile) Textures(verify bool) (t user.Texture, err error) {
+	return p.textures(verify, nil)
+}
+
+func (p *Profile) textures(verify bool, key *rsa.PublicKey) (t user.Texture, err error) {
+	i := slices.IndexFunc(p.Properties, func(p user.Property) bool { return p.Name == "textures" })
+	if i < 0 {
+		return t, errors.New("textures property not found")
+	}
+	property := p.Properties[i]
+	if verify {
+		valid := property.Verify()
+		if key != nil {
+			valid = property.VerifyWith(key)
+		}
+		if !valid {
+			return t, ErrInvalidSignature
+		}
+	}
+	data, err := base64.StdEncoding.DecodeString(property.Value)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(data, &t)
+	return
+}
+
+// profileJSON is the format of profiles in the responses, whose properties have lower case keys.
+type profileJSON struct {
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	Properties []struct {
+		Name      string `json:"name"`
+		Value     string `json:"value"`
+		Signature string `json:"signature"`
+	} `json:"properties"`
+}
+
+func (p *profileJSON) profile() Profile {
+	profile := Profile{ID: p.ID, Name: p.Name}
+	for _, property := range p.Properties {
+		profile.Properties = append(profile.Properties, user.Property(property))
+	}
+	return profile
+}
+
+// Client requests the profile services.
+// Results are cached for CacheTTL, including the players not found.
+type Client struct {
+	// APIURL is the root of the name lookup API.
+	APIURL string
+	// SessionURL is the root of the session server, which serves the profiles with properties.
+	SessionURL string
+	// HTTPClient is used to send the requests. http.DefaultClient is used if it's nil.
+	HTTPClient *http.Client
+	// SignatureKey verifies the textures. Mojang's key is used if it's nil.
+	SignatureKey *rsa.PublicKey
+	// CacheTTL is how long the results are cached. Nothing is cached if it's zero.
+	CacheTTL time.Duration
+
+	names    cache[string, Profile]
+	profiles cache[uuid.UUID, Profile]
+}
+
+// NewClient returns a Client of Mojang's services, caching results for 10 minutes.
+func NewClient() *Client {
+	return &Client{
+		APIURL:     "https://api.minecraftservices.com",
+		SessionURL: "https://sessionserver.mojang.com",
+		CacheTTL:   time.Minute * 10,
+	}
+}
+
+// LookupName returns the UUID and the correct case of the name.
+// The returned Profile has no properties.
+func (c *Client) LookupName(ctx context.Context, name string) (Profile, error) {
+	key := strings.ToLower(name)
+	if p, err, ok := c.names.get(key); ok {
+		return p, err
+	}
+	var resp profileJSON
+	err := c.get(ctx, c.APIURL+"/minecraft/profile/lookup/name/"+url.PathEscape(name), &resp)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return Profile{}, err
+	}
+	p := resp.profile()
+	c.names.put(key, p, err, c.CacheTTL)
+	return p, err
+}
+
+// LookupNames is the bulk version of LookupName.
+// The players not found are omitted, and the order of the results is unspecified.
+func (c *Client) LookupNames(ctx context.Context, names []string) ([]Profile, error) {
+	var (
+		profiles []Profile
+		missing  []string
+	)
+	for _, name := range names {
+		if p, err, ok := c.names.get(strings.ToLower(name)); !ok {
+			missing = append(missing, name)
+		} else if err == nil {
+			profiles = append(profiles, p)
+		}
+	}
+	for batch := range slices.Chunk(missing, bulkLimit) {
+		var resp []profileJSON
+		if err := c.post(ctx, c.APIURL+"/minecraft/profile/lookup/bulk/byname", batch, &resp); err != nil {
+			return nil, err
+		}
+		found := make(map[string]bool, len(resp))
+		for i := range resp {
+			p := resp[i].profile()
+			found[strings.ToLower(p.Name)] = true
+			c.names.put(strings.ToLower(p.Name), p, nil, c.CacheTTL)
+			profiles = append(profiles, p)
+		}
+		for _, name := range batch {
+			if !found[strings.ToLower(name)] {
+				c.names.put(strings.ToLower(name), Profile{}, ErrNotFound, c.CacheTTL)
+			}
+		}
+	}
+	return profiles, nil
+}
+
+// Profile fetches the profile with the signed properties, including the textures.
+func (c *Client) Profile(ctx context.Context, id uuid.UUID) (Profile, error) {
+	if p, err, ok := c.profiles.get(id); ok {
+		return p, err
+	}
+	var resp profileJSON
+	endpoint := c.SessionURL + "/session/minecraft/profile/" + strings.ReplaceAll(id.String(), "-", "") + "?unsigned=false"
+	err := c.get(ctx, endpoint, &resp)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return Profile{}, err
+	}
+	p := resp.profile()
+	c.profiles.put(id, p, err, c.CacheTTL)
+	return p, err
+}
+
+// Textures fetches the profile and decodes the verified textures.
+func (c *Client) Textures(ctx context.Context, id uuid.UUID) (user.Texture, error) {
+	p, err := c.Profile(ctx, id)
+	if err != nil {
+		return user.Texture{}, err
+	}
+	return p.textures(true, c.SignatureKey)
+}
+
+// DownloadTexture downloads the skin or cape image from the URL in the textures.
+// The downloads are not cached.
+func (c *Client) DownloadTexture(ctx context.Context, textureURL string) (image.Image, error) {
+	resp, err := c.do(ctx, http.MethodGet, textureURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, StatusErr{Code: resp.StatusCode}
+	}
+	return png.Decode(resp.Body)
+}
+
+// StatusErr is returned if the service responds an unexpected status code.
+type StatusErr struct {
+	Code int
+}
+
+func (s StatusErr) Error() string {
+	return fmt.Sprintf("profile service responds status %d", s.Code)
+}
+
+func (c *Client) get(ctx context.Context, endpoint string, v any) error {
+	resp, err := c.do(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	return decodeResp(resp, v)
+}
+
+func (c *Client) post(ctx context.Context, endpoint string, payload, v any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	resp, err := c.do(ctx, http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	return decodeResp(resp, v)
+}
+
+func (c *Client) do(ctx context.Context, method, endpoint string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-agent", "go-mc")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return client.Do(req)
+}
+
+// decodeResp decodes the JSON body, ErrNotFound is returned for 204 and 404.
+func decodeResp(resp *http.Response, v any) error {
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return json.NewDecoder(resp.Body).Decode(v)
+	case http.StatusNoContent, http.StatusNotFound:
+		return ErrNotFound
+	default:
+		return StatusErr{Code: resp.StatusCode}
+	}
+}
+
+// cache is a map with expiry. The zero value is ready to use.
+type cache[K comparable, V any] struct {
+	entries map[K]cacheEntry[V]
+	lock    sync.Mutex
+}
+
+type cacheEntry[V any] struct {
+	val     V
+	err     error
+	expires time.Time
+}
+
+func (c *cache[K, V]) get(key K) (val V, err error, ok bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return
+	}
+	if time.Now().After(e.expires) {
+		delete(c.entries, key)
+		return val, nil, false
+	}
+	return e.val, e.err, true
+}
+
+func (c *cache[K, V]) put(key K, val V, err error, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[K]cacheEntry[V])
+	}
+	now := time.Now()
+	// Drop the expired entries, so the cache doesn't grow forever.
+	for k, e := range c.entries {
+		if now.After(e.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = cacheEntry[V]{val: val, err: err, expires: now.Add(ttl)}
+}
@@ -0,0 +1,178 @@
+package profile
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+var jebID = uuid.MustParse("853c80ef-3c37-49fd-aa49-938b674adae6")
+
+// newStandIn starts a local server serving the profile of jeb_ with textures signed by key.
+func newStandIn(t *testing.T, key *rsa.PrivateKey, requests *atomic.Int32) *httptest.Server {
+	mux := http.NewServeMux()
+	var ts *httptest.Server
+	profile := func() map[string]any {
+		textures := `{"timestamp":0,"profileId":"853c80ef3c3749fdaa49938b674adae6","profileName":"jeb_",` +
+			`"textures":{"SKIN":{"url":"` + ts.URL + `/texture/skin","metadata":{"model":"slim"}}}}`
+		value := base64.StdEncoding.EncodeToString([]byte(textures))
+		hash := sha1.Sum([]byte(value))
+		signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA1, hash[:])
+		if err != nil {
+			t.Error(err)
+		}
+		return map[string]any{
+			"id":   "853c80ef3c3749fdaa49938b674adae6",
+			"name": "jeb_",
+			"properties": []map[string]string{{
+				"name":      "textures",
+				"value":     value,
+				"signature": base64.StdEncoding.EncodeToString(signature),
+			}},
+		}
+	}
+	mux.HandleFunc("GET /minecraft/profile/lookup/name/{name}", func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if !strings.EqualFold(r.PathValue("name"), "jeb_") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"id": "853c80ef3c3749fdaa49938b674adae6", "name": "jeb_"})
+	})
+	mux.HandleFunc("POST /minecraft/profile/lookup/bulk/byname", func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		var names []string
+		_ = json.NewDecoder(r.Body).Decode(&names)
+		if len(names) > bulkLimit {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		resp := []map[string]string{}
+		for _, name := range names {
+			if strings.EqualFold(name, "jeb_") {
+				resp = append(resp, map[string]string{"id": "853c80ef3c3749fdaa49938b674adae6", "name": "jeb_"})
+			}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+	mux.HandleFunc("GET /session/minecraft/profile/{id}", func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.PathValue("id") != "853c80ef3c3749fdaa49938b674adae6" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(profile())
+	})
+	mux.HandleFunc("GET /texture/skin", func(w http.ResponseWriter, r *http.Request) {
+		_ = png.Encode(w, image.NewNRGBA(image.Rect(0, 0, 64, 64)))
+	})
+	ts = httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func newTestClient(t *testing.T) (*Client, *atomic.Int32) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var requests atomic.Int32
+	ts := newStandIn(t, key, &requests)
+	c := NewClient()
+	c.APIURL, c.SessionURL, c.SignatureKey = ts.URL, ts.URL, &key.PublicKey
+	return c, &requests
+}
+
+func TestClient_LookupName(t *testing.T) {
+	c, requests := newTestClient(t)
+	ctx := context.Background()
+
+	p, err := c.LookupName(ctx, "JEB_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.ID != jebID || p.Name != "jeb_" {
+		t.Errorf("profile mismatch: %+v", p)
+	}
+	if _, err := c.LookupName(ctx, "jeb_"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.LookupName(ctx, "Notch"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("want ErrNotFound, got %v", err)
+	}
+	if _, err := c.LookupName(ctx, "notch"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("want cached ErrNotFound, got %v", err)
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("want 2 requests with cache, got %d", n)
+	}
+}
+
+func TestClient_LookupNames(t *testing.T) {
+	c, requests := newTestClient(t)
+	names := []string{"jeb_"}
+	for range 14 {
+		names = append(names, "player"+uuid.NewString()[:8])
+	}
+	profiles, err := c.LookupNames(context.Background(), names)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(profiles) != 1 || profiles[0].ID != jebID {
+		t.Errorf("profiles mismatch: %+v", profiles)
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("want 2 batches, got %d", n)
+	}
+	if _, err := c.LookupName(context.Background(), names[1]); !errors.Is(err, ErrNotFound) {
+		t.Errorf("want cached ErrNotFound, got %v", err)
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("missing names should be cached, got %d requests", n)
+	}
+}
+
+func TestClient_Textures(t *testing.T) {
+	c, _ := newTestClient(t)
+	ctx := context.Background()
+
+	textures, err := c.Textures(ctx, jebID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if textures.Textures.SKIN.Metadata.Model != "slim" {
+		t.Errorf("want slim model, got %q", textures.Textures.SKIN.Metadata.Model)
+	}
+	skin, err := c.DownloadTexture(ctx, textures.Textures.SKIN.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if skin.Bounds().Dx() != 64 {
+		t.Errorf("skin size mismatch: %v", skin.Bounds())
+	}
+
+	p, err := c.Profile(ctx, jebID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Textures(true); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("textures signed by a test key shouldn't pass Mojang's key, got %v", err)
+	}
+	if _, err := c.Profile(ctx, uuid.New()); !errors.Is(err, ErrNotFound) {
+		t.Errorf("want ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,152 @@
+package user
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"os"
+	"sync"
+	"time"
+)
+
+// KeyPair is the parsed KeyPairResp, the player's certificate used to sign chat messages.
+type KeyPair struct {
+	PrivateKey *rsa.PrivateKey
+	// PublicKey is the public key signed by Mojang, sent to the server in the chat session.
+	PublicKey PublicKey
+	// RefreshedAfter is the time after which a new key pair should be fetched,
+	// which is earlier than PublicKey.ExpiresAt.
+	RefreshedAfter time.Time
+}
+
+// Parse decodes the PEM encoded keys and the signature.
+func (k KeyPairResp) Parse() (*KeyPair, error) {
+	privBlock, _ := pem.Decode([]byte(k.KeyPair.PrivateKey))
+	pubBlock, _ := pem.Decode([]byte(k.KeyPair.PublicKey))
+	if privBlock == nil || pubBlock == nil {
+		return nil, errors.New("pem decode error: no data is found")
+	}
+	// The private key is PKCS #8 in practice, despite the PEM header saying "RSA PRIVATE KEY".
+	var privKey *rsa.PrivateKey
+	if key, err := x509.ParsePKCS8PrivateKey(privBlock.Bytes); err == nil {
+		var ok bool
+		if privKey, ok = key.(*rsa.PrivateKey); !ok {
+			return nil, errors.New("expect RSA private key")
+		}
+	} else if privKey, err = x509.ParsePKCS1PrivateKey(privBlock.Bytes); err != nil {
+		return nil, err
+	}
+	pubKey, err := x509.ParsePKIXPublicKey(pubBlock.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaPubKey, ok := pubKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("expect RSA public key")
+	}
+	signature, err := base64.StdEncoding.DecodeString(k.PublicKeySignatureV2)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyPair{
+		PrivateKey: privKey,
+		PublicKey: PublicKey{
+			ExpiresAt: k.ExpiresAt,
+			PubKey:    rsaPubKey,
+			Signature: signature,
+		},
+		RefreshedAfter: k.RefreshedAfter,
+	}, nil
+}
+
+// KeyManager keeps the player's key pair, caches it on disk, and refreshes it before it expires.
+//
+// The key pair changes after being refreshed.
+// A client should start a new chat session with the new key, by sending a new ChatSessionUpdate packet.
+type KeyManager struct {
+	// CachePath is the file where the key pair is cached. The key pair isn't saved if it's empty.
+	CachePath string
+
+	accessToken string
+	resp        *KeyPairResp
+	keyPair     *KeyPair
+	lock        sync.Mutex
+}
+
+// NewKeyManager creates a KeyManager fetching the key pair with the Minecraft access token.
+func NewKeyManager(accessToken, cachePath string) *KeyManager {
+	return &KeyManager{CachePath: cachePath, accessToken: accessToken}
+}
+
+// SetAccessToken changes the access token used to fetch the next key pair,
+// which should be called after the token is refreshed.
+func (m *KeyManager) SetAccessToken(accessToken string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.accessToken = accessToken
+}
+
+// KeyPair returns the current key pair, and fetches a new one if it should be refreshed.
+// If the fetching fails but the current key pair isn't expired yet, the current one is returned.
+func (m *KeyManager) KeyPair(ctx context.Context) (*KeyPair, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.keyPair == nil && m.CachePath != "" {
+		// The cache file is optional, the key pair is fetched if it can't be loaded.
+		_ = m.load()
+	}
+	now := time.Now()
+	if m.keyPair != nil && now.Before(m.keyPair.RefreshedAfter) {
+		return m.keyPair, nil
+	}
+	resp, err := fetchKeyPair(ctx, m.accessToken)
+	if err == nil {
+		err = m.update(resp)
+	}
+	if err != nil {
+		if m.keyPair != nil && now.Before(m.keyPair.PublicKey.ExpiresAt) {
+			return m.keyPair, nil
+		}
+		return nil, err
+	}
+	if m.CachePath != "" {
+		if err := m.save(); err != nil {
+			return nil, err
+		}
+	}
+	return m.keyPair, nil
+}
+
+func (m *KeyManager) update(resp KeyPairResp) error {
+	keyPair, err := resp.Parse()
+	if err != nil {
+		return err
+	}
+	m.resp, m.keyPair = &resp, keyPair
+	return nil
+}
+
+func (m *KeyManager) load() error {
+	data, err := os.ReadFile(m.CachePath)
+	if err != nil {
+		return err
+	}
+	var resp KeyPairResp
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return err
+	}
+	return m.update(resp)
+}
+
+func (m *KeyManager) save() error {
+	data, err := json.Marshal(m.resp)
+	if err != nil {
+		return err
+	}
+	// The private key is a credential, only the current user can read it.
+	return os.WriteFile(m.CachePath, data, 0o600)
+}
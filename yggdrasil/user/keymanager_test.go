@@ -0,0 +1,89 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newKeyPairResp(t *testing.T, refreshedAfter time.Time) KeyPairResp {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	priv, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var resp KeyPairResp
+	resp.KeyPair.PrivateKey = string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: priv}))
+	resp.KeyPair.PublicKey = string(pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: pub}))
+	resp.PublicKeySignatureV2 = base64.StdEncoding.EncodeToString([]byte("signature"))
+	resp.RefreshedAfter = refreshedAfter
+	resp.ExpiresAt = refreshedAfter.Add(time.Hour)
+	return resp
+}
+
+func TestKeyManager(t *testing.T) {
+	var requests atomic.Int32
+	refreshedAfter := time.Now().Add(time.Hour)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/player/certificates" || r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(newKeyPairResp(t, refreshedAfter))
+	}))
+	defer ts.Close()
+	defer func(url string) { ServicesURL = url }(ServicesURL)
+	ServicesURL = ts.URL
+
+	path := filepath.Join(t.TempDir(), "keypair.json")
+	m := NewKeyManager("token", path)
+	first, err := m.KeyPair(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.KeyPair(context.Background()); err != nil || requests.Load() != 1 {
+		t.Errorf("key pair should be reused, %d requests, err: %v", requests.Load(), err)
+	}
+
+	// Another manager loads the cache file.
+	cached, err := NewKeyManager("token", path).KeyPair(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requests.Load() != 1 || !cached.PrivateKey.Equal(first.PrivateKey) {
+		t.Error("key pair should be loaded from the cache file")
+	}
+
+	// The key pair is refreshed, but the current one is still usable if the fetching fails.
+	m.keyPair.RefreshedAfter = time.Now()
+	m.SetAccessToken("invalid")
+	if kp, err := m.KeyPair(context.Background()); err != nil || kp != first {
+		t.Errorf("unexpired key pair should be returned, err: %v", err)
+	}
+	m.SetAccessToken("token")
+	refreshed, err := m.KeyPair(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if refreshed.PrivateKey.Equal(first.PrivateKey) {
+		t.Error("key pair should be refreshed")
+	}
+}
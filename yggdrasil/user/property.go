@@ -1,6 +1,10 @@
 package user
 
 import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha1"
+	"encoding/base64"
 	"io"
 
 	"github.com/google/uuid"
@@ -34,6 +38,26 @@ func (p *Property) ReadFrom(r io.Reader) (n int64, err error) {
 	return
 }
 
+// Verify reports whether the property is signed by Mojang's session server.
+// It works like com.mojang.authlib.properties.Property#isSignatureValid.
+func (p Property) Verify() bool {
+	return p.VerifyWith(pubKey)
+}
+
+// VerifyWith is the same as Verify, but with the key of another session server,
+// such as an authlib-injector compatible server.
+func (p Property) VerifyWith(key *rsa.PublicKey) bool {
+	if p.Signature == "" {
+		return false
+	}
+	signature, err := base64.StdEncoding.DecodeString(p.Signature)
+	if err != nil {
+		return false
+	}
+	hash := sha1.Sum([]byte(p.Value))
+	return rsa.VerifyPKCS1v15(key, crypto.SHA1, hash[:], signature) == nil
+}
+
 // Texture includes player's skin and cape
 type Texture struct {
 	TimeStamp int64     `json:"timestamp"`
@@ -42,6 +66,10 @@ type Texture struct {
 	Textures  struct {
 		SKIN, CAPE struct {
 			URL string `json:"url"`
+			// Metadata.Model is "slim" for the skins of the Alex model, and empty for the Steve model.
+			Metadata struct {
+				Model string `json:"model"`
+			} `json:"metadata"`
 		}
 	} `json:"textures"`
 }
@@ -3,11 +3,15 @@ package user
 import (
 	"crypto"
 	"crypto/rsa"
+	"crypto/sha1"
 	"crypto/x509"
+	"encoding/binary"
 	"errors"
 	"io"
 	"time"
 
+	"github.com/google/uuid"
+
 	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
 )
 
@@ -58,8 +62,14 @@ func (p *PublicKey) ReadFrom(r io.Reader) (n int64, err error) {
 	return n, nil
 }
 
+// Expired reports whether the key is expired at the time.
+// The players must refresh their key pairs and start a new chat session after it's expired.
+func (p *PublicKey) Expired(now time.Time) bool {
+	return !now.Before(p.ExpiresAt)
+}
+
 func (p *PublicKey) Verify() bool {
-	if p.ExpiresAt.Before(time.Now()) {
+	if p.Expired(time.Now()) {
 		return false
 	}
 	encoded, err := x509.MarshalPKIXPublicKey(p.PubKey)
@@ -72,3 +82,23 @@ func (p *PublicKey) Verify() bool {
 func (p *PublicKey) VerifyMessage(hash, signature []byte) error {
 	return rsa.VerifyPKCS1v15(p.PubKey, crypto.SHA256, hash, signature)
 }
+
+// VerifyProfile reports whether the key isn't expired and is signed for the player.
+// It has the same functional as
+// net.minecraft.world.entity.player.ProfilePublicKey.Data#validateSignature since 1.19.1,
+// which signs the UUID of the player, the expiry time and the key together.
+func (p *PublicKey) VerifyProfile(id uuid.UUID) bool {
+	if p.Expired(time.Now()) {
+		return false
+	}
+	encoded, err := x509.MarshalPKIXPublicKey(p.PubKey)
+	if err != nil {
+		return false
+	}
+	payload := make([]byte, 0, len(id)+8+len(encoded))
+	payload = append(payload, id[:]...)
+	payload = binary.BigEndian.AppendUint64(payload, uint64(p.ExpiresAt.UnixMilli()))
+	payload = append(payload, encoded...)
+	hash := sha1.Sum(payload)
+	return rsa.VerifyPKCS1v15(pubKey, crypto.SHA1, hash[:], p.Signature) == nil
+}
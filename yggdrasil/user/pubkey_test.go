@@ -0,0 +1,59 @@
+package user
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestPublicKey_VerifyProfile(t *testing.T) {
+	// sign the keys by a test key instead of Mojang's
+	mojang, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func(key *rsa.PublicKey) { pubKey = key }(pubKey)
+	pubKey = &mojang.PublicKey
+
+	player, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signedKey := func(id uuid.UUID, expiresAt time.Time) *PublicKey {
+		encoded, err := x509.MarshalPKIXPublicKey(&player.PublicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		payload := append(id[:], binary.BigEndian.AppendUint64(nil, uint64(expiresAt.UnixMilli()))...)
+		hash := sha1.Sum(append(payload, encoded...))
+		signature, err := rsa.SignPKCS1v15(rand.Reader, mojang, crypto.SHA1, hash[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &PublicKey{ExpiresAt: expiresAt, PubKey: &player.PublicKey, Signature: signature}
+	}
+
+	id := uuid.New()
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Millisecond)
+	if !signedKey(id, expiresAt).VerifyProfile(id) {
+		t.Error("key signed for the player should be valid")
+	}
+	if signedKey(id, expiresAt).VerifyProfile(uuid.New()) {
+		t.Error("key signed for another player shouldn't be valid")
+	}
+	if signedKey(id, time.Now().Add(-time.Hour)).VerifyProfile(id) {
+		t.Error("expired key shouldn't be valid")
+	}
+	modified := signedKey(id, expiresAt)
+	modified.ExpiresAt = modified.ExpiresAt.Add(time.Hour)
+	if modified.VerifyProfile(id) {
+		t.Error("key with modified expiry time shouldn't be valid")
+	}
+}
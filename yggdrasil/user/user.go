@@ -1,6 +1,7 @@
 package user
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"encoding/pem"
@@ -8,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
 	"time"
 
 	pk "git.konjactw.dev/falloutBot/go-mc/net/packet"
@@ -44,22 +46,51 @@ func (k KeyPairResp) WriteTo(w io.Writer) (int64, error) {
 	}.WriteTo(w)
 }
 
+// GetOrFetchKeyPair returns the key pair of the access token,
+// which is cached in memory until it should be refreshed.
+// Use KeyManager to cache the key pair on disk.
 func GetOrFetchKeyPair(accessToken string) (KeyPairResp, error) {
-	return fetchKeyPair(accessToken) // TODO: cache
+	keyPairsLock.Lock()
+	defer keyPairsLock.Unlock()
+	if k, ok := keyPairs[accessToken]; ok && time.Now().Before(k.RefreshedAfter) {
+		return k, nil
+	}
+	k, err := fetchKeyPair(context.Background(), accessToken)
+	if err != nil {
+		return k, err
+	}
+	if keyPairs == nil {
+		keyPairs = make(map[string]KeyPairResp)
+	}
+	for token, k := range keyPairs {
+		if time.Now().After(k.ExpiresAt) {
+			delete(keyPairs, token)
+		}
+	}
+	keyPairs[accessToken] = k
+	return k, nil
 }
 
-func fetchKeyPair(accessToken string) (KeyPairResp, error) {
+var (
+	keyPairs     map[string]KeyPairResp
+	keyPairsLock sync.Mutex
+)
+
+func fetchKeyPair(ctx context.Context, accessToken string) (KeyPairResp, error) {
 	var keyPairResp KeyPairResp
-	err := post("/player/certificates", accessToken, &keyPairResp)
+	err := post(ctx, "/player/certificates", accessToken, &keyPairResp)
 	return keyPairResp, err
 }
 
-func post(endpoint string, accessToken string, resp any) error {
-	rowResp, err := rawPost(endpoint, accessToken)
+func post(ctx context.Context, endpoint string, accessToken string, resp any) error {
+	rowResp, err := rawPost(ctx, endpoint, accessToken)
 	if err != nil {
 		return fmt.Errorf("request fail: %v", err)
 	}
 	defer rowResp.Body.Close()
+	if rowResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request fail: %s", rowResp.Status)
+	}
 	err = json.NewDecoder(rowResp.Body).Decode(resp)
 	if err != nil {
 		return fmt.Errorf("parse resp fail: %v", err)
@@ -68,8 +99,9 @@ func post(endpoint string, accessToken string, resp any) error {
 	return nil
 }
 
-func rawPost(endpoint string, accessToken string) (*http.Response, error) {
-	PostRequest, err := http.NewRequest(
+func rawPost(ctx context.Context, endpoint string, accessToken string) (*http.Response, error) {
+	PostRequest, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodPost,
 		ServicesURL+endpoint, nil)
 	if err != nil {